@@ -1,13 +1,17 @@
 package turing
 
 import (
+	"fmt"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
 )
 
 // The m-configurations (or rather, m-functions) below are helper
-// functions to be used eventually in Turing's Universal Machine
+// functions to be used eventually in Turing's Universal Machine. They are
+// shared, so are only ever read here: tables are given copies of them
+// (see `StandardSkeletons` and `FindLeftMostSkeleton`, say).
 var (
 	// From the m-configuration `f` the machine finds the
 	// symbol of form `a` which is farthest to the left (the "first a")
@@ -139,26 +143,155 @@ var (
 	}
 )
 
-// Returns all helper functions
-func allhelperFunctions() []MConfiguration {
-	helperFunctions := []MConfiguration{}
-	helperFunctions = append(helperFunctions, findLeftMost...)
-	helperFunctions = append(helperFunctions, erase...)
-	helperFunctions = append(helperFunctions, printAtTheEnd...)
-	helperFunctions = append(helperFunctions, findLeft...)
-	helperFunctions = append(helperFunctions, findRight...)
-	helperFunctions = append(helperFunctions, copy...)
-	helperFunctions = append(helperFunctions, copyAndErase...)
-	helperFunctions = append(helperFunctions, replace...)
-	helperFunctions = append(helperFunctions, copyAndReplace...)
-	helperFunctions = append(helperFunctions, compare...)
-	helperFunctions = append(helperFunctions, compareAndErase...)
-	helperFunctions = append(helperFunctions, findRightMost...)
-	helperFunctions = append(helperFunctions, printAtTheEnd2...)
-	helperFunctions = append(helperFunctions, printAtTheEnd2...)
-	helperFunctions = append(helperFunctions, copyAndErase2...)
-	helperFunctions = append(helperFunctions, eraseAll...)
-	return helperFunctions
+type (
+	// Describes one of the m-functions of `StandardSkeletons`
+	SkeletonFunction struct {
+		// The m-function's name, as called in a table (i.e. `f` for `f(C, B, a)`)
+		Name string
+
+		// The amount of parameters the m-function takes
+		Arity int
+
+		// What the m-function does, in Turing's words
+		Description string
+	}
+)
+
+// The m-functions of `StandardSkeletons`, in order
+var standardSkeletonFunctions = []SkeletonFunction{
+	{"f", 3, "Finds the first (leftmost) `a` -> `C`. If there is no `a` -> `B`."},
+	{"e", 3, "Erases the first `a` -> `C`. If there is no `a` -> `B`."},
+	{"e", 2, "Erases all letters `a` -> `B`."},
+	{"pe", 2, "Prints `b` at the end of the sequence of symbols -> `C`."},
+	{"l", 1, "Moves to the left -> `C`."},
+	{"fl", 3, "Does the same as `f(C, B, a)`, but moves to the left before -> `C`."},
+	{"r", 1, "Moves to the right -> `C`."},
+	{"fr", 3, "Does the same as `f(C, B, a)`, but moves to the right before -> `C`."},
+	{"c", 3, "Writes at the end the first symbol marked `a` -> `C`."},
+	{"ce", 3, "Writes at the end the first symbol marked `a` and erases the `a` -> `C`."},
+	{"ce", 2, "Copies down in order at the end all symbols marked `a` and erases the letters `a` -> `B`."},
+	{"re", 4, "Replaces the first `a` by `b` -> `C`. If there is no `a` -> `B`."},
+	{"re", 3, "Replaces all letters `a` by `b` -> `B`."},
+	{"cr", 4, "Writes at the end the first symbol marked `a` and replaces the `a` by `b` -> `C`."},
+	{"cr", 3, "Copies down in order at the end all symbols marked `a`, replacing the letters `a` by `b` -> `B`."},
+	{"cp", 5, "Compares the first symbol marked `a` with the first marked `b`. If there is neither -> `E`. If they are alike -> `C`, otherwise -> `A`."},
+	{"cpe", 5, "Does the same as `cp(C, A, E, a, b)`, but if they are alike the first `a` and `b` are erased."},
+	{"cpe", 4, "Compares the sequence of symbols marked `a` with the sequence marked `b`. -> `E` if they are alike, otherwise -> `A`. Some of the symbols `a` and `b` are erased."},
+	{"g", 1, "Finds the last symbol -> `C`."},
+	{"g", 2, "Finds the last symbol of form `a` -> `C`."},
+	{"pe2", 3, "Prints `a b` at the end -> `C`."},
+	{"ce2", 3, "Copies down at the end the symbols marked `a`, then those marked `b`, and erases the `a` and `b` -> `B`."},
+	{"ce3", 4, "Like `ce2(B, a, b)`, for the symbols marked `a`, `b` and `y`."},
+	{"ce4", 5, "Like `ce2(B, a, b)`, for the symbols marked `a`, `b`, `y` and `z`."},
+	{"ce5", 6, "Like `ce2(B, a, b)`, for the symbols marked `a`, `b`, `y`, `z` and `w`."},
+	{"e", 1, "Erases the marks from all marked symbols -> `C`."},
+}
+
+// Returns the m-configurations of every skeleton table (m-function) Turing defines for the Universal
+// Machine, each once, to be added to an abbreviated table's m-configurations (see also `SkeletonLibrary`)
+func StandardSkeletons() []MConfiguration {
+	mConfigurations := []MConfiguration{}
+	for _, skeleton := range [][]MConfiguration{
+		findLeftMost,
+		erase,
+		printAtTheEnd,
+		findLeft,
+		findRight,
+		copy,
+		copyAndErase,
+		replace,
+		copyAndReplace,
+		compare,
+		compareAndErase,
+		findRightMost,
+		printAtTheEnd2,
+		copyAndErase2,
+		eraseAll,
+	} {
+		mConfigurations = append(mConfigurations, cloneMConfigurations(skeleton)...)
+	}
+	return mConfigurations
+}
+
+// Returns a copy of the m-configurations of `f(C, B, a)`, which finds the first (leftmost) `a`
+func FindLeftMostSkeleton() []MConfiguration {
+	return cloneMConfigurations(findLeftMost)
+}
+
+// Returns a copy of the m-configurations of `e(C, B, a)` and `e(B, a)`, which erase the first `a` and all letters `a`
+func EraseSkeleton() []MConfiguration {
+	return cloneMConfigurations(erase)
+}
+
+// Returns a copy of the m-configurations of `pe(C, b)`, which prints `b` at the end of the sequence of symbols
+func PrintAtTheEndSkeleton() []MConfiguration {
+	return cloneMConfigurations(printAtTheEnd)
+}
+
+// Returns a copy of the m-configurations of `l(C)` and `fl(C, B, a)`, which move to the left (after finding the first `a`)
+func FindLeftSkeleton() []MConfiguration {
+	return cloneMConfigurations(findLeft)
+}
+
+// Returns a copy of the m-configurations of `r(C)` and `fr(C, B, a)`, which move to the right (after finding the first `a`)
+func FindRightSkeleton() []MConfiguration {
+	return cloneMConfigurations(findRight)
+}
+
+// Returns a copy of the m-configurations of `c(C, B, a)`, which writes at the end the first symbol marked `a`
+func CopySkeleton() []MConfiguration {
+	return cloneMConfigurations(copy)
+}
+
+// Returns a copy of the m-configurations of `ce(C, B, a)` and `ce(B, a)`, which copy down symbols marked `a` and erase the `a`
+func CopyAndEraseSkeleton() []MConfiguration {
+	return cloneMConfigurations(copyAndErase)
+}
+
+// Returns a copy of the m-configurations of `re(C, B, a, b)` and `re(B, a, b)`, which replace the first `a` (or all letters `a`) by `b`
+func ReplaceSkeleton() []MConfiguration {
+	return cloneMConfigurations(replace)
+}
+
+// Returns a copy of the m-configurations of `cr(C, B, a, b)` and `cr(B, a, b)`, which copy down symbols marked `a` and replace the `a` by `b`
+func CopyAndReplaceSkeleton() []MConfiguration {
+	return cloneMConfigurations(copyAndReplace)
+}
+
+// Returns a copy of the m-configurations of `cp(C, A, E, a, b)`, which compares the first symbols marked `a` and `b`
+func CompareSkeleton() []MConfiguration {
+	return cloneMConfigurations(compare)
+}
+
+// Returns a copy of the m-configurations of `cpe(C, A, E, a, b)` and `cpe(A, E, a, b)`, which compare and erase symbols marked `a` and `b`
+func CompareAndEraseSkeleton() []MConfiguration {
+	return cloneMConfigurations(compareAndErase)
+}
+
+// Returns a copy of the m-configurations of `g(C)` and `g(C, a)`, which find the last symbol (of form `a`)
+func FindRightMostSkeleton() []MConfiguration {
+	return cloneMConfigurations(findRightMost)
+}
+
+// Returns a copy of the m-configurations of `pe2(C, a, b)`, which prints `a b` at the end
+func PrintAtTheEnd2Skeleton() []MConfiguration {
+	return cloneMConfigurations(printAtTheEnd2)
+}
+
+// Returns a copy of the m-configurations of `ce2(B, a, b)` to `ce5(B, a, b, y, z, w)`, which copy down and erase the symbols of several marks
+func CopyAndErase2Skeleton() []MConfiguration {
+	return cloneMConfigurations(copyAndErase2)
+}
+
+// Returns a copy of the m-configurations of `e(C)`, which erases the marks from all marked symbols
+func EraseAllSkeleton() []MConfiguration {
+	return cloneMConfigurations(eraseAll)
+}
+
+// Returns a description of every m-function of `StandardSkeletons` that a table may call, in the
+// order they are defined. Those only called by other m-functions (such as `f1`) are left out.
+func StandardSkeletonFunctions() []SkeletonFunction {
+	return slices.Clone(standardSkeletonFunctions)
 }
 
 // Input for an Abbreviated Table
@@ -166,11 +299,46 @@ type AbbreviatedTableInput MachineInput
 
 // Gives MachineInput for the abbreviated table. This requires "compiling" the abbreviated table.
 func NewAbbreviatedTable(input AbbreviatedTableInput) MachineInput {
+	machineInput, _ := CompileAbbreviatedTable(input)
+	return machineInput
+}
+
+// Gives MachineInput for the abbreviated table, like `NewAbbreviatedTable`. Also returns
+// an error if the table calls an m-function it does not define (`ErrUnknownMFunction`)
+// or contains an operation that is not valid (`ErrInvalidOperation`).
+func CompileAbbreviatedTable(input AbbreviatedTableInput) (MachineInput, error) {
+	span := startSpan(input.Tracer, SpanCompileAbbreviatedTable, SpanAttribute{"turing.m_configurations", len(input.MConfigurations)})
+	at := &abbreviatedTable{
+		input: input,
+	}
+
+	machineInput := at.toMachineInput()
+	endSpan(span, SpanAttribute{"turing.compiled_m_configurations", len(machineInput.MConfigurations)})
+	return machineInput, at.err
+}
+
+// Maps the m-configurations of a compiled abbreviated table to the m-function calls they were compiled
+// from (i.e. `q3` to `f(pe1(b,0),b,e)`), so that compiled tables can be traced back to their source
+type SourceMap map[string]string
+
+// Compiles the abbreviated table like `CompileAbbreviatedTable`, also returning the m-function call each
+// compiled m-configuration stands for
+func CompileAbbreviatedTableWithSourceMap(input AbbreviatedTableInput) (MachineInput, SourceMap, error) {
 	at := &abbreviatedTable{
 		input: input,
 	}
+	machineInput := at.toMachineInput()
+	sourceMap := SourceMap{}
+	for call, name := range at.newMConfigurationNames {
+		sourceMap[name] = call
+	}
+	return machineInput, sourceMap, at.err
+}
 
-	return at.toMachineInput()
+// The symbols a row of an m-function scans, and the values of its scanned-symbol parameters
+type scannedSymbolBinding struct {
+	symbols  []string
+	bindings map[string]string
 }
 
 // Helper struct to compile the abbreviated table
@@ -180,6 +348,7 @@ type abbreviatedTable struct {
 	newMConfigurationNames   map[string]string
 	wasAlreadyInterpretedMap map[string]bool
 	newMConfigurations       []MConfiguration
+	err                      error
 }
 
 // Used when parsing m-functions
@@ -203,14 +372,20 @@ func (at *abbreviatedTable) toMachineInput() MachineInput {
 		startingMConfiguration = at.newMConfigurationName(at.input.StartingMConfiguration, []string{})
 	}
 
-	return MachineInput{
-		MConfigurations:        at.sortedNewMConfigurations(),
-		Tape:                   at.input.Tape,
-		StartingMConfiguration: startingMConfiguration,
-		PossibleSymbols:        at.input.PossibleSymbols,
-		NoneSymbol:             at.input.NoneSymbol,
-		Debug:                  at.input.Debug,
-	}
+	// All other fields carry over from the abbreviated table (copied, so that the compiled table
+	// shares nothing with the abbreviated table, which may be compiled again concurrently)
+	machineInput := MachineInput(at.input)
+	machineInput.MConfigurations = at.sortedNewMConfigurations()
+	machineInput.Tape = slices.Clone(at.input.Tape)
+	machineInput.PossibleSymbols = slices.Clone(at.input.PossibleSymbols)
+	machineInput.HaltingMConfigurations = slices.Clone(at.input.HaltingMConfigurations)
+	machineInput.BackgroundPattern = slices.Clone(at.input.BackgroundPattern)
+	machineInput.BeepMConfigurations = slices.Clone(at.input.BeepMConfigurations)
+	machineInput.Exports = slices.Clone(at.input.Exports)
+	machineInput.Tracer = at.input.Tracer
+	machineInput.SpanRunInterval = at.input.SpanRunInterval
+	machineInput.StartingMConfiguration = startingMConfiguration
+	return machineInput
 }
 
 // Given an m-function call in the form `f(a, b, x(y, z))`, interpret recursively
@@ -225,38 +400,31 @@ func (at *abbreviatedTable) interpretMFunction(name string, params []string) str
 		at.markAsInterpreted(name, params)
 	}
 
+	// An m-function that is called must be defined (m-configurations that are not defined halt the machine)
+	mFunctions := at.findMFunctions(name, len(params))
+	if len(mFunctions) == 0 && len(params) > 0 && at.err == nil {
+		at.err = fmt.Errorf("%w: %s", ErrUnknownMFunction, composeMFunction(name, params))
+	}
+
 	// For each m-function that matches our name and param length, recursively interpret
-	for _, mFunction := range at.findMFunctions(name, len(params)) {
+	for _, mFunction := range mFunctions {
 		// Retrieve the m-function's parameter names
 		_, mFunctionParams := parseMFunction(mFunction.Name)
 
-		// This bit only required to support the scenario Turing outlines in his `c1` (copy) m-function
-		// In this scenario the supplied symbol is read and used as a parameter for operations or the
-		// final m-configuration.
-		symbolValues := []string{}
-		symbolParam, isSymbolParam := at.isSymbolParam(mFunction.Symbols, mFunctionParams)
-		if isSymbolParam {
-			for _, possibleSymbol := range append(at.input.PossibleSymbols, none) {
-				symbolValues = append(symbolValues, possibleSymbol)
-			}
-		} else {
-			symbolValues = append(symbolValues, "")
-		}
-
-		for _, symbolValue := range symbolValues {
-			// Create a substitution map from parameter names to the provided parameter values
-			if isSymbolParam {
-				mFunctionParams = append(mFunctionParams, symbolParam)
-				params = append(params, symbolValue)
-			}
-			substitutionMap := createSubstitutionMap(mFunctionParams, params)
+		// Turing's `c1` (copy) m-function reads the scanned symbol into a parameter, used by the
+		// operations or the final m-configuration. Rows may read into any number of such parameters,
+		// so the row is interpreted once for each symbol it could scan.
+		substitutionMap := createSubstitutionMap(mFunctionParams, params)
+		for _, scanned := range at.scannedSymbolBindings(mFunction.Symbols, mFunctionParams, substitutionMap) {
+			rowSubstitutionMap := maps.Clone(substitutionMap)
+			maps.Copy(rowSubstitutionMap, scanned.bindings)
 
 			// Parse the final m-configuration (it may be a function)
 			finalMFunctionName, finalMFunctionParams := parseMFunction(mFunction.FinalMConfiguration)
 
 			// Perform substitutions on both the final m-configuration name and params
-			substitutedFinalMFunctionName := at.substituteFinalMConfigurationName(finalMFunctionName, substitutionMap)
-			substitutedFinalMFunctionParams := at.substituteFinalMConfigurationParams(finalMFunctionParams, substitutionMap)
+			substitutedFinalMFunctionName := at.substituteFinalMConfigurationName(finalMFunctionName, rowSubstitutionMap)
+			substitutedFinalMFunctionParams := at.substituteFinalMConfigurationParams(finalMFunctionParams, rowSubstitutionMap)
 
 			// This block recursively attempts to interpret whatever the final m-configuration is (potentially an m-function to follow)
 			var newFinalMConfigurationName string
@@ -273,8 +441,8 @@ func (at *abbreviatedTable) interpretMFunction(name string, params []string) str
 			// Substitute Symbols and Save m-configuration
 			at.saveMConfiguration(MConfiguration{
 				Name:                newMConfigurationName,
-				Symbols:             at.substituteSymbols(mFunction.Symbols, substitutionMap),
-				Operations:          at.substituteOperations(mFunction.Operations, substitutionMap),
+				Symbols:             scanned.symbols,
+				Operations:          at.substituteOperations(mFunction.Operations, rowSubstitutionMap),
 				FinalMConfiguration: newFinalMConfigurationName,
 			})
 		}
@@ -296,20 +464,58 @@ func (at *abbreviatedTable) findMFunctions(name string, numParams int) []MConfig
 	return mFunctions
 }
 
-func (at *abbreviatedTable) isSymbolParam(symbols []string, mFunctionParams []string) (string, bool) {
-	if len(symbols) != 1 {
-		return "", false
+// Returns the symbols a row of an m-function scans, and the values of the parameters it reads the
+// scanned symbol into. A row without such parameters is interpreted once, with its symbols substituted.
+// Otherwise it is interpreted once for each symbol it could scan (the row's other symbols, and every
+// possible symbol), with its parameters bound to that symbol.
+func (at *abbreviatedTable) scannedSymbolBindings(symbols []string, mFunctionParams []string, substitutions map[string]string) []scannedSymbolBinding {
+	symbolParams := []string{}
+	for _, symbol := range symbols {
+		if at.isSymbolParam(symbol, mFunctionParams) {
+			symbolParams = append(symbolParams, symbol)
+		}
+	}
+	if len(symbolParams) == 0 {
+		return []scannedSymbolBinding{{symbols: at.substituteSymbols(symbols, substitutions)}}
+	}
+
+	scannedSymbols := []string{}
+	for _, symbol := range symbols {
+		if slices.Contains(symbolParams, symbol) {
+			scannedSymbols = append(scannedSymbols, at.input.PossibleSymbols...)
+			scannedSymbols = append(scannedSymbols, none)
+		} else {
+			scannedSymbols = append(scannedSymbols, at.substituteSymbols([]string{symbol}, substitutions)...)
+		}
 	}
-	symbol := symbols[0]
+	bindings := []scannedSymbolBinding{}
+	seen := map[string]bool{}
+	for _, scannedSymbol := range scannedSymbols {
+		if seen[scannedSymbol] {
+			continue
+		}
+		seen[scannedSymbol] = true
+		binding := scannedSymbolBinding{
+			symbols:  []string{scannedSymbol},
+			bindings: map[string]string{},
+		}
+		for _, symbolParam := range symbolParams {
+			binding.bindings[symbolParam] = scannedSymbol
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
+
+// Returns true if the symbol of a row is a parameter the scanned symbol is read into: neither a
+// possible symbol nor a parameter of the m-function
+func (at *abbreviatedTable) isSymbolParam(symbol string, mFunctionParams []string) bool {
 	if strings.Contains(symbol, not) || strings.Contains(symbol, any) {
-		return "", false
+		return false
 	}
-	notAPossibleSymbol := !slices.Contains(append(at.input.PossibleSymbols, none), symbol)
+	notAPossibleSymbol := symbol != none && !slices.Contains(at.input.PossibleSymbols, symbol)
 	notAMFunctionParam := !slices.Contains(mFunctionParams, symbol)
-	if notAPossibleSymbol && notAMFunctionParam {
-		return symbol, true
-	}
-	return "", false
+	return notAPossibleSymbol && notAMFunctionParam
 }
 
 // For the Symbols column of an m-function, substitute any m-function params with values
@@ -337,9 +543,15 @@ func (at *abbreviatedTable) substituteSymbols(mFunctionSymbols []string, substit
 func (at *abbreviatedTable) substituteOperations(mFunctionOperations []string, substitutions map[string]string) []string {
 	substitutedOperations := []string{}
 	for _, mFunctionOperation := range mFunctionOperations {
+		if len(mFunctionOperation) == 0 || (operationCode(mFunctionOperation[0]) == printOp && len(mFunctionOperation) == 1) {
+			if at.err == nil {
+				at.err = fmt.Errorf("%w: %q", ErrInvalidOperation, mFunctionOperation)
+			}
+			continue
+		}
 		switch operationCode(mFunctionOperation[0]) {
 		case printOp:
-			mFunctionOperationSymbol := string(mFunctionOperation[1])
+			mFunctionOperationSymbol := mFunctionOperation[1:]
 			if substitutedOperation, ok := substitutions[mFunctionOperationSymbol]; ok {
 				substitutedOperations = append(substitutedOperations, string(printOp)+substitutedOperation)
 
@@ -430,7 +642,7 @@ func (at *abbreviatedTable) markAsInterpreted(mFunctionName string, mFunctionPar
 
 // Returns a sorted slice of the stored interpreted m-configurations
 func (at *abbreviatedTable) sortedNewMConfigurations() []MConfiguration {
-	slices.SortFunc(at.newMConfigurations, func(a, b MConfiguration) int {
+	slices.SortStableFunc(at.newMConfigurations, func(a, b MConfiguration) int {
 		aInt, _ := strconv.Atoi(a.Name[1:])
 		bInt, _ := strconv.Atoi(b.Name[1:])
 		return aInt - bInt
@@ -2,7 +2,11 @@ package turing
 
 import (
 	"reflect"
+	"slices"
+	"strconv"
 	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
 )
 
 var (
@@ -53,7 +57,7 @@ func TestFindLeftMost(t *testing.T) {
 	}
 
 	mConfigurations = append(mConfigurations, printAndHalt)
-	mConfigurations = append(mConfigurations, findLeftMost...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
 	possibleSymbols := []string{"e", "x", "y", "0", "1"}
 
 	t.Run("FindFirstZero", func(t *testing.T) {
@@ -64,7 +68,7 @@ func TestFindLeftMost(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee1 1 x 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee1 1 x 0")
 	})
 
 	t.Run("NoZero", func(t *testing.T) {
@@ -75,7 +79,7 @@ func TestFindLeftMost(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee1 1  y")
+		turingtest.TapePrefix(t, m.TapeString(), "ee1 1  y")
 	})
 }
 
@@ -87,12 +91,12 @@ func TestErase(t *testing.T) {
 
 	mConfigurations := []MConfiguration{}
 	mConfigurations = append(mConfigurations, printAndHalt)
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, erase...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
 	possibleSymbols := []string{"e", "0", "z", "x", "y"}
 
 	t.Run("EraseX", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, eraseOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), eraseOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "z", "0", "z"},
@@ -100,11 +104,11 @@ func TestErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0x0z")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0x0z")
 	})
 
 	t.Run("EraseXDoesNotExist", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, eraseOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), eraseOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e"},
@@ -112,11 +116,11 @@ func TestErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee  y")
+		turingtest.TapePrefix(t, m.TapeString(), "ee  y")
 	})
 
 	t.Run("EraseAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, eraseAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), eraseAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "", "z", " ", "z"},
@@ -124,7 +128,7 @@ func TestErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(30)
-		checkTape(t, m.TapeString(), "ee  x")
+		turingtest.TapePrefix(t, m.TapeString(), "ee  x")
 	})
 }
 
@@ -133,8 +137,8 @@ func TestPrintAtTheEnd(t *testing.T) {
 	printAtTheEndTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "pe(halt, x)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
 	mConfigurations = append(mConfigurations, printAtTheEndTest)
 	possibleSymbols := []string{"e", "0", "x"}
 
@@ -146,7 +150,7 @@ func TestPrintAtTheEnd(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0 0 x")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 x")
 	})
 }
 
@@ -158,12 +162,12 @@ func TestFindLeft(t *testing.T) {
 
 	mConfigurations := []MConfiguration{}
 	mConfigurations = append(mConfigurations, printAndHalt)
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "y"}
 
 	t.Run("Left", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, leftTest)
+		mConfigurations := append(slices.Clone(mConfigurations), leftTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e"},
@@ -171,11 +175,11 @@ func TestFindLeft(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0")
 	})
 
 	t.Run("FindLeft", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, findLeftTest)
+		mConfigurations := append(slices.Clone(mConfigurations), findLeftTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "1", " ", "1", " ", "0", " ", "0"},
@@ -183,7 +187,7 @@ func TestFindLeft(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee1 1x0 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee1 1x0 0")
 	})
 }
 
@@ -195,12 +199,12 @@ func TestFindRight(t *testing.T) {
 
 	mConfigurations := []MConfiguration{}
 	mConfigurations = append(mConfigurations, printAndHalt)
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findRight...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindRightSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "y"}
 
 	t.Run("Right", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, rightTest)
+		mConfigurations := append(slices.Clone(mConfigurations), rightTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0"},
@@ -208,11 +212,11 @@ func TestFindRight(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0x")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0x")
 	})
 
 	t.Run("FindRight", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, findRightTest)
+		mConfigurations := append(slices.Clone(mConfigurations), findRightTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "1", " ", "1", " ", "0", " ", "0"},
@@ -220,7 +224,7 @@ func TestFindRight(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee1 1 0x0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee1 1 0x0")
 	})
 }
 
@@ -229,14 +233,14 @@ func TestCopy(t *testing.T) {
 	copyTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "c(halt, halt, x)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, copy...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, CopySkeleton()...)
 	possibleSymbols := []string{"e", "0", "x"}
 
 	t.Run("Copy", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyTest)
+		mConfigurations := append(slices.Clone(mConfigurations), copyTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "0", "x"},
@@ -244,7 +248,7 @@ func TestCopy(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(30)
-		checkTape(t, m.TapeString(), "ee0 0x0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0x0")
 	})
 }
 
@@ -255,16 +259,16 @@ func TestCopyAndErase(t *testing.T) {
 	copyAndEraseAllTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "ce(halt, x)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, erase...)
-	mConfigurations = append(mConfigurations, copy...)
-	mConfigurations = append(mConfigurations, copyAndErase...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
+	mConfigurations = append(mConfigurations, CopySkeleton()...)
+	mConfigurations = append(mConfigurations, CopyAndEraseSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x"}
 
 	t.Run("CopyAndEraseOnce", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyAndEraseOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), copyAndEraseOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "0", "x"},
@@ -272,11 +276,11 @@ func TestCopyAndErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(50)
-		checkTape(t, m.TapeString(), "ee0 0 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 0")
 	})
 
 	t.Run("CopyAndEraseAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyAndEraseAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), copyAndEraseAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "1", "x", "0", "x"},
@@ -284,7 +288,7 @@ func TestCopyAndErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0 1 0 1 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 1 0 1 0")
 	})
 }
 
@@ -295,12 +299,12 @@ func TestReplace(t *testing.T) {
 	replaceAllTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "re(halt, x, y)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, replace...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, ReplaceSkeleton()...)
 	possibleSymbols := []string{"e", "0", "x", "y"}
 
 	t.Run("ReplaceOnce", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, replaceOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), replaceOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", "x"},
@@ -308,11 +312,11 @@ func TestReplace(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0y0x")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0y0x")
 	})
 
 	t.Run("ReplaceAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, replaceAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), replaceAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", "x"},
@@ -320,7 +324,7 @@ func TestReplace(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0y0y")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0y0y")
 	})
 }
 
@@ -331,18 +335,18 @@ func TestCopyAndReplace(t *testing.T) {
 	copyAndReplaceAllTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "cr(halt, x, y)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, erase...)
-	mConfigurations = append(mConfigurations, copy...)
-	mConfigurations = append(mConfigurations, copyAndErase...)
-	mConfigurations = append(mConfigurations, replace...)
-	mConfigurations = append(mConfigurations, copyAndReplace...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
+	mConfigurations = append(mConfigurations, CopySkeleton()...)
+	mConfigurations = append(mConfigurations, CopyAndEraseSkeleton()...)
+	mConfigurations = append(mConfigurations, ReplaceSkeleton()...)
+	mConfigurations = append(mConfigurations, CopyAndReplaceSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "y"}
 
 	t.Run("CopyAndReplaceOnce", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyAndReplaceOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), copyAndReplaceOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "0", "x"},
@@ -350,11 +354,11 @@ func TestCopyAndReplace(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0 0y0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0y0")
 	})
 
 	t.Run("CopyAndReplaceAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyAndReplaceAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), copyAndReplaceAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "1", "x", "0", "x"},
@@ -362,7 +366,7 @@ func TestCopyAndReplace(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0 1y0y1 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 1y0y1 0")
 	})
 }
 
@@ -375,14 +379,14 @@ func TestCompare(t *testing.T) {
 	compareEqualTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "cp(pe(halt, z), halt, halt, x, y)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, compare...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, CompareSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "y", "z"}
 
 	t.Run("CompareNeitherExist", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, compareNeitherExistTest)
+		mConfigurations := append(slices.Clone(mConfigurations), compareNeitherExistTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "0"},
@@ -390,11 +394,11 @@ func TestCompare(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0 0 z")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 z")
 	})
 
 	t.Run("CompareNotEqual", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, compareNotEqualTest)
+		mConfigurations := append(slices.Clone(mConfigurations), compareNotEqualTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "1", "y"},
@@ -402,11 +406,11 @@ func TestCompare(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0x1yz")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0x1yz")
 	})
 
 	t.Run("CompareEqual", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, compareEqualTest)
+		mConfigurations := append(slices.Clone(mConfigurations), compareEqualTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", "y"},
@@ -414,7 +418,7 @@ func TestCompare(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0x0yz")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0x0yz")
 	})
 }
 
@@ -425,16 +429,16 @@ func TestCompareAndErase(t *testing.T) {
 	compareAndEraseAllTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "cpe(halt, halt, x, y)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, compare...)
-	mConfigurations = append(mConfigurations, erase...)
-	mConfigurations = append(mConfigurations, compareAndErase...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, CompareSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
+	mConfigurations = append(mConfigurations, CompareAndEraseSkeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "y"}
 
 	t.Run("CompareAndEraseOnce", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, compareAndEraseOnceTest)
+		mConfigurations := append(slices.Clone(mConfigurations), compareAndEraseOnceTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", "y"},
@@ -442,11 +446,11 @@ func TestCompareAndErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(200)
-		checkTape(t, m.TapeString(), "ee0 0 ")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 ")
 	})
 
 	t.Run("CompareAndEraseAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, compareAndEraseAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), compareAndEraseAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "1", "x", "0", "y", "1", "y"},
@@ -454,7 +458,7 @@ func TestCompareAndErase(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(200)
-		checkTape(t, m.TapeString(), "ee0 1 0 1 ")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 1 0 1 ")
 	})
 }
 
@@ -467,11 +471,11 @@ func TestFindRightMost(t *testing.T) {
 	mConfigurations := []MConfiguration{}
 	mConfigurations = append(mConfigurations, printAndHalt)
 	mConfigurations = append(mConfigurations, printToRightAndHalt)
-	mConfigurations = append(mConfigurations, findRightMost...)
+	mConfigurations = append(mConfigurations, FindRightMostSkeleton()...)
 	possibleSymbols := []string{"e", "x", "0", "1"}
 
 	t.Run("FindEndOfTape", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, findEndOfTapeTest)
+		mConfigurations := append(slices.Clone(mConfigurations), findEndOfTapeTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "1", " ", "0", " ", "1"},
@@ -479,11 +483,11 @@ func TestFindRightMost(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0 1 0 1 x")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 1 0 1 x")
 	})
 
 	t.Run("FindRightMost", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, findRightMostTest)
+		mConfigurations := append(slices.Clone(mConfigurations), findRightMostTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", " ", "1", " ", "0", " ", "1"},
@@ -491,7 +495,7 @@ func TestFindRightMost(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(20)
-		checkTape(t, m.TapeString(), "ee0 1 0x1")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 1 0x1")
 	})
 }
 
@@ -500,9 +504,9 @@ func TestPrintAtTheEnd2(t *testing.T) {
 	printAtTheEndTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "pe2(halt, x, y)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, printAtTheEnd2...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEnd2Skeleton()...)
 	mConfigurations = append(mConfigurations, printAtTheEndTest)
 	possibleSymbols := []string{"e", "0", "x", "y"}
 
@@ -514,7 +518,7 @@ func TestPrintAtTheEnd2(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(30)
-		checkTape(t, m.TapeString(), "ee0 0 x y")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 x y")
 	})
 }
 
@@ -523,17 +527,17 @@ func TestCopyAndErase2(t *testing.T) {
 	copyAndEraseAll2Test := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "ce5(halt, x, s, t, u, v)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, findLeftMost...)
-	mConfigurations = append(mConfigurations, findLeft...)
-	mConfigurations = append(mConfigurations, printAtTheEnd...)
-	mConfigurations = append(mConfigurations, erase...)
-	mConfigurations = append(mConfigurations, copy...)
-	mConfigurations = append(mConfigurations, copyAndErase...)
-	mConfigurations = append(mConfigurations, copyAndErase2...)
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, FindLeftSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
+	mConfigurations = append(mConfigurations, CopySkeleton()...)
+	mConfigurations = append(mConfigurations, CopyAndEraseSkeleton()...)
+	mConfigurations = append(mConfigurations, CopyAndErase2Skeleton()...)
 	possibleSymbols := []string{"e", "0", "1", "x", "s", "t", "u", "v"}
 
 	t.Run("CopyAndEraseAll2", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, copyAndEraseAll2Test)
+		mConfigurations := append(slices.Clone(mConfigurations), copyAndEraseAll2Test)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", "x", "0", "s", "1", "s", "1", "t", "0", "t", "1", "u", "1", "u", "0", "v", "0", "v", "0"},
@@ -541,7 +545,7 @@ func TestCopyAndErase2(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(1500)
-		checkTape(t, m.TapeString(), "ee0 0 0 1 1 0 1 1 0 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 0 1 1 0 1 1 0 0")
 	})
 }
 
@@ -550,11 +554,11 @@ func TestEraseAll(t *testing.T) {
 	eraseAllTest := MConfiguration{"b", []string{"*", " "}, []string{"R", "R"}, "e(halt)"}
 
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, eraseAll...)
+	mConfigurations = append(mConfigurations, EraseAllSkeleton()...)
 	possibleSymbols := []string{"e", "0", "x", "y"}
 
 	t.Run("EraseAll", func(t *testing.T) {
-		mConfigurations := append(mConfigurations, eraseAllTest)
+		mConfigurations := append(slices.Clone(mConfigurations), eraseAllTest)
 		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 			MConfigurations:        mConfigurations,
 			Tape:                   []string{"e", "e", "0", "x", "0", " ", "0", "y"},
@@ -562,6 +566,126 @@ func TestEraseAll(t *testing.T) {
 			StartingMConfiguration: "b",
 		}))
 		m.MoveN(100)
-		checkTape(t, m.TapeString(), "ee0 0 0")
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 0")
 	})
 }
+
+func TestMultipleSymbolParams(t *testing.T) {
+	// `cp2(C)` copies the two symbols it begins on to the end of the sequence. The first is read
+	// into `α` and passed along to the row that reads the second into `β`.
+	mConfigurations := []MConfiguration{
+		{"cp2(C)", []string{"α"}, []string{"R"}, "cp2a(C, α)"},
+		{"cp2a(C, α)", []string{"β"}, []string{"R"}, "pe2(C, α, β)"},
+		{"pe2(C, α, β)", []string{"0", "1"}, []string{"R"}, "pe2(C, α, β)"},
+		{"pe2(C, α, β)", []string{" "}, []string{"Pα", "R", "Pβ"}, "C"},
+		{"b", []string{"*", " "}, []string{}, "cp2(halt)"},
+	}
+
+	for tape, expected := range map[string]string{"10": "1010", "01": "0101", "11": "1111"} {
+		t.Run(tape, func(t *testing.T) {
+			m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
+				MConfigurations:        mConfigurations,
+				Tape:                   ParseTape(tape, ""),
+				PossibleSymbols:        []string{"0", "1"},
+				StartingMConfiguration: "b",
+			}))
+			m.MoveN(100)
+			turingtest.TapePrefix(t, m.TapeString(), expected)
+		})
+	}
+
+	t.Run("WithOtherSymbols", func(t *testing.T) {
+		// The scanned symbol is read into both `α` and `γ`, whether it is `x` or any other symbol
+		m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{}, "f(halt)"},
+				{"f(C)", []string{"x", "α", "γ"}, []string{"E", "R", "Pα", "R", "Pγ"}, "C"},
+			},
+			Tape:                   []string{"1"},
+			PossibleSymbols:        []string{"0", "1", "x"},
+			StartingMConfiguration: "b",
+		}))
+		m.MoveN(100)
+		turingtest.TapePrefix(t, m.TapeString(), " 11")
+	})
+}
+
+func TestStandardSkeletons(t *testing.T) {
+	skeletons := StandardSkeletons()
+	library := NewSkeletonLibrary()
+	library.Register(skeletons...)
+
+	// Every row is included once
+	rows := map[string]bool{}
+	for _, mConfiguration := range skeletons {
+		if rows[rowKey(mConfiguration)] {
+			t.Errorf("got %s on %q more than once", mConfiguration.Name, mConfiguration.Symbols)
+		}
+		rows[rowKey(mConfiguration)] = true
+	}
+
+	// Every described m-function is defined
+	for _, function := range StandardSkeletonFunctions() {
+		key := function.Name + "/" + strconv.Itoa(function.Arity)
+		if !slices.Contains(library.Names(), key) {
+			t.Errorf("got no m-function %s", key)
+		}
+	}
+
+	// The skeletons are copied
+	skeletons[0].Symbols[0] = "x"
+	if StandardSkeletons()[0].Symbols[0] != "e" {
+		t.Errorf("got %q, want the skeletons unchanged", StandardSkeletons()[0].Symbols)
+	}
+}
+
+func TestSkeletonAccessors(t *testing.T) {
+	erase := EraseSkeleton()
+	erase[0].Operations = append(erase[0].Operations, "P0")
+	erase[0].FinalMConfiguration = "halt"
+	if got := EraseSkeleton()[0]; len(got.Operations) != 0 || got.FinalMConfiguration != "f(e1(C, B, a), B, a)" {
+		t.Errorf("got %v, want the skeleton unchanged", got)
+	}
+}
+
+func TestCompileAbbreviatedTableConcurrently(t *testing.T) {
+	// The possible symbols have room to spare, so compiling must not append to them in place
+	possibleSymbols := make([]string, 0, 10)
+	possibleSymbols = append(possibleSymbols, "e", "0", "x")
+	mConfigurations := []MConfiguration{
+		{"b", []string{"*", " "}, []string{}, "c(halt, halt, x)"},
+	}
+	mConfigurations = append(mConfigurations, StandardSkeletons()...)
+	input := AbbreviatedTableInput{
+		MConfigurations:        mConfigurations,
+		Tape:                   []string{"e", "e", "0", "x"},
+		PossibleSymbols:        possibleSymbols,
+		StartingMConfiguration: "b",
+	}
+	want, err := CompileAbbreviatedTable(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan MachineInput)
+	for range 8 {
+		go func() {
+			machineInput, _ := CompileAbbreviatedTable(input)
+			m := NewMachine(machineInput)
+			m.MoveN(50)
+			results <- machineInput
+		}()
+	}
+	for range 8 {
+		if got := <-results; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+
+	// The compiled table shares nothing with the abbreviated table
+	want.PossibleSymbols[0] = "y"
+	want.Tape[0] = "y"
+	if input.PossibleSymbols[0] != "e" || input.Tape[0] != "e" {
+		t.Errorf("got %q and %q, want the abbreviated table unchanged", input.PossibleSymbols, input.Tape)
+	}
+}
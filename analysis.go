@@ -0,0 +1,124 @@
+package turing
+
+import (
+	"slices"
+	"strings"
+)
+
+// Returns the names of the m-configurations reachable from the starting m-configuration,
+// in the order they first appear in the table.
+func Reachable(input MachineInput) []string {
+	reachable := reachableSet(input)
+	names := []string{}
+	for _, mConfiguration := range input.MConfigurations {
+		if reachable[mConfiguration.Name] && !slices.Contains(names, mConfiguration.Name) {
+			names = append(names, mConfiguration.Name)
+		}
+	}
+	return names
+}
+
+// Returns a copy of the MachineInput without the m-configurations that can never be reached
+// from the starting m-configuration.
+func Prune(input MachineInput) MachineInput {
+	reachable := reachableSet(input)
+	pruned := input
+	pruned.MConfigurations = []MConfiguration{}
+	for _, mConfiguration := range input.MConfigurations {
+		if reachable[mConfiguration.Name] {
+			pruned.MConfigurations = append(pruned.MConfigurations, mConfiguration)
+		}
+	}
+	return pruned
+}
+
+// Returns the name of the m-configuration the machine starts with
+func startingMConfigurationName(input MachineInput) string {
+	if len(input.StartingMConfiguration) != 0 {
+		return input.StartingMConfiguration
+	}
+	if len(input.MConfigurations) == 0 {
+		return ""
+	}
+	return input.MConfigurations[0].Name
+}
+
+// Performs a breadth-first search over the transition graph from the starting m-configuration
+func reachableSet(input MachineInput) map[string]bool {
+	successors := input.Graph().successorsByNode()
+	start := startingMConfigurationName(input)
+	reachable := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, successor := range successors[node] {
+			if !reachable[successor] {
+				reachable[successor] = true
+				queue = append(queue, successor)
+			}
+		}
+	}
+	return reachable
+}
+
+// The result of analyzing the symbols a machine declares and uses
+type SymbolReport struct {
+	// Symbols declared in PossibleSymbols that are never printed, matched, or on the Tape
+	Unused []string `json:"unused"`
+
+	// Symbols printed, matched, or on the Tape that are missing from PossibleSymbols.
+	// These are not included when `*` (Any) or `!` (Not) are expanded.
+	Undeclared []string `json:"undeclared"`
+}
+
+// Reports symbols that are declared but never used, and used but never declared
+func AnalyzeSymbols(input MachineInput) SymbolReport {
+	noneSymbol := input.NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+
+	used := []string{}
+	use := func(symbol string) {
+		if symbol != noneSymbol && !slices.Contains(used, symbol) {
+			used = append(used, symbol)
+		}
+	}
+	for _, mConfiguration := range input.MConfigurations {
+		for _, symbol := range mConfiguration.Symbols {
+			if symbol == any {
+				continue
+			}
+			if strings.HasPrefix(symbol, not) {
+				use(symbol[len(not):])
+			} else {
+				use(symbol)
+			}
+		}
+		for _, operation := range mConfiguration.Operations {
+			if len(operation) > 0 && operationCode(operation[0]) == printOp {
+				use(operation[1:])
+			}
+		}
+	}
+	for _, square := range input.Tape {
+		use(square)
+	}
+
+	report := SymbolReport{
+		Unused:     []string{},
+		Undeclared: []string{},
+	}
+	for _, symbol := range input.PossibleSymbols {
+		if !slices.Contains(used, symbol) {
+			report.Unused = append(report.Unused, symbol)
+		}
+	}
+	for _, symbol := range used {
+		if !slices.Contains(input.PossibleSymbols, symbol) {
+			report.Undeclared = append(report.Undeclared, symbol)
+		}
+	}
+	return report
+}
@@ -0,0 +1,64 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestReachable(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "b"},
+			{"unused", []string{" "}, []string{"P1"}, "b"},
+		},
+	}
+	reachable := Reachable(input)
+	if !reflect.DeepEqual(reachable, []string{"b", "c"}) {
+		t.Errorf("got %v, want [b c]", reachable)
+	}
+}
+
+func TestPruneAbbreviatedTable(t *testing.T) {
+	mConfigurations := []MConfiguration{
+		{"b", []string{"*", " "}, []string{"R", "R"}, "pe(halt, x)"},
+		{"unused", []string{"*", " "}, []string{}, "e(halt, x)"},
+	}
+	mConfigurations = append(mConfigurations, FindLeftMostSkeleton()...)
+	mConfigurations = append(mConfigurations, EraseSkeleton()...)
+	mConfigurations = append(mConfigurations, PrintAtTheEndSkeleton()...)
+
+	input := NewAbbreviatedTable(AbbreviatedTableInput{
+		MConfigurations:        mConfigurations,
+		Tape:                   []string{"e", "e", "0", " ", "0"},
+		PossibleSymbols:        []string{"e", "0", "x"},
+		StartingMConfiguration: "b",
+	})
+	pruned := Prune(input)
+	if len(pruned.MConfigurations) >= len(input.MConfigurations) {
+		t.Errorf("expected pruning to remove m-configurations, got %d of %d", len(pruned.MConfigurations), len(input.MConfigurations))
+	}
+
+	m := NewMachine(pruned)
+	m.MoveN(20)
+	turingtest.TapePrefix(t, m.TapeString(), "ee0 0 x")
+}
+
+func TestAnalyzeSymbols(t *testing.T) {
+	report := AnalyzeSymbols(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "P0"}, "c"},
+			{"c", []string{"!x"}, []string{"R"}, "b"},
+		},
+		Tape:            []string{"y"},
+		PossibleSymbols: []string{"0", "1", "x"},
+	})
+	if !reflect.DeepEqual(report.Unused, []string{"1"}) {
+		t.Errorf("got %v, want [1]", report.Unused)
+	}
+	if !reflect.DeepEqual(report.Undeclared, []string{"e", "y"}) {
+		t.Errorf("got %v, want [e y]", report.Undeclared)
+	}
+}
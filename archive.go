@@ -0,0 +1,223 @@
+package turing
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+type (
+	// A complete experiment bundled as one file (a zip archive, conventionally with the
+	// `MachineArchiveExtension`). Only the MachineInput is required.
+	MachineArchive struct {
+		// The machine
+		Input MachineInput
+
+		// The source the machine was compiled from (see `CompileAssembly`)
+		Source string
+
+		// The symbols of the machine's standard form (see `StandardTable`)
+		SymbolMap SymbolMap
+
+		// The machine's Standard Description
+		StandardDescription StandardDescription
+
+		// The machine's Description Number
+		DescriptionNumber DescriptionNumber
+
+		// A tape to run the machine on, other than the MachineInput's
+		Tape Tape
+
+		// Free-form information such as the author or a description
+		Metadata map[string]string
+	}
+
+	// The manifest of a MachineArchive
+	machineArchiveManifest struct {
+		Version  int               `json:"version"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+	}
+)
+
+const (
+	// The conventional extension of a MachineArchive file
+	MachineArchiveExtension string = ".tmachine"
+
+	// The current version of the MachineArchive format
+	MachineArchiveVersion int = 1
+
+	// The kind of a MachineArchive's manifest (see `RegisterMigration`)
+	MachineArchiveKind string = "archive"
+
+	machineArchiveManifestFile            string = "manifest.json"
+	machineArchiveInputFile               string = "machine.json"
+	machineArchiveSourceFile              string = "source.tm"
+	machineArchiveSymbolMapFile           string = "symbols.json"
+	machineArchiveStandardDescriptionFile string = "description.sd"
+	machineArchiveDescriptionNumberFile   string = "description.dn"
+	machineArchiveTapeFile                string = "tape.json"
+)
+
+// Returns a MachineArchive for the machine, including its standard form's symbols,
+// Standard Description and Description Number
+func NewMachineArchive(input MachineInput) MachineArchive {
+	st := NewStandardTable(input)
+	return MachineArchive{
+		Input:               input,
+		SymbolMap:           st.SymbolMap,
+		StandardDescription: st.StandardDescription,
+		DescriptionNumber:   st.DescriptionNumber,
+		Metadata:            map[string]string{},
+	}
+}
+
+// Writes the archive as a zip file. Empty optional parts are omitted.
+func SaveMachineArchive(w io.Writer, archive MachineArchive) error {
+	zw := zip.NewWriter(w)
+	create := func(name string, write func(io.Writer) error) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		return write(f)
+	}
+	writeJSON := func(name string, value interface{}) error {
+		return create(name, func(w io.Writer) error {
+			return json.NewEncoder(w).Encode(value)
+		})
+	}
+	writeString := func(name string, value string) error {
+		if len(value) == 0 {
+			return nil
+		}
+		return create(name, func(w io.Writer) error {
+			_, err := io.WriteString(w, value)
+			return err
+		})
+	}
+
+	if err := writeJSON(machineArchiveManifestFile, machineArchiveManifest{
+		Version:  MachineArchiveVersion,
+		Metadata: archive.Metadata,
+	}); err != nil {
+		return err
+	}
+	if err := create(machineArchiveInputFile, func(w io.Writer) error {
+		return WriteMachineInput(w, archive.Input)
+	}); err != nil {
+		return err
+	}
+	if err := writeString(machineArchiveSourceFile, archive.Source); err != nil {
+		return err
+	}
+	if len(archive.SymbolMap) != 0 {
+		if err := writeJSON(machineArchiveSymbolMapFile, archive.SymbolMap); err != nil {
+			return err
+		}
+	}
+	if err := writeString(machineArchiveStandardDescriptionFile, string(archive.StandardDescription)); err != nil {
+		return err
+	}
+	if err := writeString(machineArchiveDescriptionNumberFile, string(archive.DescriptionNumber)); err != nil {
+		return err
+	}
+	if archive.Tape != nil {
+		if err := writeJSON(machineArchiveTapeFile, archive.Tape); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Reads an archive written by `SaveMachineArchive`. An error wrapping `ErrUnsupportedVersion`
+// is returned if it was written in a newer format.
+func LoadMachineArchive(r io.ReaderAt, size int64) (MachineArchive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return MachineArchive{}, err
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return MachineArchive{}, err
+		}
+		contents, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return MachineArchive{}, err
+		}
+		files[f.Name] = contents
+	}
+
+	manifestData, ok := files[machineArchiveManifestFile]
+	if !ok {
+		return MachineArchive{}, fmt.Errorf("archive has no %s", machineArchiveManifestFile)
+	}
+	var versioned struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(manifestData, &versioned); err != nil {
+		return MachineArchive{}, err
+	}
+	manifestData, err = migrate(MachineArchiveKind, versioned.Version, MachineArchiveVersion, manifestData)
+	if err != nil {
+		return MachineArchive{}, err
+	}
+	var manifest machineArchiveManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return MachineArchive{}, err
+	}
+
+	inputData, ok := files[machineArchiveInputFile]
+	if !ok {
+		return MachineArchive{}, fmt.Errorf("archive has no %s", machineArchiveInputFile)
+	}
+	input, err := ReadMachineInput(bytes.NewReader(inputData))
+	if err != nil {
+		return MachineArchive{}, err
+	}
+
+	archive := MachineArchive{
+		Input:               input,
+		Source:              string(files[machineArchiveSourceFile]),
+		StandardDescription: StandardDescription(files[machineArchiveStandardDescriptionFile]),
+		DescriptionNumber:   DescriptionNumber(files[machineArchiveDescriptionNumberFile]),
+		Metadata:            manifest.Metadata,
+	}
+	if archive.Metadata == nil {
+		archive.Metadata = map[string]string{}
+	}
+	if data, ok := files[machineArchiveSymbolMapFile]; ok {
+		if err := json.Unmarshal(data, &archive.SymbolMap); err != nil {
+			return MachineArchive{}, err
+		}
+	}
+	if data, ok := files[machineArchiveTapeFile]; ok {
+		if err := json.Unmarshal(data, &archive.Tape); err != nil {
+			return MachineArchive{}, err
+		}
+	}
+	return archive, nil
+}
+
+// Writes the archive to the file (see `SaveMachineArchive`)
+func SaveMachineArchiveFile(path string, archive MachineArchive) error {
+	var buffer bytes.Buffer
+	if err := SaveMachineArchive(&buffer, archive); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buffer.Bytes(), 0o644)
+}
+
+// Reads the archive from the file (see `LoadMachineArchive`)
+func LoadMachineArchiveFile(path string) (MachineArchive, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return MachineArchive{}, err
+	}
+	return LoadMachineArchive(bytes.NewReader(contents), int64(len(contents)))
+}
@@ -0,0 +1,69 @@
+package turing
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMachineArchive(t *testing.T) {
+	source := "b:\n    on \" \" do P0, R goto c\nc:\n    on \" \" do R goto b\n"
+	input, err := CompileAssembly(source, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := NewMachineArchive(input)
+	archive.Source = source
+	archive.Tape = Tape{"1"}
+	archive.Metadata["author"] = "Turing"
+
+	path := filepath.Join(t.TempDir(), "example"+MachineArchiveExtension)
+	if err := SaveMachineArchiveFile(path, archive); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadMachineArchiveFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, archive) {
+		t.Errorf("got %+v, want %+v", loaded, archive)
+	}
+	if loaded.DescriptionNumber != "7313325311731133531" {
+		t.Errorf("got D.N. %s", loaded.DescriptionNumber)
+	}
+}
+
+func TestMachineArchiveMinimal(t *testing.T) {
+	archive := MachineArchive{Input: MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0"}, "b"},
+		},
+	}}
+	var buffer bytes.Buffer
+	if err := SaveMachineArchive(&buffer, archive); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadMachineArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Input, archive.Input) || loaded.Tape != nil || len(loaded.Source) != 0 {
+		t.Errorf("got %+v, want %+v", loaded, archive)
+	}
+}
+
+func TestMachineArchiveVersion(t *testing.T) {
+	var buffer bytes.Buffer
+	zw := zip.NewWriter(&buffer)
+	f, _ := zw.Create("manifest.json")
+	f.Write([]byte(`{"version": 99}`))
+	zw.Close()
+
+	_, err := LoadMachineArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("got %v, want ErrUnsupportedVersion", err)
+	}
+}
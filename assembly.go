@@ -0,0 +1,281 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// Returns the source of an included file
+	AssemblyResolver func(name string) (string, error)
+
+	// Struct to hold shared values when compiling assembly
+	assemblyCompiler struct {
+		input     MachineInput
+		resolve   AssemblyResolver
+		including []string
+	}
+
+	// A token of a line of assembly, and where the remainder of the line begins
+	assemblyToken struct {
+		text   string
+		quoted bool
+		end    int
+	}
+)
+
+const (
+	assemblyComment  string = ";"
+	assemblyHash     string = "#"
+	assemblyLabel    string = ":"
+	assemblyOn       string = "on"
+	assemblyDo       string = "do"
+	assemblyGoto     string = "goto"
+	assemblyInclude  string = "include"
+	assemblyStart    string = "start"
+	assemblySymbols  string = "symbols"
+	assemblyNone     string = "none"
+	assemblyMainName string = "main"
+)
+
+// Compiles a textual machine description to MachineInput. Each line is one of:
+//
+//	; A comment (lines beginning with `;` or `#`)
+//	include "other"            Compiles another source (found with `resolve`) in place
+//	start b                    The starting m-configuration
+//	symbols 0 1 e x            The possible symbols
+//	none _                     The None symbol
+//	b:                         A label, naming the m-configuration of the rules below it
+//	on " " do P0, R goto c     A rule: symbols, optional operations, and final m-configuration
+//
+// Symbols and operations are separated by spaces or commas, and may be quoted (as Go strings)
+// if they contain either. Labels and final m-configurations run to the end of the line, so may be
+// m-function signatures such as `f(C, B, a)`. An error wrapping `ErrSyntax` is returned if the
+// source cannot be compiled.
+func CompileAssembly(source string, resolve AssemblyResolver) (MachineInput, error) {
+	c := &assemblyCompiler{
+		input: MachineInput{
+			MConfigurations: []MConfiguration{},
+		},
+		resolve:   resolve,
+		including: []string{assemblyMainName},
+	}
+	if err := c.compile(assemblyMainName, source); err != nil {
+		return MachineInput{}, err
+	}
+	return c.input, nil
+}
+
+// Compiles the lines of a source into the MachineInput
+func (c *assemblyCompiler) compile(file string, source string) error {
+	label := ""
+	for i, line := range strings.Split(source, "\n") {
+		syntaxError := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%w: %s:%d: %s", ErrSyntax, file, i+1, fmt.Sprintf(format, args...))
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, assemblyComment) || strings.HasPrefix(line, assemblyHash) {
+			continue
+		}
+
+		tokens, err := tokenizeAssembly(line)
+		if err != nil {
+			return syntaxError("%v", err)
+		}
+		keyword := ""
+		if !tokens[0].quoted {
+			keyword = tokens[0].text
+		}
+
+		switch keyword {
+		case assemblyInclude:
+			if len(tokens) != 2 {
+				return syntaxError("include takes one name")
+			}
+			if err := c.include(tokens[1].text); err != nil {
+				return syntaxError("%v", err)
+			}
+		case assemblyStart:
+			c.input.StartingMConfiguration = strings.TrimSpace(line[tokens[0].end:])
+		case assemblySymbols:
+			for _, token := range tokens[1:] {
+				if !slices.Contains(c.input.PossibleSymbols, token.text) {
+					c.input.PossibleSymbols = append(c.input.PossibleSymbols, token.text)
+				}
+			}
+		case assemblyNone:
+			if len(tokens) != 2 {
+				return syntaxError("none takes one symbol")
+			}
+			c.input.NoneSymbol = tokens[1].text
+		case assemblyOn:
+			if len(label) == 0 {
+				return syntaxError("rule before any label")
+			}
+			mConfiguration, err := compileAssemblyRule(label, line, tokens)
+			if err != nil {
+				return syntaxError("%v", err)
+			}
+			c.input.MConfigurations = append(c.input.MConfigurations, mConfiguration)
+		default:
+			if !strings.HasSuffix(line, assemblyLabel) {
+				return syntaxError("unexpected %q", tokens[0].text)
+			}
+			label = strings.TrimSpace(strings.TrimSuffix(line, assemblyLabel))
+			if len(label) == 0 {
+				return syntaxError("empty label")
+			}
+		}
+	}
+	return nil
+}
+
+// Compiles an included source in place
+func (c *assemblyCompiler) include(name string) error {
+	if c.resolve == nil {
+		return fmt.Errorf("cannot include %q without a resolver", name)
+	}
+	if slices.Contains(c.including, name) {
+		return fmt.Errorf("%q includes itself", name)
+	}
+	source, err := c.resolve(name)
+	if err != nil {
+		return err
+	}
+	c.including = append(c.including, name)
+	defer func() {
+		c.including = c.including[:len(c.including)-1]
+	}()
+	return c.compile(name, source)
+}
+
+// Compiles `on SYMBOLS [do OPERATIONS] goto FINAL`
+func compileAssemblyRule(name string, line string, tokens []assemblyToken) (MConfiguration, error) {
+	mConfiguration := MConfiguration{
+		Name:       name,
+		Symbols:    []string{},
+		Operations: []string{},
+	}
+	operations := false
+	for _, token := range tokens[1:] {
+		if !token.quoted && token.text == assemblyDo {
+			operations = true
+			continue
+		}
+		if !token.quoted && token.text == assemblyGoto {
+			final := strings.TrimSpace(line[token.end:])
+			if unquoted, err := strconv.Unquote(final); err == nil {
+				final = unquoted
+			}
+			if len(final) == 0 {
+				return mConfiguration, fmt.Errorf("goto without an m-configuration")
+			}
+			if len(mConfiguration.Symbols) == 0 {
+				return mConfiguration, fmt.Errorf("rule without symbols")
+			}
+			mConfiguration.FinalMConfiguration = final
+			return mConfiguration, nil
+		}
+		if operations {
+			mConfiguration.Operations = append(mConfiguration.Operations, token.text)
+		} else {
+			mConfiguration.Symbols = append(mConfiguration.Symbols, token.text)
+		}
+	}
+	return mConfiguration, fmt.Errorf("rule without goto")
+}
+
+// Splits a line into tokens separated by spaces or commas, unquoting quoted tokens
+func tokenizeAssembly(line string) ([]assemblyToken, error) {
+	tokens := []assemblyToken{}
+	isSeparator := func(char rune) bool {
+		return unicode.IsSpace(char) || char == ','
+	}
+	i := 0
+	for i < len(line) {
+		if char, size := utf8.DecodeRuneInString(line[i:]); isSeparator(char) {
+			i += size
+			continue
+		}
+		if line[i] == '"' {
+			quoted, err := strconv.QuotedPrefix(line[i:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			text, _ := strconv.Unquote(quoted)
+			i += len(quoted)
+			tokens = append(tokens, assemblyToken{text: text, quoted: true, end: i})
+			continue
+		}
+		start := i
+		for i < len(line) {
+			char, size := utf8.DecodeRuneInString(line[i:])
+			if isSeparator(char) {
+				break
+			}
+			i += size
+		}
+		tokens = append(tokens, assemblyToken{text: line[start:i], end: i})
+	}
+	return tokens, nil
+}
+
+// Returns the assembly for the MachineInput (see `CompileAssembly`). Compiling the result
+// gives back the same MachineInput.
+func DecompileAssembly(input MachineInput) string {
+	var assembly strings.Builder
+	if len(input.StartingMConfiguration) != 0 {
+		assembly.WriteString(fmt.Sprintf("%s %s\n", assemblyStart, input.StartingMConfiguration))
+	}
+	if len(input.PossibleSymbols) != 0 {
+		assembly.WriteString(assemblySymbols)
+		for _, symbol := range input.PossibleSymbols {
+			assembly.WriteString(" " + quoteAssembly(symbol))
+		}
+		assembly.WriteString("\n")
+	}
+	if len(input.NoneSymbol) != 0 {
+		assembly.WriteString(fmt.Sprintf("%s %s\n", assemblyNone, quoteAssembly(input.NoneSymbol)))
+	}
+
+	label := ""
+	for i, mConfiguration := range input.MConfigurations {
+		if i == 0 || mConfiguration.Name != label {
+			label = mConfiguration.Name
+			assembly.WriteString(fmt.Sprintf("\n%s%s\n", label, assemblyLabel))
+		}
+		assembly.WriteString("    " + assemblyRule(mConfiguration) + "\n")
+	}
+	return assembly.String()
+}
+
+// Returns the assembly for a single row, without its label (i.e. `on " " do P0, R goto c`)
+func assemblyRule(mConfiguration MConfiguration) string {
+	var rule strings.Builder
+	rule.WriteString(assemblyOn)
+	for _, symbol := range mConfiguration.Symbols {
+		rule.WriteString(" " + quoteAssembly(symbol))
+	}
+	if len(mConfiguration.Operations) != 0 {
+		quoted := []string{}
+		for _, operation := range mConfiguration.Operations {
+			quoted = append(quoted, quoteAssembly(operation))
+		}
+		rule.WriteString(fmt.Sprintf(" %s %s", assemblyDo, strings.Join(quoted, ", ")))
+	}
+	rule.WriteString(fmt.Sprintf(" %s %s", assemblyGoto, mConfiguration.FinalMConfiguration))
+	return rule.String()
+}
+
+// Quotes the token if it would not otherwise be read back as a single token
+func quoteAssembly(token string) string {
+	if len(token) == 0 || strings.ContainsAny(token, " \t\n\r,\"") || slices.Contains([]string{assemblyDo, assemblyGoto}, token) {
+		return strconv.Quote(token)
+	}
+	return token
+}
@@ -0,0 +1,108 @@
+package turing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestCompileAssembly(t *testing.T) {
+	input, err := CompileAssembly(`
+; Turing's first example, alternating 0 and 1
+start b
+symbols 0 1
+
+b:
+    on " " do P0, R goto c
+c:
+    on " " do R goto e
+include "second half"
+`, func(name string) (string, error) {
+		if name != "second half" {
+			t.Errorf("got %s, want second half", name)
+		}
+		return "e:\n    on \" \" do P1 R goto k\nk:\n    on \" \" do R goto b\n", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.StartingMConfiguration != "b" || !reflect.DeepEqual(input.PossibleSymbols, []string{"0", "1"}) {
+		t.Errorf("got %s and %v, want b and [0 1]", input.StartingMConfiguration, input.PossibleSymbols)
+	}
+	m := NewMachine(input)
+	m.MoveN(50)
+	turingtest.TapePrefix(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
+}
+
+func TestDecompileAssemblyRoundTrip(t *testing.T) {
+	mConfigurations := StandardSkeletons()
+	mConfigurations = append(mConfigurations, configuration...)
+	mConfigurations = append(mConfigurations, begin...)
+	mConfigurations = append(mConfigurations, anfang...)
+	mConfigurations = append(mConfigurations, kom...)
+	mConfigurations = append(mConfigurations, kmp...)
+	mConfigurations = append(mConfigurations, similar...)
+	mConfigurations = append(mConfigurations, mark...)
+	mConfigurations = append(mConfigurations, instruction...)
+	input := MachineInput{
+		MConfigurations:        mConfigurations,
+		StartingMConfiguration: "b",
+		PossibleSymbols:        possibleSymbolsForUniversalMachine,
+		NoneSymbol:             " ",
+	}
+
+	compiled, err := CompileAssembly(DecompileAssembly(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(compiled, input) {
+		for i := range compiled.MConfigurations {
+			if !reflect.DeepEqual(compiled.MConfigurations[i], input.MConfigurations[i]) {
+				t.Fatalf("got %v, want %v", compiled.MConfigurations[i], input.MConfigurations[i])
+			}
+		}
+		t.Errorf("got %v, want %v", compiled, input)
+	}
+}
+
+func TestCompileAssemblyUnicode(t *testing.T) {
+	// The second byte of `à` is the same as a non-breaking space, which must not split it
+	input, err := CompileAssembly(`
+symbols ə à 🐢
+b:
+    on " " do Pə, R, Pà, R, P🐢, R goto b
+`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(input.PossibleSymbols, []string{"ə", "à", "🐢"}) {
+		t.Errorf("got %q, want ə, à and 🐢", input.PossibleSymbols)
+	}
+	m := NewMachine(input)
+	m.MoveN(6)
+	turingtest.TapePrefix(t, m.TapeString(), "əà🐢əà🐢")
+}
+
+func TestCompileAssemblyErrors(t *testing.T) {
+	for name, source := range map[string]string{
+		"NoLabel":        `on 0 goto b`,
+		"NoGoto":         "b:\n    on 0 do R",
+		"NoSymbols":      "b:\n    on goto b",
+		"Unterminated":   "b:\n    on \"0 goto b",
+		"Unexpected":     "jump b",
+		"NoResolver":     `include "x"`,
+		"IncludesItself": `include "main"`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resolve := func(name string) (string, error) { return source, nil }
+			if name == "NoResolver" {
+				resolve = nil
+			}
+			if _, err := CompileAssembly(source, resolve); !errors.Is(err, ErrSyntax) {
+				t.Errorf("got %v, want ErrSyntax", err)
+			}
+		})
+	}
+}
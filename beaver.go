@@ -2,126 +2,349 @@ package turing
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
 
+type (
+	// Configures a busy beaver search (see `BusyBeaver`)
+	BusyBeaverOption func(*busyBeaverOptions)
+
+	// The configuration of a busy beaver search
+	busyBeaverOptions struct {
+		maxMoves   int
+		score      Score
+		symbols    int
+		cache      *MeasureCache
+		debug      io.Writer
+		progress   ProgressFunc
+		every      int
+		quadruples bool
+	}
+
+	// The outcome of a busy beaver search
+	BusyBeaverResult struct {
+		// The best score of any machine
+		Score int
+
+		// The first machine found with the best score
+		Machine MachineInput
+
+		// How every machine was dealt with, and every machine with the best score
+		Report SearchReport
+	}
+)
+
 const (
-	haltMConfigurationName = "halt"
-	maxMoves               = 1000
+	defaultBusyBeaverMaxMoves = 1000
+
+	// The amount of configurations explored to find cyclers, before simulating
+	cyclerMaxConfigurations = 100
 )
 
-// Finds the m-configuration and number of `1`'s of the `n`'th busy beaver.
-func busyBeaver(n int, debug bool) (int, MachineInput) {
-	// Initialize sets of m-configurations
-	var mConfigurations []MConfiguration
-	for i := 0; i < n; i++ {
-		mConfigurations = append(mConfigurations, MConfiguration{
-			Name:                strconv.Itoa(i),
-			Symbols:             []string{"0"},
-			Operations:          []string{"P0", "L"}, // Print, then Move
-			FinalMConfiguration: "0",
-		})
-		mConfigurations = append(mConfigurations, MConfiguration{
-			Name:                strconv.Itoa(i),
-			Symbols:             []string{"1"},
-			Operations:          []string{"P0", "L"}, // Print, then Move
-			FinalMConfiguration: "0",
-		})
-	}
-
-	// Keep track of the best so far
-	var best int
-	var bestMConfigurations []MConfiguration
+// Simulates machines for at most `maxMoves` moves (defaults to 1000). Machines that have not halted
+// by then are reported as holdouts.
+func WithMaxMoves(maxMoves int) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.maxMoves = maxMoves
+	}
+}
+
+// Scores machines with `score` (defaults to `OnesScore`, see also `MovesScore`)
+func WithScore(score Score) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.score = score
+	}
+}
+
+// Searches machines with `symbols` symbols (defaults to 2). The symbols are `0` (the blank), `1`, `2`, ...
+func WithSymbols(symbols int) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.symbols = symbols
+	}
+}
+
+// Looks up measurements in the cache (see `MeasureCache`)
+func WithMeasureCache(cache *MeasureCache) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.cache = cache
+	}
+}
+
+// Writes a line to `w` for every machine simulated
+func WithDebug(w io.Writer) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.debug = w
+	}
+}
+
+// Searches machines in quadruple form, whose rows either print a symbol or move left or right, rather
+// than both (see `quadrupleChoices`). The two forms have different busy beaver numbers.
+func WithQuadruples() BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.quadruples = true
+	}
+}
+
+// Calls `report` once at least `every` more machines have been examined, and once the search is done
+func WithProgress(every int, report ProgressFunc) BusyBeaverOption {
+	return func(o *busyBeaverOptions) {
+		o.every = every
+		o.progress = report
+	}
+}
+
+// Finds the `n`'th busy beaver: the best score of any machine with `n` m-configurations, and a machine
+// with that score. With the default `OnesScore` this is the classic objective, the number of
+// non-blank squares left on the tape. Returns an error wrapping `ErrInvalidOption` if `n` or an
+// option is out of range.
+func BusyBeaver(n int, opts ...BusyBeaverOption) (BusyBeaverResult, error) {
+	options, err := newBusyBeaverOptions(n, opts)
+	if err != nil {
+		return BusyBeaverResult{}, err
+	}
+
+	report := busyBeaverSearch(n, options)
+	result := BusyBeaverResult{
+		Score:   report.Best,
+		Machine: getBusyBeaverMachineInput(nil),
+		Report:  report,
+	}
+	if len(report.Champions) != 0 {
+		result.Machine = report.Champions[0].Machine
+	}
+	return result, nil
+}
+
+// Returns the options with their defaults, or an error wrapping `ErrInvalidOption` if `n` or an option
+// is out of range
+func newBusyBeaverOptions(n int, opts []BusyBeaverOption) (busyBeaverOptions, error) {
+	options := busyBeaverOptions{
+		maxMoves: defaultBusyBeaverMaxMoves,
+		score:    OnesScore,
+		symbols:  2,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	switch {
+	case n < 1:
+		return options, fmt.Errorf("%w: %d m-configurations", ErrInvalidOption, n)
+	case options.symbols < 2:
+		return options, fmt.Errorf("%w: %d symbols", ErrInvalidOption, options.symbols)
+	case options.maxMoves < 1:
+		return options, fmt.Errorf("%w: %d max moves", ErrInvalidOption, options.maxMoves)
+	case options.score == nil:
+		return options, fmt.Errorf("%w: no score", ErrInvalidOption)
+	}
+	return options, nil
+}
+
+// Searches every machine with `n` m-configurations, reporting how each was dealt with and the
+// machines with the best score. Machines without a transition to `halt`, one of each pair of mirrored
+// machines, and cyclers (see `DecideHalting`) are pruned, and the rest are simulated.
+func busyBeaverSearch(n int, options busyBeaverOptions) SearchReport {
+	report := SearchReport{
+		N:         n,
+		Pruned:    map[string]int{},
+		Holdouts:  []MachineInput{},
+		Champions: []SearchChampion{},
+	}
+	symbols := busyBeaverSymbols(options.symbols)
+	choices := busyBeaverChoices(n, symbols, true, options)
+	progress := newProgressReporter(options.progress, options.every, enumerationSize(n, len(symbols), len(choices)))
+	defer progress.done()
+	machineInput := func(mConfigurations []MConfiguration) MachineInput {
+		input := getBusyBeaverMachineInput(mConfigurations)
+		input.PossibleSymbols = symbols[1:]
+		return input
+	}
 
 	// The main bit
-	for {
+	enumerateRows(n, symbols, choices, func(mConfigurations []MConfiguration) bool {
+		report.Enumerated++
+		defer func() { progress.examined(1, report.Best) }()
+		if !atLeastOneHaltState(mConfigurations) {
+			report.Pruned[PrunedNoHaltTransition]++
+			return true
+		}
+		input := machineInput(mConfigurations)
+		if isMirrorSearched(input) {
+			report.Pruned[PrunedMirror]++
+			return true
+		}
+		if DecideHalting(input, options.maxMoves, cyclerMaxConfigurations).Verdict == NeverHalts {
+			report.Pruned[PrunedCycler]++
+			return true
+		}
+
 		// Run the current set of m-configurations
-		if atLeastOneHaltState(mConfigurations) {
-			result := simulateBusyBeaver(mConfigurations)
-			if debug {
-				mConfigurationsString := getMConfigurationsString(mConfigurations)
-				fmt.Printf("best %d | result %d | %s\n", best, result, mConfigurationsString)
-			}
-			if result > best {
-				best = result
-				bestMConfigurations = mConfigurations
-			}
+		report.Simulated++
+		metrics := options.cache.Measure(input, options.maxMoves)
+		result := options.score(metrics)
+		if options.debug != nil {
+			mConfigurationsString := getMConfigurationsString(mConfigurations)
+			fmt.Fprintf(options.debug, "best %d | result %d | %s\n", report.Best, result, mConfigurationsString)
+		}
+		if !metrics.Halted {
+			report.Holdouts = append(report.Holdouts, machineInput(cloneMConfigurations(mConfigurations)))
+			return true
+		}
+		report.Halted++
+		if result > report.Best {
+			report.Best = result
+			report.Champions = report.Champions[:0]
 		}
+		if result == report.Best && result > 0 {
+			report.Champions = append(report.Champions, SearchChampion{
+				Table:   getMConfigurationsString(mConfigurations),
+				Machine: machineInput(cloneMConfigurations(mConfigurations)),
+				Metrics: metrics,
+			})
+		}
+		return true
+	})
 
-		var over bool
-		for i := 0; i < n*2; i++ {
-			// Iterate to the next the m-configuration
-			nextMConfiguration, reset := nextMConfiguration(n, mConfigurations[i])
-			mConfigurations[i] = nextMConfiguration
+	return report
+}
 
-			// If we don't need to reset the next m-configuration, move on
-			if !reset {
-				break
-			}
+// Returns true if the machine's mirror is searched in its place. Of each pair of mirrored machines, the
+// one with the lesser fingerprint (see `Fingerprint`) is searched.
+func isMirrorSearched(input MachineInput) bool {
+	mirrored, err := Mirror(input)
+	return err == nil && Fingerprint(mirrored) < Fingerprint(input)
+}
 
-			// We just reset all of our m-configurations (we've looped through everything)
-			if i == (n*2)-1 {
-				over = true
+// Returns the rows busy beaver machines are made of (see `enumerateRows`), moving to `halt` if `halting`
+func busyBeaverChoices(n int, symbols []string, halting bool, options busyBeaverOptions) []MConfiguration {
+	if options.quadruples {
+		return quadrupleChoices(enumerationFinals(n, halting), symbols)
+	}
+	return quintupleChoices(enumerationFinals(n, halting), symbols)
+}
+
+// Returns the symbols of busy beaver machines: `0` (the blank), `1`, `2`, ...
+func busyBeaverSymbols(count int) []string {
+	symbols := []string{}
+	for i := 0; i < count; i++ {
+		symbols = append(symbols, strconv.Itoa(i))
+	}
+	return symbols
+}
+
+// Finds the `n`'th lazy beaver number: the smallest amount of moves after which no machine with `n`
+// m-configurations halts. Machines are only simulated for as many moves as needed, since every machine
+// halting after at most `limit` moves is found when simulating that many, so WithMaxMoves is ignored.
+// WithSymbols and WithQuadruples choose the machines searched. Returns an error wrapping
+// `ErrInvalidOption` if `n` or an option is out of range.
+func LazyBeaver(n int, opts ...BusyBeaverOption) (int, error) {
+	options, err := newBusyBeaverOptions(n, opts)
+	if err != nil {
+		return 0, err
+	}
+	for limit := 2 * n; ; limit *= 2 {
+		options.maxMoves = limit
+		histogram := stepHistogram(n, options)
+		for moves := 1; moves <= limit; moves++ {
+			if histogram[moves] == 0 {
+				return moves, nil
 			}
 		}
+	}
+}
 
-		// Check to see if we are done
-		if over {
-			break
-		}
+// Counts the machines with `n` m-configurations that halt after each amount of moves, up to the most
+// moves (see WithMaxMoves). WithSymbols and WithQuadruples choose the machines searched. Returns an
+// error wrapping `ErrInvalidOption` if `n` or an option is out of range.
+func StepHistogram(n int, opts ...BusyBeaverOption) (map[int]int, error) {
+	options, err := newBusyBeaverOptions(n, opts)
+	if err != nil {
+		return nil, err
 	}
+	return stepHistogram(n, options), nil
+}
 
-	// Return the best we have
-	return best, getBusyBeaverMachineInput(bestMConfigurations)
+// Counts the machines with `n` m-configurations that halt after each amount of moves
+func stepHistogram(n int, options busyBeaverOptions) map[int]int {
+	histogram := map[int]int{}
+	symbols := busyBeaverSymbols(options.symbols)
+	enumerateRows(n, symbols, busyBeaverChoices(n, symbols, true, options), func(mConfigurations []MConfiguration) bool {
+		if atLeastOneHaltState(mConfigurations) {
+			input := getBusyBeaverMachineInput(mConfigurations)
+			input.PossibleSymbols = symbols[1:]
+			m := NewMachine(input)
+			m.MoveN(options.maxMoves)
+			if m.halted {
+				histogram[m.moves]++
+			}
+		}
+		return true
+	})
+	return histogram
 }
 
-// Iterate through all of the variables of an m-configuration, return true if we did a full loop
-func nextMConfiguration(n int, mConfiguration MConfiguration) (MConfiguration, bool) {
-	// Print Operations: P0, P1
-	if mConfiguration.Operations[0] == "P0" {
-		return MConfiguration{
-			Name:                mConfiguration.Name,
-			Symbols:             mConfiguration.Symbols,
-			Operations:          []string{"P1", mConfiguration.Operations[1]},
-			FinalMConfiguration: mConfiguration.FinalMConfiguration,
-		}, false
+// Finds the `n`'th beeping busy beaver: among machines with `n` m-configurations (which never halt)
+// and one of them chosen to beep (see `BeepMConfigurations`), the latest last beep, and the first
+// machine beeping it. Machines are simulated for the most moves (see WithMaxMoves), and a machine is
+// taken to never beep again if it does not beep in the second half of them; machines that do are
+// reported as holdouts. WithSymbols and WithQuadruples choose the machines searched, and the other
+// options are ignored. Returns an error wrapping `ErrInvalidOption` if `n` or an option is out of range.
+func BeepingBusyBeaver(n int, opts ...BusyBeaverOption) (BusyBeaverResult, error) {
+	options, err := newBusyBeaverOptions(n, opts)
+	if err != nil {
+		return BusyBeaverResult{}, err
 	}
-
-	// Move Operations: L, R
-	if mConfiguration.Operations[1] == "L" {
-		return MConfiguration{
-			Name:                mConfiguration.Name,
-			Symbols:             mConfiguration.Symbols,
-			Operations:          []string{"P0", "R"},
-			FinalMConfiguration: mConfiguration.FinalMConfiguration,
-		}, false
+	report := SearchReport{
+		N:         n,
+		Pruned:    map[string]int{},
+		Holdouts:  []MachineInput{},
+		Champions: []SearchChampion{},
+	}
+	symbols := busyBeaverSymbols(options.symbols)
+	machineInput := func(mConfigurations []MConfiguration, beep int) MachineInput {
+		input := getBusyBeaverMachineInput(mConfigurations)
+		input.PossibleSymbols = symbols[1:]
+		input.HaltingMConfigurations = nil
+		input.BeepMConfigurations = []string{strconv.Itoa(beep)}
+		return input
 	}
 
-	// Final m-configurations: 0...n, halt
-	if mConfiguration.FinalMConfiguration != haltMConfigurationName {
-		finalMConfigurationInt, _ := strconv.Atoi(mConfiguration.FinalMConfiguration)
-		var finalMConfiguration string
-		if finalMConfigurationInt == n-1 {
-			finalMConfiguration = haltMConfigurationName
-		} else {
-			finalMConfiguration = strconv.Itoa(finalMConfigurationInt + 1)
+	enumerateRows(n, symbols, busyBeaverChoices(n, symbols, false, options), func(mConfigurations []MConfiguration) bool {
+		for i := 0; i < n; i++ {
+			report.Enumerated++
+			report.Simulated++
+			m := NewMachine(machineInput(mConfigurations, i))
+			m.MoveN(options.maxMoves)
+			lastBeep := m.LastBeep()
+			if lastBeep > options.maxMoves/2 {
+				report.Holdouts = append(report.Holdouts, machineInput(cloneMConfigurations(mConfigurations), i))
+				continue
+			}
+			if lastBeep > report.Best {
+				report.Best = lastBeep
+				report.Champions = report.Champions[:0]
+			}
+			if lastBeep == report.Best && lastBeep > 0 {
+				input := machineInput(cloneMConfigurations(mConfigurations), i)
+				report.Champions = append(report.Champions, SearchChampion{
+					Table:   getMConfigurationsString(mConfigurations),
+					Machine: input,
+					Metrics: Measure(input, options.maxMoves),
+				})
+			}
 		}
-		return MConfiguration{
-			Name:                mConfiguration.Name,
-			Symbols:             mConfiguration.Symbols,
-			Operations:          []string{"P0", "L"},
-			FinalMConfiguration: finalMConfiguration,
-		}, false
-	}
+		return true
+	})
 
-	// If we reset the full m-configuration, signal upwards that the next m-configuration in the list should iterate
-	return MConfiguration{
-		Name:                mConfiguration.Name,
-		Symbols:             mConfiguration.Symbols,
-		Operations:          []string{"P0", "L"},
-		FinalMConfiguration: "0",
-	}, true
+	result := BusyBeaverResult{
+		Score:  report.Best,
+		Report: report,
+	}
+	if len(report.Champions) != 0 {
+		result.Machine = report.Champions[0].Machine
+	}
+	return result, nil
 }
 
 // No need to simulate if we know the MConfiguration will never halt
@@ -134,47 +357,31 @@ func atLeastOneHaltState(mConfigurations []MConfiguration) bool {
 	return false
 }
 
-// Return the amount of `1`'s the machine prints up to `maxMoves`
-func simulateBusyBeaver(mConfigurations []MConfiguration) int {
-	m := NewMachine(getBusyBeaverMachineInput(mConfigurations))
-	moves := m.MoveN(maxMoves)
-	if moves == maxMoves {
-		return 0
-	}
-
-	var count int
-	for _, square := range m.Tape() {
-		if square == "1" {
-			count++
-		}
-	}
-	return count
-}
-
 // For a set of our m-configurations, give a runnable MachineInput
 func getBusyBeaverMachineInput(mConfigurations []MConfiguration) MachineInput {
 	return MachineInput{
-		MConfigurations: mConfigurations,
-		PossibleSymbols: []string{"1"},
-		NoneSymbol:      "0",
+		MConfigurations:        mConfigurations,
+		PossibleSymbols:        []string{"1"},
+		NoneSymbol:             "0",
+		HaltingMConfigurations: []string{haltMConfigurationName},
 	}
 }
 
-// Shortens m-configurations for printing/debugging
+// Shortens m-configurations for printing/debugging, grouping the consecutive rows of each m-configuration
 func getMConfigurationsString(mConfigurations []MConfiguration) string {
 	var s strings.Builder
 
 	for i, mConfiguration := range mConfigurations {
-		if i%2 == 0 {
+		if i == 0 || mConfigurations[i-1].Name != mConfiguration.Name {
 			s.WriteString(fmt.Sprintf("%s[", mConfiguration.Name))
 		}
 
-		s.WriteString(fmt.Sprintf(" %s:%s;%s;%s", mConfiguration.Symbols[0], mConfiguration.Operations[0], mConfiguration.Operations[1], mConfiguration.FinalMConfiguration))
+		s.WriteString(fmt.Sprintf(" %s:%s;%s", mConfiguration.Symbols[0], strings.Join(mConfiguration.Operations, ";"), mConfiguration.FinalMConfiguration))
 
-		if i%2 == 0 {
-			s.WriteString(fmt.Sprintf(","))
+		if i == len(mConfigurations)-1 || mConfigurations[i+1].Name != mConfiguration.Name {
+			s.WriteString(" ] ")
 		} else {
-			s.WriteString(fmt.Sprintf(" ] "))
+			s.WriteString(",")
 		}
 	}
 
@@ -1,6 +1,14 @@
 package turing
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
 
 func TestFirstBusyBeaver(t *testing.T) {
 	testBusyBeaver(t, 1, 1, false)
@@ -20,9 +28,154 @@ func TestSecondBusyBeaver(t *testing.T) {
 // 	testBusyBeaver(t, 4, 13, false)
 // }
 
+func TestLazyBeaver(t *testing.T) {
+	for n, expected := range map[int]int{1: 2, 2: 7} {
+		actual, err := LazyBeaver(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != expected {
+			t.Errorf("Incorrect LB-%d number %d, expected %d", n, actual, expected)
+		}
+	}
+}
+
+func TestBeepingBusyBeaver(t *testing.T) {
+	result, err := BeepingBusyBeaver(2, WithMaxMoves(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != 6 {
+		t.Errorf("Incorrect BBB-2 number %d, expected 6", result.Score)
+	}
+	m := NewMachine(result.Machine)
+	m.MoveN(100)
+	if m.LastBeep() != result.Score {
+		t.Errorf("got last beep %d, want %d", m.LastBeep(), result.Score)
+	}
+	if len(result.Report.Champions) == 0 || result.Report.Enumerated != result.Report.Simulated {
+		t.Errorf("got report %+v, want champions and every machine simulated", result.Report)
+	}
+
+	if _, err := BeepingBusyBeaver(0); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOption)
+	}
+}
+
+func TestStepHistogram(t *testing.T) {
+	// Of the 1-state machines, those moving to `halt` from the blank halt after one move
+	histogram, err := StepHistogram(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histogram) != 1 || histogram[1] != 32 {
+		t.Errorf("got %v, want 32 machines halting after 1 move", histogram)
+	}
+
+	if _, err := StepHistogram(0); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOption)
+	}
+}
+
 func testBusyBeaver(t *testing.T, n int, expected int, debug bool) {
-	actual, _ := busyBeaver(n, debug)
-	if actual != expected {
-		t.Errorf("Incorrect BB-%d number %d, expected %d", n, actual, expected)
+	opts := []BusyBeaverOption{}
+	if debug {
+		opts = append(opts, WithDebug(os.Stdout))
+	}
+	result, err := BusyBeaver(n, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != expected {
+		t.Errorf("Incorrect BB-%d number %d, expected %d", n, result.Score, expected)
+	}
+}
+
+func TestBusyBeaverOptions(t *testing.T) {
+	t.Run("Symbols", func(t *testing.T) {
+		// The 1-state, 3-symbol champion prints a `1` or `2` and halts
+		result, err := BusyBeaver(1, WithSymbols(3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Score != 1 || !slices.Equal(result.Machine.PossibleSymbols, []string{"1", "2"}) {
+			t.Errorf("got %d with symbols %v, want 1 with symbols 1 and 2", result.Score, result.Machine.PossibleSymbols)
+		}
+		if result.Report.Enumerated != 12*12*12 {
+			t.Errorf("got %d machines, want %d", result.Report.Enumerated, 12*12*12)
+		}
+		// Each champion's table shows its m-configuration's three rows together
+		for _, champion := range result.Report.Champions {
+			if strings.Count(champion.Table, "[") != 1 || strings.Count(champion.Table, ",") != 2 {
+				t.Errorf("got table %q, want one m-configuration with three rows", champion.Table)
+			}
+		}
+	})
+
+	t.Run("Quadruples", func(t *testing.T) {
+		// A quadruple machine needs a move to print a `1` and another to move off it
+		result, err := BusyBeaver(1, WithQuadruples(), WithScore(MovesScore))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Score != 2 || result.Report.Enumerated != 8*8 {
+			t.Errorf("got %d after %d machines, want 2 after 64", result.Score, result.Report.Enumerated)
+		}
+		for _, mConfiguration := range result.Machine.MConfigurations {
+			if len(mConfiguration.Operations) != 1 {
+				t.Errorf("got operations %v, want one", mConfiguration.Operations)
+			}
+		}
+	})
+
+	t.Run("Debug", func(t *testing.T) {
+		var b strings.Builder
+		if _, err := BusyBeaver(1, WithDebug(&b)); err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(b.String(), "\n"); lines != 20 {
+			t.Errorf("got %d lines, want one for each of the 20 machines simulated", lines)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, opts := range [][]BusyBeaverOption{{WithSymbols(1)}, {WithMaxMoves(0)}, {WithScore(nil)}} {
+			if _, err := BusyBeaver(1, opts...); !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("got %v, want ErrInvalidOption", err)
+			}
+		}
+		if _, err := BusyBeaver(0); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("got %v, want ErrInvalidOption", err)
+		}
+	})
+}
+
+func TestGetMConfigurationsString(t *testing.T) {
+	mConfigurations := []MConfiguration{
+		{"0", []string{"0"}, []string{"P1", "R"}, "1"},
+		{"0", []string{"1"}, []string{"P2", "L"}, "0"},
+		{"0", []string{"2"}, []string{"P1", "R"}, "halt"},
+		{"1", []string{"0"}, []string{"P2", "L"}, "0"},
+		{"1", []string{"1"}, []string{"P2", "R"}, "1"},
+		{"1", []string{"2"}, []string{"P0", "R"}, "1"},
+	}
+	turingtest.Equal(t, getMConfigurationsString(mConfigurations),
+		"0[ 0:P1;R;1, 1:P2;L;0, 2:P1;R;halt ] 1[ 0:P2;L;0, 1:P2;R;1, 2:P0;R;1 ] ")
+}
+
+func TestIsMirrorSearched(t *testing.T) {
+	// The 2-state busy beaver champion, and its mirror
+	input := getBusyBeaverMachineInput([]MConfiguration{
+		{"A", []string{"0"}, []string{"P1", "R"}, "B"},
+		{"A", []string{"1"}, []string{"P1", "L"}, "B"},
+		{"B", []string{"0"}, []string{"P1", "L"}, "A"},
+		{"B", []string{"1"}, []string{"P1", "R"}, "halt"},
+	})
+	mirrored, err := Mirror(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isMirrorSearched(input) == isMirrorSearched(mirrored) {
+		t.Error("want exactly one of the machine and its mirror to be searched")
 	}
 }
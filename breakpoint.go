@@ -0,0 +1,382 @@
+package turing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// A condition over a machine's state, such as
+	//
+	//	state == "mk4" && tape[head] == ":" && moves > 1000
+	//
+	// The variables are `state` (the m-configuration), `symbol` (the scanned symbol), `head`
+	// (the scanned square, relative to the square originally scanned), `moves` and `halted`,
+	// and `tape[i]` is the symbol on square `i`. Integers and strings (quoted as in Go) may be
+	// compared with `==`, `!=`, `<`, `<=`, `>` and `>=`, integers added and subtracted with `+`
+	// and `-`, and conditions combined with `&&`, `||`, `!` and parentheses.
+	BreakpointExpression struct {
+		source string
+		root   breakpointNode
+	}
+
+	// A node of a parsed BreakpointExpression, which evaluates to an int, string or bool
+	breakpointNode interface {
+		evaluate(m *Machine) (interface{}, error)
+	}
+
+	breakpointLiteral struct {
+		value interface{}
+	}
+
+	breakpointVariable struct {
+		name string
+	}
+
+	breakpointTape struct {
+		index breakpointNode
+	}
+
+	breakpointUnary struct {
+		operator string
+		operand  breakpointNode
+	}
+
+	breakpointBinary struct {
+		operator string
+		left     breakpointNode
+		right    breakpointNode
+	}
+
+	// Parses a BreakpointExpression from its tokens
+	breakpointParser struct {
+		tokens []string
+		next   int
+	}
+)
+
+// Parses the expression (see `BreakpointExpression`). An error wrapping `ErrSyntax` is returned
+// if it cannot be parsed.
+func CompileBreakpoint(expression string) (*BreakpointExpression, error) {
+	tokens, err := tokenizeBreakpoint(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &breakpointParser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.next < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q in %q", ErrSyntax, p.tokens[p.next], expression)
+	}
+	return &BreakpointExpression{source: expression, root: root}, nil
+}
+
+// Returns the expression as written
+func (b *BreakpointExpression) String() string {
+	return b.source
+}
+
+// Returns true if the expression holds for the machine. An error is returned if the expression
+// does not evaluate to a bool, or compares or adds values of different types.
+func (b *BreakpointExpression) Evaluate(m *Machine) (bool, error) {
+	value, err := b.root.evaluate(m)
+	if err != nil {
+		return false, err
+	}
+	holds, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("%q is not a condition", b.source)
+	}
+	return holds, nil
+}
+
+// Splits the expression into integers, quoted strings, identifiers and operators
+func tokenizeBreakpoint(expression string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(expression); {
+		char, size := utf8.DecodeRuneInString(expression[i:])
+		switch {
+		case unicode.IsSpace(char):
+			i += size
+		case char == '"':
+			quoted, err := strconv.QuotedPrefix(expression[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: unterminated string in %q", ErrSyntax, expression)
+			}
+			tokens = append(tokens, quoted)
+			i += len(quoted)
+		case isBreakpointIdentifierRune(char):
+			start := i
+			for i < len(expression) {
+				char, size := utf8.DecodeRuneInString(expression[i:])
+				if !isBreakpointIdentifierRune(char) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, expression[start:i])
+		case i+1 < len(expression) && strings.Contains("== != <= >= && ||", expression[i:i+2]):
+			tokens = append(tokens, expression[i:i+2])
+			i += 2
+		case strings.ContainsRune("<>!+-()[]", char):
+			tokens = append(tokens, string(char))
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q in %q", ErrSyntax, char, expression)
+		}
+	}
+	return tokens, nil
+}
+
+// Returns true if the character may be part of an identifier or integer
+func isBreakpointIdentifierRune(char rune) bool {
+	return unicode.IsDigit(char) || unicode.IsLetter(char) || unicode.Is(unicode.Mn, char) || char == '_'
+}
+
+// Returns the next token without consuming it
+func (p *breakpointParser) peek() string {
+	if p.next < len(p.tokens) {
+		return p.tokens[p.next]
+	}
+	return ""
+}
+
+// Consumes the next token if it is one of the operators
+func (p *breakpointParser) accept(operators ...string) (string, bool) {
+	token := p.peek()
+	for _, operator := range operators {
+		if token == operator {
+			p.next++
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// Consumes the next token, which must be the operator
+func (p *breakpointParser) expect(operator string) error {
+	if _, ok := p.accept(operator); !ok {
+		return fmt.Errorf("%w: expected %q but found %q", ErrSyntax, operator, p.peek())
+	}
+	return nil
+}
+
+// Parses a left-associative chain of binary operators
+func (p *breakpointParser) binary(operand func() (breakpointNode, error), operators ...string) (breakpointNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.accept(operators...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = breakpointBinary{operator: operator, left: left, right: right}
+	}
+}
+
+// or := and ("||" and)*
+func (p *breakpointParser) or() (breakpointNode, error) {
+	return p.binary(p.and, "||")
+}
+
+// and := not ("&&" not)*
+func (p *breakpointParser) and() (breakpointNode, error) {
+	return p.binary(p.not, "&&")
+}
+
+// not := "!" not | comparison
+func (p *breakpointParser) not() (breakpointNode, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.not()
+		return breakpointUnary{operator: "!", operand: operand}, err
+	}
+	return p.comparison()
+}
+
+// comparison := sum (("==" | "!=" | "<" | "<=" | ">" | ">=") sum)?
+func (p *breakpointParser) comparison() (breakpointNode, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	operator, ok := p.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.sum()
+	return breakpointBinary{operator: operator, left: left, right: right}, err
+}
+
+// sum := primary (("+" | "-") primary)*
+func (p *breakpointParser) sum() (breakpointNode, error) {
+	return p.binary(p.primary, "+", "-")
+}
+
+// primary := integer | string | "true" | "false" | variable | "tape" "[" or "]" | "(" or ")" | "-" primary
+func (p *breakpointParser) primary() (breakpointNode, error) {
+	token := p.peek()
+	if len(token) == 0 {
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+	p.next++
+
+	switch token {
+	case "(":
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case "-":
+		operand, err := p.primary()
+		return breakpointUnary{operator: "-", operand: operand}, err
+	case "true", "false":
+		return breakpointLiteral{value: token == "true"}, nil
+	case "state", "symbol", "head", "moves", "halted":
+		return breakpointVariable{name: token}, nil
+	case "tape":
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+		index, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return breakpointTape{index: index}, p.expect("]")
+	}
+
+	if strings.HasPrefix(token, `"`) {
+		value, _ := strconv.Unquote(token)
+		return breakpointLiteral{value: value}, nil
+	}
+	if value, err := strconv.Atoi(token); err == nil {
+		return breakpointLiteral{value: value}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown variable %q", ErrSyntax, token)
+}
+
+func (n breakpointLiteral) evaluate(m *Machine) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n breakpointVariable) evaluate(m *Machine) (interface{}, error) {
+	switch n.name {
+	case "state":
+		return m.currentMConfigurationName, nil
+	case "symbol":
+		return m.Square(m.Head()), nil
+	case "head":
+		return m.Head(), nil
+	case "moves":
+		return m.moves, nil
+	}
+	return m.halted, nil
+}
+
+func (n breakpointTape) evaluate(m *Machine) (interface{}, error) {
+	index, err := n.index.evaluate(m)
+	if err != nil {
+		return nil, err
+	}
+	square, ok := index.(int)
+	if !ok {
+		return nil, fmt.Errorf("tape index %v is not an integer", index)
+	}
+	return m.Square(square), nil
+}
+
+func (n breakpointUnary) evaluate(m *Machine) (interface{}, error) {
+	operand, err := n.operand.evaluate(m)
+	if err != nil {
+		return nil, err
+	}
+	switch value := operand.(type) {
+	case bool:
+		if n.operator == "!" {
+			return !value, nil
+		}
+	case int:
+		if n.operator == "-" {
+			return -value, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot apply %s to %v", n.operator, operand)
+}
+
+func (n breakpointBinary) evaluate(m *Machine) (interface{}, error) {
+	left, err := n.left.evaluate(m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Conditions are short-circuited
+	if holds, ok := left.(bool); ok && (n.operator == "&&" && !holds || n.operator == "||" && holds) {
+		return holds, nil
+	}
+
+	right, err := n.right.evaluate(m)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l := left.(type) {
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch n.operator {
+			case "&&", "||":
+				return r, nil
+			case "==":
+				return l == r, nil
+			case "!=":
+				return l != r, nil
+			}
+		}
+	case int:
+		if r, ok := right.(int); ok {
+			switch n.operator {
+			case "+":
+				return l + r, nil
+			case "-":
+				return l - r, nil
+			case "==", "!=", "<", "<=", ">", ">=":
+				return compareBreakpointValues(n.operator, l-r), nil
+			}
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			switch n.operator {
+			case "==", "!=", "<", "<=", ">", ">=":
+				return compareBreakpointValues(n.operator, strings.Compare(l, r)), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot apply %s to %v and %v", n.operator, left, right)
+}
+
+// Returns the result of the comparison, given the sign of the difference of its operands
+func compareBreakpointValues(operator string, difference int) bool {
+	switch operator {
+	case "==":
+		return difference == 0
+	case "!=":
+		return difference != 0
+	case "<":
+		return difference < 0
+	case "<=":
+		return difference <= 0
+	case ">":
+		return difference > 0
+	}
+	return difference >= 0
+}
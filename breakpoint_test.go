@@ -0,0 +1,69 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBreakpointExpression(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	})
+	m.MoveN(3)
+
+	for expression, expected := range map[string]bool{
+		`state == "k"`: true,
+		`state == "k" && tape[head-1] == "1" && moves > 2`: true,
+		`state == "k" && moves > 3`:                        false,
+		`state != "k" || tape[0] == "0"`:                   true,
+		`!(head == 3)`:                                     false,
+		`head - 1 == 2 && symbol == " "`:                   true,
+		`tape[-1] == " " && !halted`:                       true,
+		`moves >= 3 && moves <= 3 && -moves < 0`:           true,
+		`"a" < "b"`:                                        true,
+		`symbol != "ə" && tape[0] != "🐢"`:                  true,
+		`true && false == false`:                           true,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			breakpoint, err := CompileBreakpoint(expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			holds, err := breakpoint.Evaluate(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if holds != expected {
+				t.Errorf("got %t, want %t", holds, expected)
+			}
+		})
+	}
+}
+
+func TestBreakpointExpressionErrors(t *testing.T) {
+	for _, expression := range []string{`state ==`, `state == "k`, `tape[0`, `x == 1`, `state # 1`, `(moves > 1`, `moves 1`} {
+		if _, err := CompileBreakpoint(expression); !errors.Is(err, ErrSyntax) {
+			t.Errorf("got %v for %s, want ErrSyntax", err, expression)
+		}
+	}
+
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+	})
+	for _, expression := range []string{`moves`, `state == 1`, `moves && true`, `tape["a"] == " "`, `!moves`} {
+		breakpoint, err := CompileBreakpoint(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := breakpoint.Evaluate(m); err == nil {
+			t.Errorf("got no error for %s, want one", expression)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package turing
+
+import "strings"
+
+type (
+	// A single operation performed by an m-configuration. Use the `L`, `R`, `E`, `N`, and `H`
+	// constants or the `P` function rather than writing raw strings.
+	Operation string
+
+	// Fluently constructs m-configurations (and m-functions) for use in MachineInput
+	// or AbbreviatedTableInput.
+	TableBuilder struct {
+		mConfigurations []MConfiguration
+		name            string
+		symbols         []string
+		operations      []string
+	}
+)
+
+const (
+	// Move one square to the left
+	L Operation = Operation(leftOp)
+	// Move one square to the right
+	R Operation = Operation(rightOp)
+	// Erase the scanned square
+	E Operation = Operation(eraseOp)
+	// Do not move
+	N Operation = Operation(noOp)
+	// Halt once the move is complete
+	H Operation = Operation(haltOp)
+)
+
+// Print the symbol on the scanned square
+func P(symbol string) Operation {
+	return Operation(string(printOp) + symbol)
+}
+
+// Returns a new TableBuilder
+func NewTable() *TableBuilder {
+	return &TableBuilder{
+		mConfigurations: []MConfiguration{},
+	}
+}
+
+// Begins the rows of an m-function with the given parameters (i.e. `Func("f", "C", "B", "a")` for `f(C, B, a)`)
+func (tb *TableBuilder) Func(name string, params ...string) *TableBuilder {
+	if len(params) == 0 {
+		tb.name = name
+	} else {
+		tb.name = name + functionOpen + strings.Join(params, functionParamDelimiter+none) + functionClose
+	}
+	return tb
+}
+
+// Begins the rows of an m-configuration
+func (tb *TableBuilder) State(name string) *TableBuilder {
+	return tb.Func(name)
+}
+
+// Begins a new row that matches any of the symbols
+func (tb *TableBuilder) On(symbols ...string) *TableBuilder {
+	tb.symbols = append([]string{}, symbols...)
+	tb.operations = []string{}
+	return tb
+}
+
+// Adds operations to the current row
+func (tb *TableBuilder) Do(operations ...Operation) *TableBuilder {
+	for _, operation := range operations {
+		tb.operations = append(tb.operations, string(operation))
+	}
+	return tb
+}
+
+// Completes the current row with the final m-configuration
+func (tb *TableBuilder) Goto(finalMConfiguration string) *TableBuilder {
+	tb.mConfigurations = append(tb.mConfigurations, MConfiguration{
+		Name:                tb.name,
+		Symbols:             tb.symbols,
+		Operations:          tb.operations,
+		FinalMConfiguration: finalMConfiguration,
+	})
+	tb.symbols = nil
+	tb.operations = nil
+	return tb
+}
+
+// Returns the constructed m-configurations
+func (tb *TableBuilder) Build() []MConfiguration {
+	return append([]MConfiguration{}, tb.mConfigurations...)
+}
@@ -0,0 +1,38 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestBuilderExample1(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: NewTable().
+			State("b").On(" ").Do(P("0"), R).Goto("c").
+			State("c").On(" ").Do(R).Goto("e").
+			State("e").On(" ").Do(P("1"), R).Goto("k").
+			State("k").On(" ").Do(R).Goto("b").
+			Build(),
+	})
+	m.MoveN(50)
+	turingtest.TapePrefix(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
+}
+
+func TestBuilderFindLeftMost(t *testing.T) {
+	built := NewTable().
+		Func("f", "C", "B", "a").On("e").Do(L).Goto("f1(C, B, a)").
+		On("!e", " ").Do(L).Goto("f(C, B, a)").
+		Func("f1", "C", "B", "a").On("a").Goto("C").
+		On("!a").Do(R).Goto("f1(C, B, a)").
+		On(" ").Do(R).Goto("f2(C, B, a)").
+		Func("f2", "C", "B", "a").On("a").Goto("C").
+		On("!a").Do(R).Goto("f1(C, B, a)").
+		On(" ").Do(R).Goto("B").
+		Build()
+
+	if !reflect.DeepEqual(built, findLeftMost) {
+		t.Errorf("got %v, want %v", built, findLeftMost)
+	}
+}
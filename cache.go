@@ -0,0 +1,74 @@
+package turing
+
+import (
+	"strconv"
+	"sync"
+)
+
+type (
+	// Caches the results of `Measure`, keyed by the machine's fingerprint (see `Fingerprint`), so
+	// that machines that are re-enumerated or differ only in the names and order of their
+	// m-configurations are only simulated once. Safe for concurrent use. A nil cache measures every
+	// machine.
+	MeasureCache struct {
+		mutex   sync.Mutex
+		metrics map[string]Metrics
+		stats   CacheStats
+	}
+
+	// Statistics of a MeasureCache
+	CacheStats struct {
+		// The amount of measurements found in the cache
+		Hits int
+
+		// The amount of measurements simulated (and added to the cache)
+		Misses int
+
+		// The amount of measurements in the cache
+		Entries int
+	}
+)
+
+// Returns an empty cache
+func NewMeasureCache() *MeasureCache {
+	return &MeasureCache{
+		metrics: map[string]Metrics{},
+	}
+}
+
+// Returns the cached measurement of the machine (see `Measure`), simulating it if not yet cached
+func (c *MeasureCache) Measure(input MachineInput, limit int) Metrics {
+	if c == nil {
+		return Measure(input, limit)
+	}
+	key := Fingerprint(input) + ":" + strconv.Itoa(limit)
+
+	c.mutex.Lock()
+	metrics, ok := c.metrics[key]
+	if ok {
+		c.stats.Hits++
+	}
+	c.mutex.Unlock()
+	if ok {
+		return metrics
+	}
+
+	metrics = Measure(input, limit)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stats.Misses++
+	c.metrics[key] = metrics
+	return metrics
+}
+
+// Returns the statistics of the cache so far
+func (c *MeasureCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.metrics)
+	return stats
+}
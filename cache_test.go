@@ -0,0 +1,42 @@
+package turing
+
+import "testing"
+
+func TestMeasureCache(t *testing.T) {
+	input := getBusyBeaverMachineInput([]MConfiguration{
+		{"A", []string{"0"}, []string{"P1", "R"}, "B"},
+		{"A", []string{"1"}, []string{"P1", "L"}, "B"},
+		{"B", []string{"0"}, []string{"P1", "L"}, "A"},
+		{"B", []string{"1"}, []string{"P1", "R"}, "halt"},
+	})
+	// The same machine, with its m-configurations renamed and reordered
+	isomorphic := getBusyBeaverMachineInput([]MConfiguration{
+		{"x", []string{"1"}, []string{"P1", "R"}, "halt"},
+		{"x", []string{"0"}, []string{"P1", "L"}, "y"},
+		{"y", []string{"0"}, []string{"P1", "R"}, "x"},
+		{"y", []string{"1"}, []string{"P1", "L"}, "x"},
+	})
+	isomorphic.StartingMConfiguration = "y"
+
+	cache := NewMeasureCache()
+	expected := Measure(input, defaultBusyBeaverMaxMoves)
+	for _, machine := range []MachineInput{input, isomorphic, input} {
+		if actual := cache.Measure(machine, defaultBusyBeaverMaxMoves); actual != expected {
+			t.Errorf("got %+v, want %+v", actual, expected)
+		}
+	}
+	cache.Measure(input, 2)
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Misses: 2, Entries: 2}) {
+		t.Errorf("got %+v", stats)
+	}
+
+	t.Run("Search", func(t *testing.T) {
+		cache := NewMeasureCache()
+		if result, err := BusyBeaver(1, WithMeasureCache(cache)); err != nil || result.Score != 1 {
+			t.Errorf("Incorrect BB-1 number %d (%v), expected 1", result.Score, err)
+		}
+		if stats := cache.Stats(); stats.Misses == 0 || stats.Misses != stats.Entries {
+			t.Errorf("got %+v", stats)
+		}
+	})
+}
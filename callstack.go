@@ -0,0 +1,31 @@
+package turing
+
+// Returns skeleton tables (m-functions) implementing a call stack, so that abbreviated tables can call
+// subroutines rather than pass every continuation as a parameter. The stack lies left of the first
+// square bearing `bottom` (Turing's machines begin their tapes with `e`), growing to the left.
+//
+// `call(S, K)` pushes `K` and -> `S`, and `ret` pops the most recent `K` and -> `K`. Both leave the
+// machine scanning the first `bottom`, as the subroutine or continuation expects to find its way
+// from there (as Turing's `f` does). The continuations are printed on the stack, so they must be
+// among the PossibleSymbols, and must not otherwise be printed or searched for. Returning with an
+// empty stack is undefined.
+func CallStack(bottom string) []MConfiguration {
+	return []MConfiguration{
+		// Find the first `bottom`, then push `K` onto the first blank square left of it
+		{"call(S, K)", []string{bottom}, []string{"L"}, "call1(S, K)"},
+		{"call(S, K)", []string{not + bottom, none}, []string{"L"}, "call(S, K)"},
+		{"call1(S, K)", []string{any}, []string{"L"}, "call1(S, K)"},
+		{"call1(S, K)", []string{none}, []string{"PK"}, "call2(S)"},
+		{"call2(S)", []string{bottom}, []string{}, "S"},
+		{"call2(S)", []string{not + bottom, none}, []string{"R"}, "call2(S)"},
+
+		// Find the first `bottom`, then pop the continuation right of the first blank square left of it
+		{"ret", []string{bottom}, []string{"L"}, "ret1"},
+		{"ret", []string{not + bottom, none}, []string{"L"}, "ret"},
+		{"ret1", []string{any}, []string{"L"}, "ret1"},
+		{"ret1", []string{none}, []string{"R"}, "ret2"},
+		{"ret2", []string{"_k"}, []string{"E"}, "ret3(_k)"},
+		{"ret3(K)", []string{bottom}, []string{}, "K"},
+		{"ret3(K)", []string{not + bottom, none}, []string{"R"}, "ret3(K)"},
+	}
+}
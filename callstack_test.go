@@ -0,0 +1,38 @@
+package turing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestCallStack(t *testing.T) {
+	// `twice` prints `0` at the end twice by calling `once` twice, and is itself called twice
+	mConfigurations := []MConfiguration{
+		{"b", []string{"*", " "}, []string{"Pe", "R", "Pe"}, "call(twice, k1)"},
+		{"k1", []string{"*", " "}, []string{}, "call(twice, k2)"},
+		{"k2", []string{"*", " "}, []string{}, "pe(halt, 1)"},
+		{"twice", []string{"*", " "}, []string{}, "call(once, t1)"},
+		{"t1", []string{"*", " "}, []string{}, "call(once, ret)"},
+		{"once", []string{"*", " "}, []string{}, "pe(ret, 0)"},
+	}
+	mConfigurations = append(mConfigurations, CallStack("e")...)
+	mConfigurations = append(mConfigurations, StandardSkeletons()...)
+	input, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+		MConfigurations:        mConfigurations,
+		PossibleSymbols:        []string{"e", "0", "1", "k1", "k2", "t1", "ret"},
+		HaltingMConfigurations: []string{"halt"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMachine(input)
+	if _, err := m.RunUntilHalt(10000); err != nil {
+		t.Fatal(err)
+	}
+	// The stack, left of the first `e`, is empty again
+	turingtest.Equal(t, m.TapeString()[m.Origin():], "ee0 0 0 0 1")
+	turingtest.Equal(t, strings.TrimSpace(m.Tape()[:m.Origin()].String("")), "")
+}
@@ -0,0 +1,170 @@
+package turing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The fields of a machine hashed by `Fingerprint` (see the corresponding MachineInput fields)
+type fingerprintedMachine struct {
+	MConfigurations        []MConfiguration
+	Tape                   Tape
+	StartingSquare         int
+	StartingMConfiguration string
+	PossibleSymbols        []string
+	NoneSymbol             string
+	StrictHalt             bool
+	HaltingMConfigurations []string
+	BackgroundPattern      []string
+	MaxTapeSquares         int
+	BeepMConfigurations    []string
+	LeftBound              LeftBound
+}
+
+// The prefix of the m-configuration names given by `Canonicalize`
+const canonicalMConfigurationPrefix string = "q"
+
+// Returns the machine in a canonical form, so that machines differing only in the names and order of
+// their m-configurations (or the order of their symbols) are written identically. The m-configurations
+// are renamed `q1`, `q2`, ... in the order a breadth-first search from the starting m-configuration
+// visits them (unreachable ones follow, searched from each in their original order), and `halt` keeps
+// its name. Within an m-configuration, rows are sorted by their symbols unless the order matters
+// (because a row uses `*` or `!`, has no symbols, or shares a symbol with another row). Abbreviated
+// tables should be compiled first.
+func Canonicalize(input MachineInput) MachineInput {
+	// Group the rows of each m-configuration, in the order they are first defined
+	rows := map[string][]MConfiguration{}
+	defined := []string{}
+	for _, mConfiguration := range cloneMConfigurations(input.MConfigurations) {
+		if _, ok := rows[mConfiguration.Name]; !ok {
+			defined = append(defined, mConfiguration.Name)
+		}
+		slices.Sort(mConfiguration.Symbols)
+		rows[mConfiguration.Name] = append(rows[mConfiguration.Name], mConfiguration)
+	}
+	for name, mConfigurations := range rows {
+		if !slices.ContainsFunc(mConfigurations, isOrderedRow) && haveDisjointSymbols(mConfigurations) {
+			slices.SortStableFunc(mConfigurations, func(a, b MConfiguration) int {
+				return slices.Compare(a.Symbols, b.Symbols)
+			})
+			rows[name] = mConfigurations
+		}
+	}
+
+	// Name the m-configurations breadth-first
+	names := map[string]string{}
+	order := []string{}
+	visit := func(name string) {
+		if _, ok := names[name]; ok {
+			return
+		}
+		if name == haltMConfigurationName {
+			names[name] = name
+		} else {
+			names[name] = canonicalMConfigurationPrefix + strconv.Itoa(len(order)+1)
+		}
+		order = append(order, name)
+	}
+	searched := 0
+	search := func(name string) {
+		visit(name)
+		for ; searched < len(order); searched++ {
+			for _, mConfiguration := range rows[order[searched]] {
+				visit(mConfiguration.FinalMConfiguration)
+			}
+		}
+	}
+	search(startingMConfigurationName(input))
+	for _, name := range defined {
+		search(name)
+	}
+	for _, name := range slices.Concat(input.HaltingMConfigurations, input.BeepMConfigurations) {
+		visit(name)
+	}
+
+	canonical := input
+	canonical.MConfigurations = []MConfiguration{}
+	for _, name := range order {
+		for _, mConfiguration := range rows[name] {
+			mConfiguration.Name = names[mConfiguration.Name]
+			mConfiguration.FinalMConfiguration = names[mConfiguration.FinalMConfiguration]
+			canonical.MConfigurations = append(canonical.MConfigurations, mConfiguration)
+		}
+	}
+	canonical.StartingMConfiguration = names[startingMConfigurationName(input)]
+	if input.PossibleSymbols != nil {
+		canonical.PossibleSymbols = slices.Sorted(slices.Values(input.PossibleSymbols))
+	}
+	if input.HaltingMConfigurations != nil {
+		canonical.HaltingMConfigurations = []string{}
+		for _, name := range input.HaltingMConfigurations {
+			canonical.HaltingMConfigurations = append(canonical.HaltingMConfigurations, names[name])
+		}
+		slices.Sort(canonical.HaltingMConfigurations)
+	}
+	if input.BeepMConfigurations != nil {
+		canonical.BeepMConfigurations = []string{}
+		for _, name := range input.BeepMConfigurations {
+			canonical.BeepMConfigurations = append(canonical.BeepMConfigurations, names[name])
+		}
+		slices.Sort(canonical.BeepMConfigurations)
+	}
+	if input.Exports != nil {
+		canonical.Exports = []string{}
+		for _, name := range input.Exports {
+			canonical.Exports = append(canonical.Exports, names[name])
+		}
+		slices.Sort(canonical.Exports)
+	}
+	return canonical
+}
+
+// Returns a fingerprint of the machine's canonical form (see `Canonicalize`), so that machines
+// differing only in the names and order of their m-configurations have the same fingerprint. Only the
+// fields affecting how the machine runs are fingerprinted, so fields such as Debug, TrimTapeInterval
+// and Exports do not change it.
+func Fingerprint(input MachineInput) string {
+	canonical := Canonicalize(input)
+	data, _ := json.Marshal(fingerprintedMachine{
+		MConfigurations:        canonical.MConfigurations,
+		Tape:                   canonical.Tape,
+		StartingSquare:         canonical.StartingSquare,
+		StartingMConfiguration: canonical.StartingMConfiguration,
+		PossibleSymbols:        canonical.PossibleSymbols,
+		NoneSymbol:             canonical.NoneSymbol,
+		StrictHalt:             canonical.StrictHalt,
+		HaltingMConfigurations: canonical.HaltingMConfigurations,
+		BackgroundPattern:      canonical.BackgroundPattern,
+		MaxTapeSquares:         canonical.MaxTapeSquares,
+		BeepMConfigurations:    canonical.BeepMConfigurations,
+		LeftBound:              canonical.LeftBound,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Returns true if the row matches any symbol (has no symbols) or matches symbols with `*` or `!`, so
+// rows before it take precedence
+func isOrderedRow(mConfiguration MConfiguration) bool {
+	return len(mConfiguration.Symbols) == 0 || slices.ContainsFunc(mConfiguration.Symbols, func(symbol string) bool {
+		return symbol == any || strings.HasPrefix(symbol, not)
+	})
+}
+
+// Returns true if no symbol is matched by more than one of the rows, so their order does not matter
+func haveDisjointSymbols(mConfigurations []MConfiguration) bool {
+	seen := map[string]bool{}
+	for _, mConfiguration := range mConfigurations {
+		for _, symbol := range mConfiguration.Symbols {
+			if seen[symbol] {
+				return false
+			}
+			seen[symbol] = true
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestCanonicalize(t *testing.T) {
+	a := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{"0"}, []string{"R"}, "halt"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" ", "1"}, []string{"P1", "R"}, "b"},
+		},
+		PossibleSymbols: []string{"1", "0"},
+	}
+	b := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"third", []string{"1", " "}, []string{"P1", "R"}, "first"},
+			{"second", []string{" "}, []string{"R"}, "third"},
+			{"first", []string{" "}, []string{"P0", "R"}, "second"},
+			{"second", []string{"0"}, []string{"R"}, "halt"},
+		},
+		StartingMConfiguration: "first",
+		PossibleSymbols:        []string{"0", "1"},
+	}
+
+	canonical := Canonicalize(a)
+	expected := []MConfiguration{
+		{"q1", []string{" "}, []string{"P0", "R"}, "q2"},
+		{"q2", []string{" "}, []string{"R"}, "q3"},
+		{"q2", []string{"0"}, []string{"R"}, "halt"},
+		{"q3", []string{" ", "1"}, []string{"P1", "R"}, "q1"},
+	}
+	if !reflect.DeepEqual(canonical.MConfigurations, expected) {
+		t.Errorf("got %v, want %v", canonical.MConfigurations, expected)
+	}
+	if !reflect.DeepEqual(Canonicalize(b), canonical) {
+		t.Errorf("got %+v, want %+v", Canonicalize(b), canonical)
+	}
+	if !reflect.DeepEqual(Canonicalize(canonical), canonical) {
+		t.Error("canonicalizing twice changed the machine")
+	}
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("got different fingerprints for equal machines")
+	}
+
+	// The canonical form computes the same tape
+	m, mc := NewMachine(a), NewMachine(canonical)
+	m.MoveN(20)
+	mc.MoveN(20)
+	turingtest.TapePrefix(t, mc.TapeString(), m.TapeString())
+}
+
+func TestCanonicalizeKeepsOrderedRows(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"1"}, []string{"P0"}, "b"},
+			{"b", []string{"*"}, []string{"P1"}, "b"},
+			{"b", []string{" "}, []string{"P1"}, "b"},
+		},
+	}
+	canonical := Canonicalize(input)
+	for i, mConfiguration := range canonical.MConfigurations {
+		if !reflect.DeepEqual(mConfiguration.Symbols, input.MConfigurations[i].Symbols) {
+			t.Errorf("got %v, want rows in their original order", canonical.MConfigurations)
+		}
+	}
+
+	different := input
+	different.MConfigurations = []MConfiguration{
+		{"b", []string{"1"}, []string{"P1"}, "b"},
+		{"b", []string{"*"}, []string{"P1"}, "b"},
+		{"b", []string{" "}, []string{"P1"}, "b"},
+	}
+	if Fingerprint(input) == Fingerprint(different) {
+		t.Error("got the same fingerprint for different machines")
+	}
+}
+
+func TestCanonicalizeKeepsCatchAllRows(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"0"}, []string{"P1", "R"}, "b"},
+			{"b", []string{}, []string{"P0"}, "b"},
+		},
+		Tape: Tape{"0", "0"},
+	}
+	m := NewMachine(input)
+	m.MoveN(3)
+	mc := NewMachine(Canonicalize(input))
+	mc.MoveN(3)
+	turingtest.Equal(t, mc.TapeString(), m.TapeString())
+	turingtest.Equal(t, mc.TapeString(), "110")
+}
+
+func TestCanonicalizeKeepsOverlappingRows(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"1", "0"}, []string{"P1", "R"}, "b"},
+			{"b", []string{"0"}, []string{"P0", "R"}, "b"},
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+		Tape: Tape{"0", "0"},
+	}
+	canonical := Canonicalize(input)
+	expected := []MConfiguration{
+		{"q1", []string{"0", "1"}, []string{"P1", "R"}, "q1"},
+		{"q1", []string{"0"}, []string{"P0", "R"}, "q1"},
+		{"q1", []string{" "}, []string{"R"}, "q1"},
+	}
+	if !reflect.DeepEqual(canonical.MConfigurations, expected) {
+		t.Errorf("got %v, want %v", canonical.MConfigurations, expected)
+	}
+
+	// The canonical form computes the same tape
+	m, mc := NewMachine(input), NewMachine(canonical)
+	m.MoveN(3)
+	mc.MoveN(3)
+	turingtest.Equal(t, mc.TapeString(), m.TapeString())
+	turingtest.Equal(t, mc.TapeString(), "11 ")
+}
+
+func TestCanonicalizeBeepMConfigurations(t *testing.T) {
+	a := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P1", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "b"},
+		},
+		BeepMConfigurations: []string{"c"},
+	}
+	b := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"second", []string{" "}, []string{"R"}, "first"},
+			{"first", []string{" "}, []string{"P1", "R"}, "second"},
+		},
+		StartingMConfiguration: "first",
+		BeepMConfigurations:    []string{"second"},
+	}
+
+	canonical := Canonicalize(a)
+	if !reflect.DeepEqual(canonical.BeepMConfigurations, []string{"q2"}) {
+		t.Errorf("got %v, want [q2]", canonical.BeepMConfigurations)
+	}
+
+	// The canonical form beeps on the same moves
+	m, mc := NewMachine(a), NewMachine(canonical)
+	m.MoveN(5)
+	mc.MoveN(5)
+	if m.LastBeep() != 4 || mc.LastBeep() != m.LastBeep() {
+		t.Errorf("got last beep %d, want %d", mc.LastBeep(), m.LastBeep())
+	}
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("got different fingerprints for equal machines")
+	}
+	different := a
+	different.BeepMConfigurations = []string{"b"}
+	if Fingerprint(a) == Fingerprint(different) {
+		t.Error("got the same fingerprint for machines beeping in different m-configurations")
+	}
+}
+
+func TestFingerprintIgnoresNonBehavioralFields(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "b"},
+		},
+	}
+	observed := input
+	observed.Debug = true
+	observed.HeadTrajectoryInterval = 10
+	observed.RecordTapeWrites = true
+	observed.TrimTapeInterval = 100
+	observed.Exports = []string{"b"}
+	turingtest.Equal(t, Fingerprint(observed), Fingerprint(input))
+
+	bounded := input
+	bounded.LeftBound = StrictLeftBound
+	if Fingerprint(bounded) == Fingerprint(input) {
+		t.Error("got the same fingerprint for machines with different tapes")
+	}
+}
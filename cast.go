@@ -0,0 +1,91 @@
+package turing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type (
+	// Options for `WriteAsciicast`
+	AsciicastOptions struct {
+		// How long each move is shown for. Defaults to a tenth of a second.
+		FrameDuration time.Duration
+
+		// If `true`, the scanned square and the m-configuration are highlighted (see `AlignedConfiguration`)
+		Color bool
+
+		// If provided, the title of the recording
+		Title string
+	}
+
+	// The header of an asciicast (version 2) file
+	asciicastHeader struct {
+		Version int    `json:"version"`
+		Width   int    `json:"width"`
+		Height  int    `json:"height"`
+		Title   string `json:"title,omitempty"`
+	}
+)
+
+const (
+	// The version of the asciicast format written
+	asciicastVersion int = 2
+
+	// The duration of a frame if none is given
+	defaultAsciicastFrameDuration = 100 * time.Millisecond
+
+	// Clears the terminal and moves the cursor to the top left before each frame
+	ansiClearScreen string = "\x1b[H\x1b[2J"
+)
+
+// Runs the machine for at most `maxMoves` moves (stopping early if it halts), and writes the run as an
+// asciinema recording (an asciicast version 2 `.cast` file), so it can be played back in a terminal or
+// embedded in a web page with the asciinema player. Each frame shows the tape and m-configuration as
+// `AlignedConfiguration` does, followed by the amount of moves made.
+func WriteAsciicast(w io.Writer, input MachineInput, maxMoves int, options AsciicastOptions) error {
+	frameDuration := options.FrameDuration
+	if frameDuration <= 0 {
+		frameDuration = defaultAsciicastFrameDuration
+	}
+
+	// The header comes first but holds the size of the largest frame, so the frames are rendered beforehand
+	m := NewMachine(input)
+	frames := []string{}
+	header := asciicastHeader{Version: asciicastVersion, Title: options.Title}
+	for {
+		frame := fmt.Sprintf("%s\nmove %d", m.AlignedConfiguration(false), m.moves)
+		for _, line := range strings.Split(frame, "\n") {
+			header.Width = max(header.Width, SymbolWidth(line))
+		}
+		header.Height = max(header.Height, strings.Count(frame, "\n")+1)
+		if options.Color {
+			frame = fmt.Sprintf("%s\nmove %d", m.AlignedConfiguration(true), m.moves)
+		}
+		frames = append(frames, frame)
+
+		if m.moves >= maxMoves {
+			break
+		}
+		moves := m.moves
+		m.Move()
+		if m.moves == moves {
+			break
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+	for i, frame := range frames {
+		elapsed := (time.Duration(i) * frameDuration).Seconds()
+		output := ansiClearScreen + strings.ReplaceAll(frame, "\n", "\r\n")
+		if err := encoder.Encode([]interface{}{elapsed, "o", output}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
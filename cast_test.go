@@ -0,0 +1,55 @@
+package turing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestWriteAsciicast(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	var buffer bytes.Buffer
+	if err := WriteAsciicast(&buffer, input, 4, AsciicastOptions{FrameDuration: 500 * time.Millisecond, Title: "Example I"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	turingtest.Equal(t, lines[0], `{"version":2,"width":21,"height":3,"title":"Example I"}`)
+	if len(lines) != 6 {
+		t.Fatalf("got %d frames, want 5", len(lines)-1)
+	}
+	var event []interface{}
+	if err := json.Unmarshal([]byte(lines[5]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event[0] != 2.0 || event[1] != "o" {
+		t.Errorf("got %v, want an output event at 2 seconds", event[:2])
+	}
+	turingtest.Equal(t, event[2].(string), ansiClearScreen+"| 0 |   | 1 |   |   |\r\n                  ^ b\r\nmove 4")
+
+	t.Run("Halting", func(t *testing.T) {
+		// The frames stop once the machine halts
+		var buffer bytes.Buffer
+		halting := MachineInput{MConfigurations: []MConfiguration{{"b", []string{" "}, []string{"P1"}, "halt"}}}
+		if err := WriteAsciicast(&buffer, halting, 100, AsciicastOptions{Color: true}); err != nil {
+			t.Fatal(err)
+		}
+		if frames := strings.Count(buffer.String(), "\n") - 1; frames != 2 {
+			t.Errorf("got %d frames, want 2", frames)
+		}
+		if !strings.Contains(buffer.String(), `\u001b[7m`) {
+			t.Error("got no highlighting, want the scanned square highlighted")
+		}
+	})
+}
@@ -0,0 +1,196 @@
+// Command turing works with the machines of the turing package from the command line.
+//
+// Usage:
+//
+//	turing archive save <machine> <archive>    bundles the machine as a MachineArchive
+//	turing archive load <archive>              writes the archive's machine as JSON
+//	turing run [-moves n] <machine>            runs the machine and writes its tape
+//	turing machines                            lists the machines registered by name
+//	turing serve [-addr address] [machine]     serves a Playground for the machine
+//
+// A machine is the name of a machine of the `StandardMachineRegistry` (i.e. `bb2-champion`), or a file:
+// either a MachineArchive (ending in `.tmachine`) or a machine written by `WriteMachineInput`. `run`
+// and `serve` take `-register name=file` (any number of times) to register more machines by name, and
+// the Playground resolves the `machine` query parameter from the same registry.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/planetlambert/turing"
+)
+
+const usage = `usage:
+  turing archive save <machine> <archive>
+  turing archive load <archive>
+  turing run [-moves n] [-register name=file]... <machine>
+  turing machines
+  turing serve [-addr address] [-register name=file]... [machine]`
+
+var errUsage = errors.New(usage)
+
+// Registers machines read from files, given as `name=file` flags
+type registerFlag struct {
+	registry *turing.MachineRegistry
+}
+
+func (f registerFlag) String() string {
+	return ""
+}
+
+func (f registerFlag) Set(value string) error {
+	name, path, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("%q is not name=file", value)
+	}
+	return f.registry.RegisterFile(name, path)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Runs the subcommand named by the first argument, writing its output to `w`
+func run(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "archive":
+		return archive(args[1:], w)
+	case "run":
+		return runMachine(args[1:], w)
+	case "machines":
+		return listMachines(w)
+	case "serve":
+		return serve(args[1:], w)
+	}
+	return errUsage
+}
+
+// Saves a machine as an archive, or writes the machine of an archive
+func archive(args []string, w io.Writer) error {
+	switch {
+	case len(args) == 3 && args[0] == "save":
+		input, err := loadMachine(turing.StandardMachineRegistry(), args[1])
+		if err != nil {
+			return err
+		}
+		archive := turing.NewMachineArchive(input)
+		archive.Metadata["source"] = args[1]
+		return turing.SaveMachineArchiveFile(args[2], archive)
+	case len(args) == 2 && args[0] == "load":
+		archive, err := turing.LoadMachineArchiveFile(args[1])
+		if err != nil {
+			return err
+		}
+		return turing.WriteMachineInput(w, archive.Input)
+	}
+	return errUsage
+}
+
+// Runs a machine for at most the given amount of moves, and writes its tape
+func runMachine(args []string, w io.Writer) error {
+	registry := turing.StandardMachineRegistry()
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	moves := flags.Int("moves", 1000, "the most moves to make")
+	flags.Var(registerFlag{registry}, "register", "registers the machine in a file by name")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+	input, err := loadMachine(registry, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	m := turing.NewMachine(input)
+	m.MoveN(*moves)
+	_, err = fmt.Fprintln(w, strings.TrimRight(m.TapeString(), " "))
+	return err
+}
+
+// Writes the name and description of every registered machine
+func listMachines(w io.Writer) error {
+	for _, machine := range turing.StandardMachineRegistry().Machines() {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", machine.Name, machine.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serves a Playground for the machine (or the first registered machine) until the server fails
+func serve(args []string, w io.Writer) error {
+	registry := turing.StandardMachineRegistry()
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	addr := flags.String("addr", "localhost:8080", "the address to listen on")
+	flags.Var(registerFlag{registry}, "register", "registers the machine in a file by name")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 1 {
+		return errUsage
+	}
+	playground, err := newPlayground(registry, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "serving http://%s\n", *addr)
+	return http.ListenAndServe(*addr, playground)
+}
+
+// Returns a Playground for the machine (or the first registered machine if empty), resolving the
+// `machine` query parameter from the registry
+func newPlayground(registry *turing.MachineRegistry, machine string) (*turing.Playground, error) {
+	if len(machine) == 0 {
+		machine = registry.Machines()[0].Name
+	}
+	input, err := loadMachine(registry, machine)
+	if err != nil {
+		return nil, err
+	}
+	playground := turing.NewPlayground(input, 0)
+	playground.Registry = registry
+	return playground, nil
+}
+
+// Returns the machine registered under the name or, if there is none, reads it from the file: an
+// archive if it has the archive extension, or otherwise a machine written by `WriteMachineInput`.
+// An archive's Tape, if it has one, replaces the machine's.
+func loadMachine(registry *turing.MachineRegistry, name string) (turing.MachineInput, error) {
+	if machine, err := registry.Lookup(name); err == nil {
+		return machine.Input, nil
+	}
+	path := name
+	if strings.HasSuffix(path, turing.MachineArchiveExtension) {
+		archive, err := turing.LoadMachineArchiveFile(path)
+		if err != nil {
+			return turing.MachineInput{}, err
+		}
+		if len(archive.Tape) != 0 {
+			archive.Input.Tape = archive.Tape
+		}
+		return archive.Input, nil
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return turing.MachineInput{}, fmt.Errorf("%w: %s is neither registered nor a file", turing.ErrUnknownMachine, name)
+	}
+	if err != nil {
+		return turing.MachineInput{}, err
+	}
+	defer file.Close()
+	input, err := turing.ReadMachineInput(file)
+	if err != nil {
+		return turing.MachineInput{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return input, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing"
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	machine := filepath.Join(dir, "machine.json")
+	var input bytes.Buffer
+	if err := turing.WriteMachineInput(&input, turing.MachineInput{
+		MConfigurations: []turing.MConfiguration{
+			{Name: "b", Symbols: []string{" "}, Operations: []string{"P0", "R"}, FinalMConfiguration: "c"},
+			{Name: "c", Symbols: []string{" "}, Operations: []string{"R"}, FinalMConfiguration: "e"},
+			{Name: "e", Symbols: []string{" "}, Operations: []string{"P1", "R"}, FinalMConfiguration: "k"},
+			{Name: "k", Symbols: []string{" "}, Operations: []string{"R"}, FinalMConfiguration: "b"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(machine, input.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(dir, "machine"+turing.MachineArchiveExtension)
+	var output bytes.Buffer
+	if err := run([]string{"archive", "save", machine, archive}, &output); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"archive", "load", archive}, &output); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := turing.ReadMachineInput(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	turingtest.Equal(t, loaded.MConfigurations[3].FinalMConfiguration, "b")
+
+	// The archive runs like the machine it was saved from
+	for _, path := range []string{machine, archive} {
+		output.Reset()
+		if err := run([]string{"run", "-moves", "8", path}, &output); err != nil {
+			t.Fatal(err)
+		}
+		turingtest.Equal(t, output.String(), "0 1 0 1\n")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	var output bytes.Buffer
+	if err := run([]string{"machines"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "bb2-champion\t") {
+		t.Errorf("got %q, want bb2-champion listed", output.String())
+	}
+
+	// Machines are resolved by name
+	output.Reset()
+	if err := run([]string{"run", "-moves", "8", "turing-example-1"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	turingtest.Equal(t, output.String(), "0 1 0 1\n")
+
+	// Machines in files are registered by name
+	dir := t.TempDir()
+	machine := filepath.Join(dir, "machine.json")
+	var input bytes.Buffer
+	turing.WriteMachineInput(&input, turing.MachineInput{
+		MConfigurations: []turing.MConfiguration{
+			{Name: "b", Symbols: []string{" "}, Operations: []string{"P1", "R"}, FinalMConfiguration: "b"},
+		},
+	})
+	if err := os.WriteFile(machine, input.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output.Reset()
+	if err := run([]string{"run", "-moves", "3", "-register", "ones=" + machine, "ones"}, &output); err != nil {
+		t.Fatal(err)
+	}
+	turingtest.Equal(t, output.String(), "111\n")
+
+	// The playground resolves machines from the registry
+	registry := turing.StandardMachineRegistry()
+	playground, err := newPlayground(registry, "bb2-champion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	champion, _ := registry.Lookup("bb2-champion")
+	turingtest.Equal(t, playground.Input.MConfigurations[0].Name, champion.Input.MConfigurations[0].Name)
+	if playground.Registry != registry {
+		t.Error("got a playground without the registry")
+	}
+	if _, err := newPlayground(registry, "bb1-champion"); !errors.Is(err, turing.ErrUnknownMachine) {
+		t.Errorf("got %v, want %v", err, turing.ErrUnknownMachine)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	for _, args := range [][]string{{}, {"jump"}, {"archive", "save", "machine.json"}, {"run", "-moves"}} {
+		if err := run(args, &bytes.Buffer{}); !errors.Is(err, errUsage) {
+			t.Errorf("got %v for %q, want the usage", err, args)
+		}
+	}
+}
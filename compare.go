@@ -0,0 +1,217 @@
+package turing
+
+import "fmt"
+
+type (
+	// What is compared between two machines running in lockstep
+	LockstepComparison int
+
+	// The result of running two machines in lockstep
+	LockstepResult struct {
+		// Whether the machines diverged
+		Diverged bool
+
+		// The amount of moves made before the machines diverged (or in total if they did not)
+		Moves int
+
+		// The compared value of the first machine when they diverged
+		A string
+
+		// The compared value of the second machine when they diverged
+		B string
+	}
+)
+
+const (
+	// Compare the complete configurations of the machines
+	CompareCompleteConfigurations LockstepComparison = iota
+	// Compare only the tapes of the machines
+	CompareTapes
+)
+
+// Runs two machines in lockstep (each on its own tape) for at most `maxMoves` moves, and reports
+// the first move after which their complete configurations (or tapes) differ, or one halts
+// without the other.
+func Lockstep(a, b MachineInput, maxMoves int, comparison LockstepComparison) LockstepResult {
+	ma := NewMachine(a)
+	mb := NewMachine(b)
+	for moves := 0; ; moves++ {
+		valueA, valueB := lockstepValue(ma, comparison), lockstepValue(mb, comparison)
+		if valueA != valueB || ma.halted != mb.halted {
+			return LockstepResult{
+				Diverged: true,
+				Moves:    moves,
+				A:        valueA,
+				B:        valueB,
+			}
+		}
+		if moves == maxMoves || (ma.halted && mb.halted) {
+			return LockstepResult{Moves: moves}
+		}
+		ma.Move()
+		mb.Move()
+	}
+}
+
+// Returns the value of the machine to compare
+func lockstepValue(m *Machine, comparison LockstepComparison) string {
+	if comparison == CompareTapes {
+		return m.TapeString()
+	}
+	return m.CompleteConfiguration()
+}
+
+// The most moves either machine makes when computing figures for `SameSequence`
+const sameSequenceMaxMoves = 1000000
+
+// Compares the first `digits` figures computed by two machines. The figures are the symbols `0` and
+// `1` on the F-squares (every other square, starting from the square originally scanned) up until a
+// blank F-square. Other symbols on the F-squares (such as Turing's `e` markers) are not figures. Returns
+// whether the sequences are the same, and the amount of figures that matched.
+func SameSequence(a, b MachineInput, digits int) (bool, int) {
+	return sameSequence(a, nil, b, nil, digits)
+}
+
+// Compares the first `digits` figures computed by a machine and by its standardized form, reading the
+// standardized machine's symbols through the StandardTable's SymbolMap (see `SameSequence`).
+func SameStandardSequence(input MachineInput, standardTable StandardTable, digits int) (bool, int) {
+	return sameSequence(input, nil, standardTable.MachineInput, standardTable.SymbolMap, digits)
+}
+
+// Compares the figures computed by two machines, reading the symbols of each through its SymbolMap
+// (if any)
+func sameSequence(a MachineInput, symbolsA SymbolMap, b MachineInput, symbolsB SymbolMap, digits int) (bool, int) {
+	figuresA := computeFigures(a, symbolsA, digits)
+	figuresB := computeFigures(b, symbolsB, digits)
+	for i := 0; i < digits; i++ {
+		if i >= len(figuresA) || i >= len(figuresB) {
+			return len(figuresA) == len(figuresB), i
+		}
+		if figuresA[i] != figuresB[i] {
+			return false, i
+		}
+	}
+	return true, digits
+}
+
+// Runs the machine until it has computed the amount of figures (or halts, or runs too long)
+func computeFigures(input MachineInput, symbols SymbolMap, digits int) []string {
+	m := NewMachine(input)
+	for moves := 0; moves < sameSequenceMaxMoves && !m.halted; moves += 100 {
+		if len(m.figures(symbols)) >= digits {
+			break
+		}
+		m.MoveN(100)
+	}
+	figures := m.figures(symbols)
+	return figures[:min(digits, len(figures))]
+}
+
+// Returns the figures on the F-squares, up until the first blank F-square. Symbols are read through
+// the SymbolMap (if any).
+func (m *Machine) figures(symbols SymbolMap) []string {
+	figures := []string{}
+	for _, symbol := range m.fSquareSymbols() {
+		if original, ok := symbols[symbol]; ok {
+			symbol = original
+		}
+		if symbol == "0" || symbol == "1" {
+			figures = append(figures, symbol)
+		}
+	}
+	return figures
+}
+
+// Returns the symbols on the F-squares, up until the first blank F-square
+func (m *Machine) fSquareSymbols() []string {
+	symbols := []string{}
+	for i := m.origin; i < len(m.tape) && m.tape[i] != m.noneSymbol; i += 2 {
+		symbols = append(symbols, m.tape[i])
+	}
+	return symbols
+}
+
+type (
+	// A stage of the pipeline checked by `VerifyRoundTrip`
+	RoundTripStage string
+
+	// The stage at which a machine stopped behaving the same during `VerifyRoundTrip`
+	RoundTripError struct {
+		// The stage that diverged
+		Stage RoundTripStage
+
+		// The amount of figures that matched before diverging
+		Figures int
+
+		// Why the stage diverged. Wraps `ErrRoundTripMismatch` unless the stage failed outright.
+		Err error
+	}
+)
+
+const (
+	// Standardizing the machine (see `NewStandardTable`)
+	RoundTripStandardize RoundTripStage = "standardize"
+	// Reconstructing the standardized machine from its S.D.
+	RoundTripStandardDescription RoundTripStage = "standard description"
+	// Reconstructing the standardized machine from its D.N.
+	RoundTripDescriptionNumber RoundTripStage = "description number"
+)
+
+// Standardizes the machine, serializes it to its S.D. and D.N., and reconstructs it from each.
+// Every stage must compute the same first `digits` figures as the stage before it (see
+// `SameStandardSequence`), and reconstructed machines must serialize to the same S.D. Returns a
+// `*RoundTripError` for the first stage that diverges.
+func VerifyRoundTrip(input MachineInput, digits int) error {
+	standardTable := NewStandardTable(input)
+	if same, figures := SameStandardSequence(input, standardTable, digits); !same {
+		return &RoundTripError{
+			Stage:   RoundTripStandardize,
+			Figures: figures,
+			Err:     fmt.Errorf("%w: figures differ", ErrRoundTripMismatch),
+		}
+	}
+
+	fromStandardDescription, err := NewMachineFromStandardDescription(standardTable.StandardDescription)
+	if err := verifyReconstruction(RoundTripStandardDescription, standardTable, fromStandardDescription, err, digits); err != nil {
+		return err
+	}
+	fromDescriptionNumber, err := NewMachineFromDescriptionNumber(standardTable.DescriptionNumber)
+	return verifyReconstruction(RoundTripDescriptionNumber, standardTable, fromDescriptionNumber, err, digits)
+}
+
+// Checks that a machine reconstructed from the standard table serializes and behaves the same
+func verifyReconstruction(stage RoundTripStage, standardTable StandardTable, reconstructed MachineInput, err error, digits int) error {
+	if err != nil {
+		return &RoundTripError{Stage: stage, Err: err}
+	}
+	if sd := toStandardDescription(reconstructed); sd != standardTable.StandardDescription {
+		return &RoundTripError{
+			Stage: stage,
+			Err:   fmt.Errorf("%w: S.D. %s became %s", ErrRoundTripMismatch, standardTable.StandardDescription, sd),
+		}
+	}
+
+	// The S.D. only describes the m-configurations, so the rest is carried over
+	standard := standardTable.MachineInput
+	reconstructed.Tape = standard.Tape
+	reconstructed.StartingSquare = standard.StartingSquare
+	reconstructed.StartingMConfiguration = standard.StartingMConfiguration
+	reconstructed.HaltingMConfigurations = standard.HaltingMConfigurations
+	symbols := standardTable.SymbolMap
+	if same, figures := sameSequence(standard, symbols, reconstructed, symbols, digits); !same {
+		return &RoundTripError{
+			Stage:   stage,
+			Figures: figures,
+			Err:     fmt.Errorf("%w: figures differ", ErrRoundTripMismatch),
+		}
+	}
+	return nil
+}
+
+func (e *RoundTripError) Error() string {
+	return fmt.Sprintf("%s after %d figures: %s", e.Stage, e.Figures, e.Err)
+}
+
+func (e *RoundTripError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,133 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLockstep(t *testing.T) {
+	a := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	short := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0"}, "b"},
+			{"b", []string{"0"}, []string{"R", "R", "P1"}, "b"},
+			{"b", []string{"1"}, []string{"R", "R", "P0"}, "b"},
+		},
+	}
+
+	result := Lockstep(a, a, 50, CompareCompleteConfigurations)
+	if result.Diverged || result.Moves != 50 {
+		t.Errorf("got %+v, want no divergence after 50 moves", result)
+	}
+
+	result = Lockstep(a, short, 50, CompareTapes)
+	if !result.Diverged || result.Moves != 2 {
+		t.Errorf("got %+v, want divergence after 2 moves", result)
+	}
+	if result.A != "0 " || result.B != "0 1" {
+		t.Errorf("got %q and %q, want \"0 \" and \"0 1\"", result.A, result.B)
+	}
+}
+
+func TestSameSequence(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+			{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+			{"o", []string{"0"}, []string{}, "q"},
+			{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+			{"q", []string{" "}, []string{"P1", "L"}, "p"},
+			{"p", []string{"x"}, []string{"E", "R"}, "q"},
+			{"p", []string{"e"}, []string{"R"}, "f"},
+			{"p", []string{" "}, []string{"L", "L"}, "p"},
+			{"f", []string{"*"}, []string{"R", "R"}, "f"},
+			{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+		},
+		PossibleSymbols: []string{"0", "1", "e", "x"},
+	}
+	same, matched := SameSequence(input, input, 20)
+	if !same || matched != 20 {
+		t.Errorf("got %t after %d figures, want the same 20 figures", same, matched)
+	}
+
+	same, matched = SameStandardSequence(input, NewStandardTable(input), 20)
+	if !same || matched != 20 {
+		t.Errorf("got %t after %d figures, want the same 20 figures as the standardized machine", same, matched)
+	}
+
+	different := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	same, matched = SameSequence(input, different, 20)
+	if same || matched != 1 {
+		t.Errorf("got %t after %d figures, want to differ after 1 figure", same, matched)
+	}
+
+	alternating := func(first, second string) MachineInput {
+		return MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P" + first, "R", "R"}, "c"},
+				{"c", []string{" "}, []string{"P" + second, "R", "R"}, "b"},
+			},
+		}
+	}
+	same, matched = SameSequence(alternating("0", "1"), alternating("1", "0"), 20)
+	if same || matched != 0 {
+		t.Errorf("got %t after %d figures, want 0101... and 1010... to differ at once", same, matched)
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	t.Run("Same", func(t *testing.T) {
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+				{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+				{"o", []string{"0"}, []string{}, "q"},
+				{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+				{"q", []string{" "}, []string{"P1", "L"}, "p"},
+				{"p", []string{"x"}, []string{"E", "R"}, "q"},
+				{"p", []string{"e"}, []string{"R"}, "f"},
+				{"p", []string{" "}, []string{"L", "L"}, "p"},
+				{"f", []string{"*"}, []string{"R", "R"}, "f"},
+				{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+			},
+			PossibleSymbols: []string{"0", "1", "e", "x"},
+		}
+		if err := VerifyRoundTrip(input, 20); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Diverges", func(t *testing.T) {
+		// Turing's standard form has no background pattern, so the standardized machine scans blanks
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"0", " "}, []string{"P0", "R", "R"}, "b"},
+				{"b", []string{"1"}, []string{"P1", "R", "R"}, "b"},
+			},
+			PossibleSymbols:   []string{"0", "1"},
+			BackgroundPattern: []string{"0", " ", "1", " "},
+		}
+		err := VerifyRoundTrip(input, 10)
+		var roundTripErr *RoundTripError
+		if !errors.As(err, &roundTripErr) || !errors.Is(err, ErrRoundTripMismatch) {
+			t.Fatalf("got %v, want a RoundTripError", err)
+		}
+		if roundTripErr.Stage != RoundTripStandardize || roundTripErr.Figures != 1 {
+			t.Errorf("got divergence at %s after %d figures, want standardize after 1", roundTripErr.Stage, roundTripErr.Figures)
+		}
+	})
+}
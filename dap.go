@@ -0,0 +1,390 @@
+package turing
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"slices"
+	"strconv"
+)
+
+type (
+	// Serves the Debug Adapter Protocol (DAP) for a Machine, so editors can step through
+	// machine runs with their native debugging UI. Breakpoints are set on m-configurations
+	// (as function breakpoints), and the variables are the machine's state and a window of the tape.
+	DebugAdapter struct {
+		reader      *bufio.Reader
+		writer      io.Writer
+		seq         int
+		machine     *Machine
+		stopOnEntry bool
+		maxMoves    int
+		breakpoints []dapBreakpoint
+	}
+
+	// The arguments of the DAP `launch` request
+	DebugLaunchArguments struct {
+		// The machine to debug
+		Machine MachineInput `json:"machine"`
+
+		// If `true`, the machine is paused before its first move
+		StopOnEntry bool `json:"stopOnEntry"`
+
+		// The amount of moves a `continue` request runs before pausing. Defaults to 1000000.
+		MaxMoves int `json:"maxMoves"`
+	}
+
+	// A DAP request
+	dapRequest struct {
+		Seq       int             `json:"seq"`
+		Command   string          `json:"command"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+
+	// A DAP response or event
+	dapMessage struct {
+		Seq        int         `json:"seq"`
+		Type       string      `json:"type"`
+		RequestSeq int         `json:"request_seq,omitempty"`
+		Success    bool        `json:"success,omitempty"`
+		Command    string      `json:"command,omitempty"`
+		Message    string      `json:"message,omitempty"`
+		Event      string      `json:"event,omitempty"`
+		Body       interface{} `json:"body,omitempty"`
+	}
+
+	// A function breakpoint, which may only stop the machine if its condition holds
+	dapBreakpoint struct {
+		name      string
+		condition *BreakpointExpression
+	}
+
+	// A DAP variable
+	dapVariable struct {
+		Name               string `json:"name"`
+		Value              string `json:"value"`
+		VariablesReference int    `json:"variablesReference"`
+	}
+)
+
+const (
+	dapThreadID                int = 1
+	dapMachineVariables        int = 1
+	dapTapeVariables           int = 2
+	dapTapeWindow              int = 10
+	defaultDebugMaxMoves       int = 1000000
+	dapContentLengthHeader         = "Content-Length"
+	dapStoppedReasonEntry          = "entry"
+	dapStoppedReasonStep           = "step"
+	dapStoppedReasonBreakpoint     = "function breakpoint"
+	dapStoppedReasonPause          = "pause"
+)
+
+// Returns a new DebugAdapter reading requests from `r` and writing responses and events to `w`
+func NewDebugAdapter(r io.Reader, w io.Writer) *DebugAdapter {
+	return &DebugAdapter{
+		reader:      bufio.NewReader(r),
+		writer:      w,
+		breakpoints: []dapBreakpoint{},
+	}
+}
+
+// Handles requests until the client disconnects or the reader is exhausted
+func (d *DebugAdapter) Serve() error {
+	for {
+		request, err := d.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		done, err := d.handle(request)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// Reads a single request, framed by a Content-Length header
+func (d *DebugAdapter) read() (dapRequest, error) {
+	header, err := textproto.NewReader(d.reader).ReadMIMEHeader()
+	if err != nil {
+		return dapRequest{}, err
+	}
+	length, err := strconv.Atoi(header.Get(dapContentLengthHeader))
+	if err != nil {
+		return dapRequest{}, fmt.Errorf("invalid %s: %w", dapContentLengthHeader, err)
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(d.reader, content); err != nil {
+		return dapRequest{}, err
+	}
+	var request dapRequest
+	err = json.Unmarshal(content, &request)
+	return request, err
+}
+
+// Writes a single message, framed by a Content-Length header
+func (d *DebugAdapter) write(message dapMessage) error {
+	d.seq++
+	message.Seq = d.seq
+	content, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(d.writer, "%s: %d\r\n\r\n%s", dapContentLengthHeader, len(content), content)
+	return err
+}
+
+// Responds to the request successfully
+func (d *DebugAdapter) respond(request dapRequest, body interface{}) error {
+	return d.write(dapMessage{
+		Type:       "response",
+		RequestSeq: request.Seq,
+		Success:    true,
+		Command:    request.Command,
+		Body:       body,
+	})
+}
+
+// Responds to the request with an error
+func (d *DebugAdapter) fail(request dapRequest, message string) error {
+	return d.write(dapMessage{
+		Type:       "response",
+		RequestSeq: request.Seq,
+		Command:    request.Command,
+		Message:    message,
+	})
+}
+
+// Sends an event
+func (d *DebugAdapter) event(event string, body interface{}) error {
+	return d.write(dapMessage{
+		Type:  "event",
+		Event: event,
+		Body:  body,
+	})
+}
+
+// Sends a `stopped` event
+func (d *DebugAdapter) stopped(reason string) error {
+	return d.event("stopped", map[string]interface{}{
+		"reason":            reason,
+		"threadId":          dapThreadID,
+		"allThreadsStopped": true,
+	})
+}
+
+// Handles a request, returning true if the session is over
+func (d *DebugAdapter) handle(request dapRequest) (bool, error) {
+	if d.machine == nil && !slices.Contains([]string{"initialize", "launch", "disconnect"}, request.Command) {
+		return false, d.fail(request, "no machine has been launched")
+	}
+
+	switch request.Command {
+	case "initialize":
+		if err := d.respond(request, map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsFunctionBreakpoints":      true,
+		}); err != nil {
+			return false, err
+		}
+		return false, d.event("initialized", nil)
+	case "launch":
+		var arguments DebugLaunchArguments
+		if err := json.Unmarshal(request.Arguments, &arguments); err != nil {
+			return false, d.fail(request, err.Error())
+		}
+		if len(arguments.Machine.MConfigurations) == 0 {
+			return false, d.fail(request, "the machine has no m-configurations")
+		}
+		d.machine = NewMachine(arguments.Machine)
+		d.stopOnEntry = arguments.StopOnEntry
+		d.maxMoves = arguments.MaxMoves
+		if d.maxMoves <= 0 {
+			d.maxMoves = defaultDebugMaxMoves
+		}
+		return false, d.respond(request, nil)
+	case "setFunctionBreakpoints":
+		return false, d.setFunctionBreakpoints(request)
+	case "setBreakpoints", "setExceptionBreakpoints":
+		// Machines have no source lines, so only function breakpoints are supported
+		return false, d.respond(request, map[string]interface{}{"breakpoints": []interface{}{}})
+	case "configurationDone":
+		if err := d.respond(request, nil); err != nil {
+			return false, err
+		}
+		if d.stopOnEntry {
+			return false, d.stopped(dapStoppedReasonEntry)
+		}
+		return false, d.run()
+	case "threads":
+		return false, d.respond(request, map[string]interface{}{
+			"threads": []map[string]interface{}{{"id": dapThreadID, "name": "machine"}},
+		})
+	case "stackTrace":
+		return false, d.respond(request, map[string]interface{}{
+			"stackFrames": []map[string]interface{}{{
+				"id":     0,
+				"name":   d.machine.currentMConfigurationName,
+				"line":   d.machine.moves,
+				"column": d.machine.Head(),
+			}},
+			"totalFrames": 1,
+		})
+	case "scopes":
+		return false, d.respond(request, map[string]interface{}{
+			"scopes": []map[string]interface{}{
+				{"name": "Machine", "variablesReference": dapMachineVariables, "expensive": false},
+				{"name": "Tape", "variablesReference": dapTapeVariables, "expensive": false},
+			},
+		})
+	case "variables":
+		return false, d.variables(request)
+	case "continue":
+		if err := d.respond(request, map[string]interface{}{"allThreadsContinued": true}); err != nil {
+			return false, err
+		}
+		return false, d.run()
+	case "next", "stepIn", "stepOut":
+		if err := d.respond(request, nil); err != nil {
+			return false, err
+		}
+		d.machine.Move()
+		if d.machine.halted {
+			return false, d.terminated()
+		}
+		return false, d.stopped(dapStoppedReasonStep)
+	case "pause":
+		// Moves are performed synchronously, so the machine is always paused between requests
+		if err := d.respond(request, nil); err != nil {
+			return false, err
+		}
+		return false, d.stopped(dapStoppedReasonPause)
+	case "disconnect", "terminate":
+		return true, d.respond(request, nil)
+	}
+	return false, d.fail(request, fmt.Sprintf("unsupported command %s", request.Command))
+}
+
+// Replaces the breakpoints with the m-configurations named. Breakpoints may have a condition
+// (see `BreakpointExpression`), and are unverified if it cannot be compiled.
+func (d *DebugAdapter) setFunctionBreakpoints(request dapRequest) error {
+	var arguments struct {
+		Breakpoints []struct {
+			Name      string `json:"name"`
+			Condition string `json:"condition"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(request.Arguments, &arguments); err != nil {
+		return d.fail(request, err.Error())
+	}
+
+	d.breakpoints = []dapBreakpoint{}
+	breakpoints := []map[string]interface{}{}
+	for _, breakpoint := range arguments.Breakpoints {
+		verified := slices.ContainsFunc(d.machine.mConfigurations, func(mConfiguration MConfiguration) bool {
+			return mConfiguration.Name == breakpoint.Name
+		})
+		response := map[string]interface{}{}
+		var condition *BreakpointExpression
+		if len(breakpoint.Condition) != 0 {
+			var err error
+			if condition, err = CompileBreakpoint(breakpoint.Condition); err != nil {
+				verified = false
+				response["message"] = err.Error()
+			}
+		}
+		if verified {
+			d.breakpoints = append(d.breakpoints, dapBreakpoint{name: breakpoint.Name, condition: condition})
+		}
+		response["verified"] = verified
+		breakpoints = append(breakpoints, response)
+	}
+	return d.respond(request, map[string]interface{}{"breakpoints": breakpoints})
+}
+
+// Moves the machine until it reaches a breakpoint, halts, or has made the maximum amount of moves
+func (d *DebugAdapter) run() error {
+	for i := 0; i < d.maxMoves; i++ {
+		d.machine.Move()
+		if d.machine.halted {
+			return d.terminated()
+		}
+		if d.atBreakpoint() {
+			return d.stopped(dapStoppedReasonBreakpoint)
+		}
+	}
+	return d.stopped(dapStoppedReasonPause)
+}
+
+// Returns true if the machine is in the m-configuration of a breakpoint whose condition holds
+func (d *DebugAdapter) atBreakpoint() bool {
+	return atBreakpoint(d.machine, d.breakpoints)
+}
+
+// Returns true if the machine is in the m-configuration of one of the breakpoints, and its condition holds
+func atBreakpoint(m *Machine, breakpoints []dapBreakpoint) bool {
+	for _, breakpoint := range breakpoints {
+		if breakpoint.name != m.currentMConfigurationName {
+			continue
+		}
+		if breakpoint.condition == nil {
+			return true
+		}
+		// Conditions that cannot be evaluated are treated as holding, so they are noticed
+		if holds, err := breakpoint.condition.Evaluate(m); holds || err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports that the machine halted
+func (d *DebugAdapter) terminated() error {
+	if d.machine.err != nil {
+		if err := d.event("output", map[string]interface{}{"category": "stderr", "output": d.machine.err.Error() + "\n"}); err != nil {
+			return err
+		}
+	}
+	return d.event("terminated", nil)
+}
+
+// Responds with the variables of the Machine or Tape scope
+func (d *DebugAdapter) variables(request dapRequest) error {
+	var arguments struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+	if err := json.Unmarshal(request.Arguments, &arguments); err != nil {
+		return d.fail(request, err.Error())
+	}
+
+	m := d.machine
+	variables := []dapVariable{}
+	switch arguments.VariablesReference {
+	case dapMachineVariables:
+		variables = append(variables,
+			dapVariable{Name: "m-configuration", Value: m.currentMConfigurationName},
+			dapVariable{Name: "scanned symbol", Value: strconv.Quote(m.squareSymbol(m.scannedSquare))},
+			dapVariable{Name: "head", Value: strconv.Itoa(m.Head())},
+			dapVariable{Name: "moves", Value: strconv.Itoa(m.moves)},
+			dapVariable{Name: "complete configuration", Value: m.CompleteConfiguration()},
+		)
+	case dapTapeVariables:
+		for square := m.scannedSquare - dapTapeWindow; square <= m.scannedSquare+dapTapeWindow; square++ {
+			name := strconv.Itoa(square - m.origin)
+			if square == m.scannedSquare {
+				name += " (scanned)"
+			}
+			variables = append(variables, dapVariable{Name: name, Value: strconv.Quote(m.squareSymbol(square))})
+		}
+	}
+	return d.respond(request, map[string]interface{}{"variables": variables})
+}
@@ -0,0 +1,151 @@
+package turing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"testing"
+)
+
+func TestDebugAdapter(t *testing.T) {
+	machine := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	messages := runDebugAdapter(t,
+		"initialize", nil,
+		"launch", DebugLaunchArguments{Machine: machine, StopOnEntry: true},
+		"setFunctionBreakpoints", map[string]interface{}{"breakpoints": []map[string]string{{"name": "k"}, {"name": "z"}}},
+		"configurationDone", nil,
+		"next", nil,
+		"continue", nil,
+		"variables", map[string]int{"variablesReference": dapMachineVariables},
+		"disconnect", nil,
+	)
+
+	events := []string{}
+	for _, message := range messages {
+		if message["type"] == "event" {
+			event := message["event"].(string)
+			if event == "stopped" {
+				event += ":" + message["body"].(map[string]interface{})["reason"].(string)
+			}
+			events = append(events, event)
+		} else if message["success"] != true {
+			t.Errorf("got failed response %v", message)
+		}
+	}
+	expected := []string{"initialized", "stopped:entry", "stopped:step", "stopped:function breakpoint"}
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("got %v, want %v", events, expected)
+	}
+
+	breakpoints := messages[3]["body"].(map[string]interface{})["breakpoints"].([]interface{})
+	if breakpoints[0].(map[string]interface{})["verified"] != true || breakpoints[1].(map[string]interface{})["verified"] != false {
+		t.Errorf("got %v, want k verified and z unverified", breakpoints)
+	}
+
+	variables := messages[len(messages)-2]["body"].(map[string]interface{})["variables"].([]interface{})
+	for _, variable := range variables {
+		variable := variable.(map[string]interface{})
+		if variable["name"] == "complete configuration" && variable["value"] != "0 1k" {
+			t.Errorf("got %v, want 0 1k", variable["value"])
+		}
+	}
+}
+
+func TestDebugAdapterTerminates(t *testing.T) {
+	messages := runDebugAdapter(t,
+		"launch", DebugLaunchArguments{Machine: MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "halt"},
+			},
+		}},
+		"configurationDone", nil,
+	)
+	if messages[len(messages)-1]["event"] != "terminated" {
+		t.Errorf("got %v, want terminated event", messages[len(messages)-1])
+	}
+}
+
+func TestDebugAdapterConditionalBreakpoints(t *testing.T) {
+	messages := runDebugAdapter(t,
+		"launch", DebugLaunchArguments{Machine: MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"R"}, "b"},
+			},
+		}},
+		"setFunctionBreakpoints", map[string]interface{}{"breakpoints": []map[string]string{
+			{"name": "b", "condition": "moves > 5 && tape[head-2] == \"0\""},
+			{"name": "c", "condition": "moves >"},
+		}},
+		"configurationDone", nil,
+		"variables", map[string]int{"variablesReference": dapMachineVariables},
+		"disconnect", nil,
+	)
+
+	breakpoints := messages[1]["body"].(map[string]interface{})["breakpoints"].([]interface{})
+	if breakpoints[0].(map[string]interface{})["verified"] != true || breakpoints[1].(map[string]interface{})["verified"] != false {
+		t.Errorf("got %v, want the first verified and the second unverified", breakpoints)
+	}
+
+	variables := messages[len(messages)-2]["body"].(map[string]interface{})["variables"].([]interface{})
+	for _, variable := range variables {
+		variable := variable.(map[string]interface{})
+		if variable["name"] == "moves" && variable["value"] != "6" {
+			t.Errorf("got %v moves, want 6", variable["value"])
+		}
+	}
+}
+
+// Sends pairs of commands and arguments to a DebugAdapter, and returns every message it wrote
+func runDebugAdapter(t *testing.T, commandsAndArguments ...interface{}) []map[string]interface{} {
+	var input bytes.Buffer
+	for i := 0; i < len(commandsAndArguments); i += 2 {
+		request := map[string]interface{}{
+			"seq":       i/2 + 1,
+			"type":      "request",
+			"command":   commandsAndArguments[i],
+			"arguments": commandsAndArguments[i+1],
+		}
+		content, err := json.Marshal(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+
+	var output bytes.Buffer
+	if err := NewDebugAdapter(&input, &output).Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []map[string]interface{}{}
+	reader := bufio.NewReader(&output)
+	for {
+		header, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err == io.EOF {
+			return messages
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		content := make([]byte, length)
+		io.ReadFull(reader, content)
+		var message map[string]interface{}
+		if err := json.Unmarshal(content, &message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}
+}
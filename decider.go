@@ -0,0 +1,81 @@
+package turing
+
+import "slices"
+
+type (
+	// Input for a machine that decides whether words belong to a language, rather than
+	// computing a sequence.
+	DeciderInput struct {
+		MachineInput
+
+		// The machine accepts the word as soon as it moves to one of these m-configurations
+		AcceptMConfigurations []string
+
+		// The machine rejects the word as soon as it moves to one of these m-configurations.
+		// Halting anywhere other than an accepting m-configuration is also a rejection.
+		RejectMConfigurations []string
+
+		// The amount of moves after which the machine is considered to have timed out.
+		// Defaults to 10000.
+		MaxMoves int
+	}
+
+	// The outcome of running a decider on a word
+	Decision int
+)
+
+const (
+	// The machine did not halt within the maximum amount of moves
+	Timeout Decision = iota
+	// The machine accepted the word
+	Accept
+	// The machine rejected the word
+	Reject
+)
+
+const (
+	defaultDeciderMaxMoves int = 10000
+)
+
+// Runs the machine with the word on its tape (starting at the scanned square), and returns
+// whether the machine accepted, rejected, or timed out.
+func (d DeciderInput) Decide(input []string) Decision {
+	_, decision := d.run(input)
+	return decision
+}
+
+// Runs the machine on the tape, returning the machine once it stopped and its decision
+func (d DeciderInput) run(tape []string) (*Machine, Decision) {
+	machineInput := d.MachineInput
+	machineInput.Tape = tape
+	machineInput.HaltingMConfigurations = append(slices.Clone(d.HaltingMConfigurations), d.AcceptMConfigurations...)
+	machineInput.HaltingMConfigurations = append(machineInput.HaltingMConfigurations, d.RejectMConfigurations...)
+
+	maxMoves := d.MaxMoves
+	if maxMoves <= 0 {
+		maxMoves = defaultDeciderMaxMoves
+	}
+
+	m := NewMachine(machineInput)
+	m.MoveN(maxMoves)
+	if !m.halted {
+		return m, Timeout
+	}
+	if m.err == nil && slices.Contains(d.AcceptMConfigurations, m.currentMConfigurationName) {
+		return m, Accept
+	}
+	return m, Reject
+}
+
+// Returns the decision as a string
+func (d Decision) String() string {
+	switch d {
+	case Timeout:
+		return "timeout"
+	case Accept:
+		return "accept"
+	case Reject:
+		return "reject"
+	}
+	return "unknown"
+}
@@ -0,0 +1,65 @@
+package turing
+
+import "testing"
+
+func TestDeciderEvenOnes(t *testing.T) {
+	d := DeciderInput{
+		MachineInput: MachineInput{
+			MConfigurations: []MConfiguration{
+				{"even", []string{"1"}, []string{"R"}, "odd"},
+				{"even", []string{" "}, []string{}, "accept"},
+				{"odd", []string{"1"}, []string{"R"}, "even"},
+				{"odd", []string{" "}, []string{}, "reject"},
+			},
+			PossibleSymbols: []string{"1"},
+		},
+		AcceptMConfigurations: []string{"accept"},
+		RejectMConfigurations: []string{"reject"},
+	}
+	for word, expected := range map[string]Decision{
+		"":     Accept,
+		"1":    Reject,
+		"11":   Accept,
+		"111":  Reject,
+		"1111": Accept,
+	} {
+		t.Run(word, func(t *testing.T) {
+			input := []string{}
+			for _, symbol := range word {
+				input = append(input, string(symbol))
+			}
+			if actual := d.Decide(input); actual != expected {
+				t.Errorf("got %s, want %s", actual, expected)
+			}
+		})
+	}
+}
+
+func TestDeciderRejectsOnOtherHalt(t *testing.T) {
+	d := DeciderInput{
+		MachineInput: MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"1"}, []string{"R"}, "accept"},
+			},
+		},
+		AcceptMConfigurations: []string{"accept"},
+	}
+	if actual := d.Decide([]string{"0"}); actual != Reject {
+		t.Errorf("got %s, want reject", actual)
+	}
+}
+
+func TestDeciderTimeout(t *testing.T) {
+	d := DeciderInput{
+		MachineInput: MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{"R"}, "b"},
+			},
+		},
+		AcceptMConfigurations: []string{"accept"},
+		MaxMoves:              100,
+	}
+	if actual := d.Decide([]string{}); actual != Timeout {
+		t.Errorf("got %s, want timeout", actual)
+	}
+}
@@ -0,0 +1,328 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// A deterministic finite automaton over single-square symbols
+	DFA struct {
+		// The states of the automaton, in the order their m-configurations should be listed.
+		// If empty, the starting state is listed first followed by the others in sorted order.
+		States []string
+
+		// The symbols the automaton reads
+		Alphabet []string
+
+		// The starting state
+		Start string
+
+		// The accepting states
+		Accept []string
+
+		// For each state, the state moved to on each symbol. A missing transition rejects the word.
+		Transitions map[string]map[string]string
+	}
+
+	// A nondeterministic finite automaton with empty (epsilon) moves, used to compile patterns
+	nfa struct {
+		epsilons    [][]int
+		transitions []map[string][]int
+	}
+
+	// A piece of an NFA with a single entry and a single exit
+	nfaFragment struct {
+		start int
+		end   int
+	}
+
+	// Parses a pattern into an NFA
+	patternParser struct {
+		pattern  []rune
+		position int
+		nfa      *nfa
+		alphabet []string
+	}
+)
+
+const (
+	dfaAcceptMConfigurationName string = "accept"
+	dfaRejectMConfigurationName string = "reject"
+	dfaStatePrefix              string = "d"
+)
+
+// Compiles the automaton into a decider. The machine reads the word from left to right
+// (one move per symbol) and accepts or rejects once it scans the blank square after the word.
+func (dfa DFA) Compile() DeciderInput {
+	states := dfa.states()
+	accept := uniqueName(dfaAcceptMConfigurationName, states)
+	reject := uniqueName(dfaRejectMConfigurationName, states)
+
+	mConfigurations := []MConfiguration{}
+	for _, state := range states {
+		transitions := dfa.Transitions[state]
+		for _, symbol := range dfa.symbols(transitions) {
+			mConfigurations = append(mConfigurations, MConfiguration{state, []string{symbol}, []string{string(rightOp)}, transitions[symbol]})
+		}
+		final := reject
+		if slices.Contains(dfa.Accept, state) {
+			final = accept
+		}
+		mConfigurations = append(mConfigurations, MConfiguration{state, []string{none}, []string{}, final})
+	}
+
+	return DeciderInput{
+		MachineInput: MachineInput{
+			MConfigurations:        mConfigurations,
+			StartingMConfiguration: dfa.Start,
+			PossibleSymbols:        slices.Clone(dfa.Alphabet),
+		},
+		AcceptMConfigurations: []string{accept},
+		RejectMConfigurations: []string{reject},
+	}
+}
+
+// Returns the states of the automaton in order
+func (dfa DFA) states() []string {
+	if len(dfa.States) != 0 {
+		return dfa.States
+	}
+	others := []string{}
+	add := func(state string) {
+		if state != dfa.Start && !slices.Contains(others, state) {
+			others = append(others, state)
+		}
+	}
+	for state, transitions := range dfa.Transitions {
+		add(state)
+		for _, next := range transitions {
+			add(next)
+		}
+	}
+	for _, state := range dfa.Accept {
+		add(state)
+	}
+	slices.Sort(others)
+	return append([]string{dfa.Start}, others...)
+}
+
+// Returns the symbols of the transitions in the order of the alphabet (then sorted)
+func (dfa DFA) symbols(transitions map[string]string) []string {
+	symbols := []string{}
+	for _, symbol := range dfa.Alphabet {
+		if _, ok := transitions[symbol]; ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+	extra := []string{}
+	for symbol := range transitions {
+		if !slices.Contains(symbols, symbol) {
+			extra = append(extra, symbol)
+		}
+	}
+	slices.Sort(extra)
+	return append(symbols, extra...)
+}
+
+// Returns the name (of an m-configuration or symbol), primed until it does not collide with any of the names
+func uniqueName(name string, names []string) string {
+	for slices.Contains(names, name) {
+		name += "'"
+	}
+	return name
+}
+
+// Compiles a restricted regular expression into a DFA. Every character is a symbol, apart from
+// `|` (alternation), `*` (zero or more), `+` (one or more), `?` (zero or one), and parentheses
+// for grouping. Special characters may be escaped with `\`. The whole word must match.
+// An error wrapping `ErrInvalidPattern` is returned if the pattern cannot be parsed.
+func CompileRegex(pattern string) (DFA, error) {
+	p := &patternParser{
+		pattern:  []rune(pattern),
+		nfa:      &nfa{},
+		alphabet: []string{},
+	}
+	fragment, err := p.parseAlternation()
+	if err != nil {
+		return DFA{}, err
+	}
+	if p.position < len(p.pattern) {
+		return DFA{}, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidPattern, p.pattern[p.position], p.position)
+	}
+	return p.nfa.determinize(fragment, p.alphabet), nil
+}
+
+// alternation := concatenation ('|' concatenation)*
+func (p *patternParser) parseAlternation() (nfaFragment, error) {
+	fragment, err := p.parseConcatenation()
+	if err != nil {
+		return fragment, err
+	}
+	for p.position < len(p.pattern) && p.pattern[p.position] == '|' {
+		p.position++
+		other, err := p.parseConcatenation()
+		if err != nil {
+			return fragment, err
+		}
+		start, end := p.nfa.newState(), p.nfa.newState()
+		p.nfa.epsilon(start, fragment.start)
+		p.nfa.epsilon(start, other.start)
+		p.nfa.epsilon(fragment.end, end)
+		p.nfa.epsilon(other.end, end)
+		fragment = nfaFragment{start, end}
+	}
+	return fragment, nil
+}
+
+// concatenation := repetition*
+func (p *patternParser) parseConcatenation() (nfaFragment, error) {
+	start := p.nfa.newState()
+	fragment := nfaFragment{start, start}
+	for p.position < len(p.pattern) && p.pattern[p.position] != '|' && p.pattern[p.position] != ')' {
+		next, err := p.parseRepetition()
+		if err != nil {
+			return fragment, err
+		}
+		p.nfa.epsilon(fragment.end, next.start)
+		fragment.end = next.end
+	}
+	return fragment, nil
+}
+
+// repetition := atom ('*' | '+' | '?')*
+func (p *patternParser) parseRepetition() (nfaFragment, error) {
+	fragment, err := p.parseAtom()
+	if err != nil {
+		return fragment, err
+	}
+	for p.position < len(p.pattern) && strings.ContainsRune("*+?", p.pattern[p.position]) {
+		start, end := p.nfa.newState(), p.nfa.newState()
+		p.nfa.epsilon(start, fragment.start)
+		p.nfa.epsilon(fragment.end, end)
+		switch p.pattern[p.position] {
+		case '*':
+			p.nfa.epsilon(start, end)
+			p.nfa.epsilon(fragment.end, fragment.start)
+		case '+':
+			p.nfa.epsilon(fragment.end, fragment.start)
+		case '?':
+			p.nfa.epsilon(start, end)
+		}
+		fragment = nfaFragment{start, end}
+		p.position++
+	}
+	return fragment, nil
+}
+
+// atom := '(' alternation ')' | '\' character | character (other than `*`, `!` and ` `)
+func (p *patternParser) parseAtom() (nfaFragment, error) {
+	character := p.pattern[p.position]
+	p.position++
+	switch character {
+	case '(':
+		fragment, err := p.parseAlternation()
+		if err != nil {
+			return fragment, err
+		}
+		if p.position >= len(p.pattern) || p.pattern[p.position] != ')' {
+			return fragment, fmt.Errorf("%w: missing ) at %d", ErrInvalidPattern, p.position)
+		}
+		p.position++
+		return fragment, nil
+	case '*', '+', '?':
+		return nfaFragment{}, fmt.Errorf("%w: nothing to repeat at %d", ErrInvalidPattern, p.position-1)
+	case '\\':
+		if p.position >= len(p.pattern) {
+			return nfaFragment{}, fmt.Errorf("%w: trailing \\", ErrInvalidPattern)
+		}
+		character = p.pattern[p.position]
+		p.position++
+	}
+	symbol := string(character)
+	// The machine deciding the language would read these as `*` (Any), `!` (Not) or ` ` (None)
+	if symbol == any || strings.Contains(symbol, not) || symbol == none {
+		return nfaFragment{}, fmt.Errorf("%w: %q cannot be a symbol at %d", ErrInvalidPattern, symbol, p.position-1)
+	}
+	if !slices.Contains(p.alphabet, symbol) {
+		p.alphabet = append(p.alphabet, symbol)
+	}
+	start, end := p.nfa.newState(), p.nfa.newState()
+	p.nfa.transitions[start][symbol] = append(p.nfa.transitions[start][symbol], end)
+	return nfaFragment{start, end}, nil
+}
+
+// Adds a new state to the NFA, returning its index
+func (n *nfa) newState() int {
+	n.epsilons = append(n.epsilons, []int{})
+	n.transitions = append(n.transitions, map[string][]int{})
+	return len(n.epsilons) - 1
+}
+
+// Adds an empty move between two states
+func (n *nfa) epsilon(from int, to int) {
+	n.epsilons[from] = append(n.epsilons[from], to)
+}
+
+// Returns the sorted set of states reachable from the states using only empty moves
+func (n *nfa) closure(states []int) []int {
+	closure := []int{}
+	for _, state := range states {
+		if !slices.Contains(closure, state) {
+			closure = append(closure, state)
+		}
+	}
+	for i := 0; i < len(closure); i++ {
+		for _, next := range n.epsilons[closure[i]] {
+			if !slices.Contains(closure, next) {
+				closure = append(closure, next)
+			}
+		}
+	}
+	slices.Sort(closure)
+	return closure
+}
+
+// Converts the NFA fragment to a DFA using the subset construction
+func (n *nfa) determinize(fragment nfaFragment, alphabet []string) DFA {
+	dfa := DFA{
+		Alphabet:    alphabet,
+		Accept:      []string{},
+		Transitions: map[string]map[string]string{},
+	}
+	names := map[string]string{}
+	subsets := [][]int{}
+	name := func(subset []int) string {
+		key := fmt.Sprint(subset)
+		if _, ok := names[key]; !ok {
+			names[key] = dfaStatePrefix + strconv.Itoa(len(subsets))
+			subsets = append(subsets, subset)
+			dfa.States = append(dfa.States, names[key])
+			if slices.Contains(subset, fragment.end) {
+				dfa.Accept = append(dfa.Accept, names[key])
+			}
+		}
+		return names[key]
+	}
+
+	dfa.Start = name(n.closure([]int{fragment.start}))
+	for i := 0; i < len(subsets); i++ {
+		from := dfa.States[i]
+		for _, symbol := range alphabet {
+			next := []int{}
+			for _, state := range subsets[i] {
+				next = append(next, n.transitions[state][symbol]...)
+			}
+			if len(next) == 0 {
+				continue
+			}
+			if dfa.Transitions[from] == nil {
+				dfa.Transitions[from] = map[string]string{}
+			}
+			dfa.Transitions[from][symbol] = name(n.closure(next))
+		}
+	}
+	return dfa
+}
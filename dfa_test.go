@@ -0,0 +1,96 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestDFACompile(t *testing.T) {
+	// Binary numbers divisible by three
+	decider := DFA{
+		Alphabet: []string{"0", "1"},
+		Start:    "r0",
+		Accept:   []string{"r0"},
+		Transitions: map[string]map[string]string{
+			"r0": {"0": "r0", "1": "r1"},
+			"r1": {"0": "r2", "1": "r0"},
+			"r2": {"0": "r1", "1": "r2"},
+		},
+	}.Compile()
+	for word, expected := range map[string]bool{
+		"0":    true,
+		"11":   true,
+		"110":  true,
+		"1001": true,
+		"1":    false,
+		"10":   false,
+		"111":  false,
+	} {
+		t.Run(word, func(t *testing.T) {
+			result, err := decider.RunWord(word, WordOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Accepted != expected {
+				t.Errorf("got %s, want accepted to be %t", result.Decision, expected)
+			}
+		})
+	}
+}
+
+func TestCompileRegex(t *testing.T) {
+	dfa, err := CompileRegex("a(b|c)*d+e?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decider := dfa.Compile()
+	for word, expected := range map[string]bool{
+		"ad":     true,
+		"abcbdd": true,
+		"acde":   true,
+		"":       false,
+		"a":      false,
+		"abe":    false,
+		"adee":   false,
+		"xd":     false,
+	} {
+		t.Run(word, func(t *testing.T) {
+			result, err := decider.RunWord(word, WordOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Accepted != expected {
+				t.Errorf("got %s, want accepted to be %t", result.Decision, expected)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"(ab", "*a", "a)", "a\\", "a\\*", "a!b", "a b"} {
+		if _, err := CompileRegex(pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("got %v for %q, want ErrInvalidPattern", err, pattern)
+		}
+	}
+}
+
+func TestCompileRegexStandardized(t *testing.T) {
+	dfa, _ := CompileRegex("(01)*")
+	decider := dfa.Compile()
+	st := NewStandardTable(decider.MachineInput)
+	for _, word := range []string{"0101", "010"} {
+		input := decider.MachineInput
+		input.Tape = []string{}
+		for _, symbol := range word {
+			input.Tape = append(input.Tape, string(symbol))
+		}
+		m := NewMachine(input)
+		m.MoveN(100)
+
+		standardInput := st.MachineInput
+		standardInput.Tape = NewStandardTable(input).MachineInput.Tape
+		standardM := NewMachine(standardInput)
+		standardM.MoveN(100)
+		turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(standardM.Tape()), m.TapeString())
+	}
+}
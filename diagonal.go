@@ -1,5 +1,10 @@
 package turing
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // The following m-functions and m-configurations test if a Standard Description
 // on the Tape is well-defined. It is assumed that the head of the Tape is at the
 // start of the S.D.
@@ -70,7 +75,8 @@ var (
 )
 
 // The following defines Turing's `H` machine. The entire machine is implemented
-// with the exception of the `D` machine (which is not possible).
+// with the exception of the `D` machine (which is not possible). Until then, `RunHMachine`
+// carries out its steps, with a bounded decider in place of `D`.
 var (
 	hMachinePossibleSymbols = []string{}
 
@@ -108,6 +114,129 @@ var (
 	}
 )
 
+type (
+	// Decides whether the machine described by the (well-defined) S.D. is circle-free, standing in for
+	// Turing's `D`, which cannot exist. Deciders can only be bounded (see `BoundedCircleFreeDecider`),
+	// answering `false` for machines they cannot show to be circle-free.
+	CircleFreeDecider func(sd StandardDescription) bool
+
+	// Options for `RunHMachine`
+	HMachineOptions struct {
+		// The first D.N. `H` considers (defaults to 1)
+		From int
+
+		// The last D.N. `H` considers
+		To int
+
+		// Decides which well-defined machines are circle-free, as `D` would
+		Decider CircleFreeDecider
+
+		// The most moves `U` may make to show the R-th figure of a machine (defaults to 10000000)
+		MaxMoves int
+	}
+
+	// What `H` did with a D.N. that is well-defined
+	HMachineStep struct {
+		// The D.N. of the machine
+		DescriptionNumber DescriptionNumber
+
+		// Whether `D` decided the machine is circle-free (`s`) or not (`u`)
+		Satisfactory bool
+
+		// The amount of satisfactory D.N.s up to and including this one, Turing's R(N)
+		R int
+
+		// If the machine is satisfactory, the R-th figure of its sequence (computed by `U`), which `H` prints
+		Digit string
+	}
+)
+
+// Carries out Turing's `H` for the D.N.s from `From` to `To`, with the decider in place of `D`. For each
+// D.N. (enumerate), `H` checks that it is well-defined and asks `D` whether the machine is circle-free
+// (check). If so, `U` computes the first R figures of the machine's sequence (simulate), the last of which
+// is the next digit of the diagonal sequence (print). Returns the steps taken for the well-defined D.N.s.
+// An error wrapping `ErrStepLimit` is returned if `U` does not show the R-th figure of a machine the
+// decider found circle-free within `MaxMoves` moves (the decider was wrong, or its bound too generous).
+func RunHMachine(options HMachineOptions) ([]HMachineStep, error) {
+	if options.Decider == nil {
+		return nil, fmt.Errorf("%w: H needs a decider", ErrInvalidOption)
+	}
+	from := max(options.From, 1)
+	maxMoves := options.MaxMoves
+	if maxMoves <= 0 {
+		maxMoves = universalMaxMovesPerFigure
+	}
+
+	steps := []HMachineStep{}
+	r := 0
+	for n := from; n <= options.To; n++ {
+		dn := DescriptionNumber(strconv.Itoa(n))
+		if _, err := NewMachineFromDescriptionNumber(dn); err != nil {
+			continue
+		}
+		sd := toStandardDescriptionFromDescriptionNumber(dn)
+		step := HMachineStep{DescriptionNumber: dn, R: r}
+		if options.Decider(sd) {
+			r++
+			digit, err := universalFigure(sd, r, maxMoves)
+			if err != nil {
+				return steps, fmt.Errorf("D.N. %s: %w", dn, err)
+			}
+			step.Satisfactory, step.R, step.Digit = true, r, digit
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// Returns a decider that runs the machine for `maxMoves` moves, finding it circle-free if it printed at
+// least `figures` figures (S1 or S2, which `U` shows as `0` and `1`) by then
+func BoundedCircleFreeDecider(maxMoves int, figures int) CircleFreeDecider {
+	return func(sd StandardDescription) bool {
+		input, err := NewMachineFromStandardDescription(sd)
+		if err != nil {
+			return false
+		}
+		input.RecordTapeWrites = true
+		m := NewMachine(input)
+		m.MoveN(maxMoves)
+		printed := 0
+		for _, write := range m.TapeWrites() {
+			if write.New == mConfigurationSymbolPrefix+"1" || write.New == mConfigurationSymbolPrefix+"2" {
+				printed++
+			}
+		}
+		return printed >= figures
+	}
+}
+
+// Simulates the machine with `U` (showing only `0` and `1`, as Turing's `show` does), and returns the
+// r-th figure shown
+func universalFigure(sd StandardDescription, r int, maxMoves int) (string, error) {
+	universal := NewUniversalMachine(UniversalMachineInput{
+		StandardDescription: sd,
+		Show:                OriginalShow,
+	})
+	universal.RecordTapeWrites = true
+	um := NewMachine(universal)
+	shown := 0
+	for moves := 0; moves < maxMoves && !um.halted; moves++ {
+		um.Move()
+		// The figures are the only `0` and `1` symbols `U` prints
+		for _, write := range um.tapeWrites {
+			if write.New != "0" && write.New != "1" {
+				continue
+			}
+			shown++
+			if shown == r {
+				return write.New, nil
+			}
+		}
+		um.tapeWrites = um.tapeWrites[:0]
+	}
+	return "", fmt.Errorf("%w: figure %d was not shown after %d moves", ErrStepLimit, r, maxMoves)
+}
+
 // The following defines Turing's `G` machine. The entire machine is implemented
 // with the exception of the `E` machine (which is not possible).
 var (
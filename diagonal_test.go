@@ -1,8 +1,11 @@
 package turing
 
 import (
-	"strings"
+	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
 )
 
 func TestFirstCircularDN(t *testing.T) {
@@ -20,13 +23,13 @@ func TestFirstCircleFreeDN(t *testing.T) {
 
 	m := NewMachine(machineInput)
 	m.MoveN(100)
-	checkTape(t, m.TapeString(), "S1S1S1S1S1S1S1")
+	turingtest.TapePrefix(t, m.TapeString(), "S1S1S1S1S1S1S1")
 }
 
 func TestWellDefinedness(t *testing.T) {
 	m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 		MConfigurations:        wellDefinedMachineMConfigurations,
-		Tape:                   strings.Split("; D A D A D A D", ""),
+		Tape:                   ParseTape("; D A D A D A D", ""),
 		StartingMConfiguration: "b",
 		PossibleSymbols:        wellDefinedMachinePossibleSymbols,
 	}))
@@ -38,7 +41,7 @@ func TestWellDefinedness(t *testing.T) {
 
 	m = NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
 		MConfigurations:        wellDefinedMachineMConfigurations,
-		Tape:                   strings.Split("; D A D D C R D A", ""),
+		Tape:                   ParseTape("; D A D D C R D A", ""),
 		StartingMConfiguration: "b",
 		PossibleSymbols:        wellDefinedMachinePossibleSymbols,
 	}))
@@ -49,6 +52,65 @@ func TestWellDefinedness(t *testing.T) {
 	}
 }
 
-// TODO: Test non-`D` part of `H`
+func TestHMachine(t *testing.T) {
+	decider := BoundedCircleFreeDecider(100, 3)
+
+	t.Run("CircleFree", func(t *testing.T) {
+		// 731332531 prints S1 forever, 731332631 prints S1 once and halts
+		steps, err := RunHMachine(HMachineOptions{
+			From:    731332500,
+			To:      731332700,
+			Decider: decider,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []HMachineStep{
+			{DescriptionNumber: "731332531", Satisfactory: true, R: 1, Digit: "0"},
+			{DescriptionNumber: "731332631", R: 1},
+		}
+		if !reflect.DeepEqual(steps, want) {
+			t.Errorf("got %v, want %v", steps, want)
+		}
+	})
+
+	t.Run("NoneCircleFree", func(t *testing.T) {
+		// The smallest well-defined D.N.s never print
+		steps, err := RunHMachine(HMachineOptions{
+			From:    73133400,
+			To:      73133700,
+			Decider: decider,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps) != 3 {
+			t.Fatalf("got %d steps, want 3", len(steps))
+		}
+		for _, step := range steps {
+			if step.Satisfactory {
+				t.Errorf("got %s satisfactory, want unsatisfactory", step.DescriptionNumber)
+			}
+		}
+	})
+
+	t.Run("WrongDecider", func(t *testing.T) {
+		_, err := RunHMachine(HMachineOptions{
+			From:     73133431,
+			To:       73133431,
+			Decider:  func(StandardDescription) bool { return true },
+			MaxMoves: 10000,
+		})
+		if !errors.Is(err, ErrStepLimit) {
+			t.Errorf("got %v, want %v", err, ErrStepLimit)
+		}
+	})
+
+	t.Run("NoDecider", func(t *testing.T) {
+		if _, err := RunHMachine(HMachineOptions{To: 1}); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("got %v, want %v", err, ErrInvalidOption)
+		}
+	})
+}
 
 // TODO: Test `M1`, `M2`, etc.
@@ -0,0 +1,200 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// How a row differs between two machines
+	RowChange string
+
+	// A row of the table that differs between two machines. Rows are identified by their
+	// m-configuration and scanned symbols.
+	RowDiff struct {
+		// Whether the row was added, removed or changed
+		Change RowChange `json:"change"`
+
+		// The row in the first machine (empty if it was added)
+		Old MConfiguration `json:"old"`
+
+		// The row in the second machine (empty if it was removed)
+		New MConfiguration `json:"new"`
+	}
+
+	// The differences between two machines' tables (see `Diff`)
+	MachineDiff struct {
+		// The rows added, removed or changed, in the order of the first machine's table followed
+		// by the rows only in the second machine's table
+		Rows []RowDiff `json:"rows"`
+
+		// The m-configurations only in the second machine
+		AddedMConfigurations []string `json:"addedMConfigurations"`
+
+		// The m-configurations only in the first machine
+		RemovedMConfigurations []string `json:"removedMConfigurations"`
+
+		// The symbols (declared, printed, matched or on the Tape) only in the second machine
+		AddedSymbols []string `json:"addedSymbols"`
+
+		// The symbols (declared, printed, matched or on the Tape) only in the first machine
+		RemovedSymbols []string `json:"removedSymbols"`
+	}
+)
+
+const (
+	// The row is only in the second machine
+	RowAdded RowChange = "added"
+
+	// The row is only in the first machine
+	RowRemoved RowChange = "removed"
+
+	// The row is in both machines, with different operations or final m-configuration
+	RowChanged RowChange = "changed"
+)
+
+// Compares the tables of two machines, reporting the rows, m-configurations and symbols added, removed
+// or changed from `a` to `b`. Rows are matched by their m-configuration and scanned symbols (in any
+// order), so renaming an m-configuration shows up as its rows being removed and added. The result
+// can be encoded as JSON, or written as text with `String`.
+func Diff(a, b MachineInput) MachineDiff {
+	diff := MachineDiff{
+		Rows:                   []RowDiff{},
+		AddedMConfigurations:   []string{},
+		RemovedMConfigurations: []string{},
+		AddedSymbols:           []string{},
+		RemovedSymbols:         []string{},
+	}
+
+	rowsB := map[string]MConfiguration{}
+	for _, mConfiguration := range b.MConfigurations {
+		rowsB[rowKey(mConfiguration)] = mConfiguration
+	}
+	rowsA := map[string]bool{}
+	for _, before := range a.MConfigurations {
+		key := rowKey(before)
+		rowsA[key] = true
+		after, ok := rowsB[key]
+		switch {
+		case !ok:
+			diff.Rows = append(diff.Rows, RowDiff{Change: RowRemoved, Old: before})
+		case !slices.Equal(before.Operations, after.Operations) || before.FinalMConfiguration != after.FinalMConfiguration:
+			diff.Rows = append(diff.Rows, RowDiff{Change: RowChanged, Old: before, New: after})
+		}
+	}
+	for _, after := range b.MConfigurations {
+		if !rowsA[rowKey(after)] {
+			diff.Rows = append(diff.Rows, RowDiff{Change: RowAdded, New: after})
+		}
+	}
+
+	diff.AddedMConfigurations, diff.RemovedMConfigurations = setDifferences(mConfigurationNames(a), mConfigurationNames(b))
+	diff.AddedSymbols, diff.RemovedSymbols = setDifferences(machineSymbols(a), machineSymbols(b))
+	return diff
+}
+
+// Returns true if the machines' tables do not differ
+func (d MachineDiff) Empty() bool {
+	return len(d.Rows) == 0 && len(d.AddedMConfigurations) == 0 && len(d.RemovedMConfigurations) == 0 &&
+		len(d.AddedSymbols) == 0 && len(d.RemovedSymbols) == 0
+}
+
+// Returns the differences one per line, in the form of a unified diff: removed rows are prefixed
+// with `-`, added rows with `+`, and changed rows are written as a removal followed by an addition.
+// Rows are written in assembly (see `CompileAssembly`), i.e. `- b: on " " do P0, R goto c`.
+func (d MachineDiff) String() string {
+	var s strings.Builder
+	for _, row := range d.Rows {
+		if row.Change != RowAdded {
+			s.WriteString(fmt.Sprintf("- %s%s %s\n", row.Old.Name, assemblyLabel, assemblyRule(row.Old)))
+		}
+		if row.Change != RowRemoved {
+			s.WriteString(fmt.Sprintf("+ %s%s %s\n", row.New.Name, assemblyLabel, assemblyRule(row.New)))
+		}
+	}
+	writeSet := func(heading string, added []string, removed []string) {
+		if len(added) == 0 && len(removed) == 0 {
+			return
+		}
+		s.WriteString(heading)
+		for _, item := range added {
+			s.WriteString(" +" + quoteAssembly(item))
+		}
+		for _, item := range removed {
+			s.WriteString(" -" + quoteAssembly(item))
+		}
+		s.WriteString("\n")
+	}
+	writeSet("m-configurations", d.AddedMConfigurations, d.RemovedMConfigurations)
+	writeSet("symbols", d.AddedSymbols, d.RemovedSymbols)
+	return s.String()
+}
+
+// Identifies a row by its m-configuration and scanned symbols
+func rowKey(mConfiguration MConfiguration) string {
+	symbols := slices.Clone(mConfiguration.Symbols)
+	slices.Sort(symbols)
+	return fmt.Sprintf("%q %q", mConfiguration.Name, symbols)
+}
+
+// Returns the names of the machine's m-configurations, in order of first appearance
+func mConfigurationNames(input MachineInput) []string {
+	names := []string{}
+	for _, mConfiguration := range input.MConfigurations {
+		if !slices.Contains(names, mConfiguration.Name) {
+			names = append(names, mConfiguration.Name)
+		}
+	}
+	return names
+}
+
+// Returns every symbol the machine declares, prints, matches or has on its Tape (other than
+// the None symbol), in order of first appearance
+func machineSymbols(input MachineInput) []string {
+	noneSymbol := input.NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+	symbols := []string{}
+	use := func(symbol string) {
+		if symbol != noneSymbol && !slices.Contains(symbols, symbol) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	for _, symbol := range input.PossibleSymbols {
+		use(symbol)
+	}
+	for _, mConfiguration := range input.MConfigurations {
+		for _, symbol := range mConfiguration.Symbols {
+			if symbol != any {
+				use(strings.TrimPrefix(symbol, not))
+			}
+		}
+		for _, operation := range mConfiguration.Operations {
+			if len(operation) > 1 && operationCode(operation[0]) == printOp {
+				use(operation[1:])
+			}
+		}
+	}
+	for _, square := range input.Tape {
+		use(square)
+	}
+	return symbols
+}
+
+// Returns the items only in `b` and the items only in `a`
+func setDifferences(a []string, b []string) ([]string, []string) {
+	added, removed := []string{}, []string{}
+	for _, item := range b {
+		if !slices.Contains(a, item) {
+			added = append(added, item)
+		}
+	}
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
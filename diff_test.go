@@ -0,0 +1,72 @@
+package turing
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	b := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"Pə", "R"}, "b"},
+			{"e", []string{"0", "1"}, []string{"R"}, "e"},
+		},
+		PossibleSymbols: []string{"0", "ə"},
+	}
+
+	// `1` is no longer declared or printed, but is still matched
+	diff := Diff(a, b)
+	expected := `- e: on " " do P1, R goto k
++ e: on " " do Pə, R goto b
+- k: on " " do R goto b
++ e: on 0 1 do R goto e
+m-configurations -k
+symbols +ə
+`
+	if diff.String() != expected {
+		t.Errorf("got\n%s\nwant\n%s", diff.String(), expected)
+	}
+	changes := []RowChange{}
+	for _, row := range diff.Rows {
+		changes = append(changes, row.Change)
+	}
+	if !slices.Equal(changes, []RowChange{RowChanged, RowRemoved, RowAdded}) {
+		t.Errorf("got %v, want changed, removed and added", changes)
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		encoded, err := json.Marshal(diff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded MachineDiff
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.String() != expected {
+			t.Errorf("got\n%s\nwant\n%s", decoded.String(), expected)
+		}
+	})
+
+	t.Run("Same", func(t *testing.T) {
+		// Rows are matched regardless of the order of their symbols
+		reordered := b
+		reordered.MConfigurations = slices.Clone(b.MConfigurations)
+		reordered.MConfigurations[3] = MConfiguration{"e", []string{"1", "0"}, []string{"R"}, "e"}
+		if diff := Diff(b, reordered); !diff.Empty() || diff.String() != "" {
+			t.Errorf("got %q, want no differences", diff.String())
+		}
+	})
+}
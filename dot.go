@@ -0,0 +1,117 @@
+package turing
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// How often each part of a Graph was visited during a run (see `RunRecording.Heat`)
+	GraphHeat struct {
+		// The amount of moves made from each m-configuration
+		Nodes map[string]int `json:"nodes"`
+
+		// The amount of times each row (in the order of the graph's Edges) was taken
+		Edges []int `json:"edges"`
+	}
+)
+
+// Returns the graph in the DOT language, to be drawn (as SVG, say) by Graphviz. Edges are labeled with
+// the symbols that cause them to be taken and the operations performed.
+func (g Graph) DOT() string {
+	return g.dot(nil)
+}
+
+// Returns the graph in the DOT language like `DOT`, with the m-configurations and edges colored by how
+// often the run visited them (the most visited in red, the unvisited in white) and labeled with the count,
+// so the parts of a table that dominate a run stand out. Edges taken more often are also drawn thicker.
+func (g Graph) HeatmapDOT(heat GraphHeat) string {
+	return g.dot(&heat)
+}
+
+// Writes the graph, colored by the heat if provided
+func (g Graph) dot(heat *GraphHeat) string {
+	hottest := 1
+	if heat != nil {
+		for _, count := range heat.Nodes {
+			hottest = max(hottest, count)
+		}
+		for _, count := range heat.Edges {
+			hottest = max(hottest, count)
+		}
+	}
+	// An HSV color from white (never visited) to red (visited the most)
+	color := func(count int) string {
+		return fmt.Sprintf("0.000 %.3f 1.000", float64(count)/float64(hottest))
+	}
+
+	var s strings.Builder
+	s.WriteString("digraph {\n")
+	for _, node := range g.Nodes {
+		if heat == nil {
+			s.WriteString(fmt.Sprintf("  %q;\n", node))
+			continue
+		}
+		count := heat.Nodes[node]
+		s.WriteString(fmt.Sprintf("  %q [label=%q, style=filled, fillcolor=%q];\n", node, fmt.Sprintf("%s\n%d", node, count), color(count)))
+	}
+	for i, edge := range g.Edges {
+		label := edgeLabel(edge)
+		if heat == nil {
+			s.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", edge.From, edge.To, label))
+			continue
+		}
+		count := 0
+		if i < len(heat.Edges) {
+			count = heat.Edges[i]
+		}
+		penWidth := 1 + 4*float64(count)/float64(hottest)
+		s.WriteString(fmt.Sprintf("  %q -> %q [label=%q, color=%q, penwidth=%.2f];\n", edge.From, edge.To,
+			fmt.Sprintf("%s (%d)", label, count), color(count), penWidth))
+	}
+	s.WriteString("}\n")
+	return s.String()
+}
+
+// Returns the symbols of the edge (` ` written as `None`, and no symbols as `any`), followed by its operations
+func edgeLabel(edge Edge) string {
+	symbols := []string{}
+	for _, symbol := range edge.Symbols {
+		if symbol == none {
+			symbol = "None"
+		}
+		symbols = append(symbols, symbol)
+	}
+	label := strings.Join(symbols, ", ")
+	if len(symbols) == 0 {
+		label = "any"
+	}
+	if len(edge.Operations) > 0 {
+		label += " / " + strings.Join(edge.Operations, ", ")
+	}
+	return label
+}
+
+// Runs the recorded machine again, counting the moves made from each m-configuration and the times each
+// row was taken, to be drawn over its graph (see `HeatmapDOT`)
+func (recording RunRecording) Heat() GraphHeat {
+	heat := GraphHeat{
+		Nodes: map[string]int{},
+		Edges: make([]int, len(recording.Input.MConfigurations)),
+	}
+	m := NewMachine(recording.Input)
+	for range recording.Steps {
+		symbol := m.squareSymbol(m.scannedSquare)
+		from := m.currentMConfigurationName
+		moves := m.moves
+		m.Move()
+		if m.moves == moves {
+			break
+		}
+		heat.Nodes[from]++
+		if i := m.findRow(from, symbol); i >= 0 {
+			heat.Edges[i]++
+		}
+	}
+	return heat
+}
@@ -0,0 +1,49 @@
+package turing
+
+import (
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestDOT(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+			{"k", []string{}, []string{}, "halt"},
+		},
+	}
+	turingtest.Equal(t, input.Graph().DOT(), `digraph {
+  "b";
+  "c";
+  "e";
+  "k";
+  "halt";
+  "b" -> "c" [label="None / P0, R"];
+  "c" -> "e" [label="None / R"];
+  "e" -> "k" [label="None / P1, R"];
+  "k" -> "b" [label="None / R"];
+  "k" -> "halt" [label="any"];
+}
+`)
+
+	t.Run("Heatmap", func(t *testing.T) {
+		heat := Record(input, 6).Heat()
+		turingtest.Equal(t, input.Graph().HeatmapDOT(heat), `digraph {
+  "b" [label="b\n2", style=filled, fillcolor="0.000 1.000 1.000"];
+  "c" [label="c\n2", style=filled, fillcolor="0.000 1.000 1.000"];
+  "e" [label="e\n1", style=filled, fillcolor="0.000 0.500 1.000"];
+  "k" [label="k\n1", style=filled, fillcolor="0.000 0.500 1.000"];
+  "halt" [label="halt\n0", style=filled, fillcolor="0.000 0.000 1.000"];
+  "b" -> "c" [label="None / P0, R (2)", color="0.000 1.000 1.000", penwidth=5.00];
+  "c" -> "e" [label="None / R (2)", color="0.000 1.000 1.000", penwidth=5.00];
+  "e" -> "k" [label="None / P1, R (1)", color="0.000 0.500 1.000", penwidth=3.00];
+  "k" -> "b" [label="None / R (1)", color="0.000 0.500 1.000", penwidth=3.00];
+  "k" -> "halt" [label="any (0)", color="0.000 0.000 1.000", penwidth=1.00];
+}
+`)
+	})
+}
@@ -0,0 +1,137 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Returns the non-negative integer written in unary (`n` 1's)
+func EncodeUnary(n int) Tape {
+	tape := Tape{}
+	for i := 0; i < n; i++ {
+		tape = append(tape, "1")
+	}
+	return tape
+}
+
+// Returns the integer written in unary, ignoring blanks at either end. An error wrapping
+// `ErrInvalidEncoding` is returned if any other symbol is on the tape.
+func DecodeUnary(tape Tape) (int, error) {
+	var n int
+	for _, square := range trimBlanks(tape) {
+		if square != "1" {
+			return 0, fmt.Errorf("%w: %q in unary", ErrInvalidEncoding, square)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Returns the non-negative integer written in binary (most significant digit first) on
+// the F-squares, leaving the E-squares in between blank as Turing does.
+func EncodeBinary(n int) Tape {
+	tape := Tape{}
+	for i, digit := range strconv.FormatInt(int64(max(n, 0)), 2) {
+		if i > 0 {
+			tape = append(tape, none)
+		}
+		tape = append(tape, string(digit))
+	}
+	return tape
+}
+
+// Returns the integer written in binary on the F-squares (the E-squares are ignored), up to
+// the first blank F-square. An error wrapping `ErrInvalidEncoding` is returned if an F-square
+// bears anything other than `0` or `1`.
+func DecodeBinary(tape Tape) (int, error) {
+	var n int
+	var digits int
+	for i := 0; i < len(tape) && tape[i] != none; i += 2 {
+		switch tape[i] {
+		case "0":
+			n *= 2
+		case "1":
+			n = n*2 + 1
+		default:
+			return 0, fmt.Errorf("%w: %q in binary", ErrInvalidEncoding, tape[i])
+		}
+		digits++
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("%w: no binary digits", ErrInvalidEncoding)
+	}
+	return n, nil
+}
+
+// Returns the string written one symbol per square. Symbols are those of the alphabet (the
+// longest matching symbol is used), or every character if the alphabet is empty. An error
+// wrapping `ErrNotInAlphabet` is returned if the string cannot be written in the alphabet.
+func EncodeString(s string, alphabet []string) (Tape, error) {
+	tape := Tape{}
+	if len(alphabet) == 0 {
+		for _, symbol := range s {
+			tape = append(tape, string(symbol))
+		}
+		return tape, nil
+	}
+	for len(s) > 0 {
+		longest := ""
+		for _, symbol := range alphabet {
+			if len(symbol) > len(longest) && strings.HasPrefix(s, symbol) {
+				longest = symbol
+			}
+		}
+		if len(longest) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrNotInAlphabet, s)
+		}
+		tape = append(tape, longest)
+		s = s[len(longest):]
+	}
+	return tape, nil
+}
+
+// Returns the symbols on the tape joined together, ignoring blanks at either end
+func DecodeString(tape Tape) string {
+	return strings.Join(trimBlanks(tape), "")
+}
+
+// Returns the values written one after another, separated by the delimiter
+func EncodeDelimited(values []Tape, delimiter string) Tape {
+	tape := Tape{}
+	for i, value := range values {
+		if i > 0 {
+			tape = append(tape, delimiter)
+		}
+		tape = append(tape, value...)
+	}
+	return tape
+}
+
+// Returns the values separated by the delimiter, ignoring blanks at either end of the tape
+func DecodeDelimited(tape Tape, delimiter string) []Tape {
+	values := []Tape{}
+	value := Tape{}
+	for _, square := range trimBlanks(tape) {
+		if square == delimiter {
+			values = append(values, value)
+			value = Tape{}
+			continue
+		}
+		value = append(value, square)
+	}
+	return append(values, value)
+}
+
+// Returns the squares without blanks at either end
+func trimBlanks(tape Tape) Tape {
+	start, end := 0, len(tape)
+	for start < end && tape[start] == none {
+		start++
+	}
+	for end > start && tape[end-1] == none {
+		end--
+	}
+	return slices.Clone(tape[start:end])
+}
@@ -0,0 +1,62 @@
+package turing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeUnary(t *testing.T) {
+	tape := EncodeUnary(3)
+	if !reflect.DeepEqual(tape, Tape{"1", "1", "1"}) {
+		t.Errorf("got %q, want 111", tape)
+	}
+	if n, err := DecodeUnary(append(Tape{" "}, tape...)); err != nil || n != 3 {
+		t.Errorf("got %d and %v, want 3", n, err)
+	}
+	if _, err := DecodeUnary(Tape{"1", "0"}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("got %v, want ErrInvalidEncoding", err)
+	}
+}
+
+func TestEncodeBinary(t *testing.T) {
+	tape := EncodeBinary(6)
+	if !reflect.DeepEqual(tape, Tape{"1", " ", "1", " ", "0"}) {
+		t.Errorf("got %q, want 1 1 0", tape)
+	}
+	if n, err := DecodeBinary(tape); err != nil || n != 6 {
+		t.Errorf("got %d and %v, want 6", n, err)
+	}
+	if n, err := DecodeBinary(Tape{"1", "x", "0", "x", "1"}); err != nil || n != 5 {
+		t.Errorf("got %d and %v, want 5 (E-squares ignored)", n, err)
+	}
+	for _, tape := range []Tape{{}, {"2"}} {
+		if _, err := DecodeBinary(tape); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("got %v, want ErrInvalidEncoding", err)
+		}
+	}
+}
+
+func TestEncodeString(t *testing.T) {
+	tape, err := EncodeString("::ab", []string{":", "::", "a", "b"})
+	if err != nil || !reflect.DeepEqual(tape, Tape{"::", "a", "b"}) {
+		t.Errorf("got %q and %v, want [:: a b]", tape, err)
+	}
+	if s := DecodeString(Tape{" ", "::", "a", " "}); s != "::a" {
+		t.Errorf("got %q, want ::a", s)
+	}
+	if _, err := EncodeString("c", []string{"a"}); !errors.Is(err, ErrNotInAlphabet) {
+		t.Errorf("got %v, want ErrNotInAlphabet", err)
+	}
+}
+
+func TestEncodeDelimited(t *testing.T) {
+	values := []Tape{EncodeUnary(2), {}, EncodeUnary(1)}
+	tape := EncodeDelimited(values, "#")
+	if !reflect.DeepEqual(tape, Tape{"1", "1", "#", "#", "1"}) {
+		t.Errorf("got %q, want 11##1", tape)
+	}
+	if decoded := DecodeDelimited(tape, "#"); !reflect.DeepEqual(decoded, values) {
+		t.Errorf("got %q, want %q", decoded, values)
+	}
+}
@@ -0,0 +1,125 @@
+package turing
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Returns the amount of machines of `n` m-configurations enumerated when each row is one of `choices`
+func enumerationSize(n int, symbols int, choices int) int {
+	return power(choices, n*symbols)
+}
+
+// Returns `base` raised to `exponent`
+func power(base int, exponent int) int {
+	result := 1
+	for i := 0; i < exponent; i++ {
+		result *= base
+	}
+	return result
+}
+
+// Calls yield with every machine of `n` m-configurations (named 0...n-1) over the symbols.
+// Each m-configuration has a row for each symbol that prints a symbol, moves left or right,
+// and moves to one of the m-configurations (or to `halt`, if `halting`). The slice passed
+// to yield is reused, so it must be cloned if kept. Enumeration stops if yield returns false.
+func enumerateMachines(n int, symbols []string, halting bool, yield func([]MConfiguration) bool) {
+	enumerateRows(n, symbols, quintupleChoices(enumerationFinals(n, halting), symbols), yield)
+}
+
+// Returns the m-configurations rows may move to: the `n` m-configurations, and `halt` if `halting`
+func enumerationFinals(n int, halting bool) []string {
+	finals := []string{}
+	for i := 0; i < n; i++ {
+		finals = append(finals, strconv.Itoa(i))
+	}
+	if halting {
+		finals = append(finals, haltMConfigurationName)
+	}
+	return finals
+}
+
+// Returns every row that prints a symbol, moves left or right, and moves to one of the finals
+func quintupleChoices(finals []string, symbols []string) []MConfiguration {
+	choices := []MConfiguration{}
+	for _, final := range finals {
+		for _, move := range []operationCode{leftOp, rightOp} {
+			for _, symbol := range symbols {
+				choices = append(choices, MConfiguration{
+					Operations:          []string{string(printOp) + symbol, string(move)},
+					FinalMConfiguration: final,
+				})
+			}
+		}
+	}
+	return choices
+}
+
+// Returns every row that either prints a symbol or moves left or right, and moves to one of the finals
+// (Post's quadruple form, rather than Turing's quintuple form)
+func quadrupleChoices(finals []string, symbols []string) []MConfiguration {
+	operations := []string{}
+	for _, symbol := range symbols {
+		operations = append(operations, string(printOp)+symbol)
+	}
+	operations = append(operations, string(leftOp), string(rightOp))
+
+	choices := []MConfiguration{}
+	for _, final := range finals {
+		for _, operation := range operations {
+			choices = append(choices, MConfiguration{
+				Operations:          []string{operation},
+				FinalMConfiguration: final,
+			})
+		}
+	}
+	return choices
+}
+
+// Calls yield with every machine of `n` m-configurations (named 0...n-1) with a row for each
+// symbol, each row being one of the choices (see `enumerateMachines`)
+func enumerateRows(n int, symbols []string, choices []MConfiguration, yield func([]MConfiguration) bool) {
+	mConfigurations := []MConfiguration{}
+	for i := 0; i < n; i++ {
+		for _, symbol := range symbols {
+			mConfigurations = append(mConfigurations, MConfiguration{
+				Name:                strconv.Itoa(i),
+				Symbols:             []string{symbol},
+				Operations:          choices[0].Operations,
+				FinalMConfiguration: choices[0].FinalMConfiguration,
+			})
+		}
+	}
+
+	// Every row is a digit of an odometer counting through the choices
+	digits := make([]int, len(mConfigurations))
+	for {
+		if !yield(mConfigurations) {
+			return
+		}
+
+		// Increment the odometer, returning once every digit has rolled over
+		i := 0
+		for ; i < len(digits); i++ {
+			digits[i] = (digits[i] + 1) % len(choices)
+			mConfigurations[i].Operations = choices[digits[i]].Operations
+			mConfigurations[i].FinalMConfiguration = choices[digits[i]].FinalMConfiguration
+			if digits[i] != 0 {
+				break
+			}
+		}
+		if i == len(digits) {
+			return
+		}
+	}
+}
+
+// Returns a copy of the m-configurations
+func cloneMConfigurations(mConfigurations []MConfiguration) []MConfiguration {
+	cloned := slices.Clone(mConfigurations)
+	for i := range cloned {
+		cloned[i].Symbols = slices.Clone(cloned[i].Symbols)
+		cloned[i].Operations = slices.Clone(cloned[i].Operations)
+	}
+	return cloned
+}
@@ -0,0 +1,78 @@
+package turing
+
+import "errors"
+
+// Errors returned (or wrapped) throughout the package, so callers can branch with `errors.Is`.
+var (
+	// The machine halted because no m-configuration matched the scanned symbol
+	ErrNoMatchingConfiguration = errors.New("no matching m-configuration")
+
+	// An operation is not one of `R`, `L`, `E`, `N`, `H`, or `P` followed by a symbol
+	ErrInvalidOperation = errors.New("invalid operation")
+
+	// The machine did not halt within the allowed amount of moves
+	ErrStepLimit = errors.New("step limit reached")
+
+	// A Description Number or Standard Description is not well-defined
+	ErrNotWellDefined = errors.New("not well defined")
+
+	// An abbreviated table calls an m-function that it does not define
+	ErrUnknownMFunction = errors.New("unknown m-function")
+
+	// A word contains a symbol outside of the input alphabet
+	ErrNotInAlphabet = errors.New("symbol not in input alphabet")
+
+	// A regular expression could not be parsed
+	ErrInvalidPattern = errors.New("invalid pattern")
+
+	// A file was written in a format version this package does not understand
+	ErrUnsupportedVersion = errors.New("unsupported version")
+
+	// Source text could not be parsed
+	ErrSyntax = errors.New("syntax error")
+
+	// A tape does not hold a value in the expected encoding
+	ErrInvalidEncoding = errors.New("invalid encoding")
+
+	// The machine would have grown its tape beyond the allowed amount of squares
+	ErrTapeLimit = errors.New("tape limit reached")
+
+	// The machine would have moved left of the leftmost square of a left-bounded tape
+	ErrLeftBound = errors.New("moved left of the leftmost square")
+
+	// Two simulators disagree about the outcome of running a machine
+	ErrSimulatorMismatch = errors.New("simulators disagree")
+
+	// Renaming symbols would give two symbols the same name
+	ErrSymbolCollision = errors.New("symbol collision")
+
+	// A halting certificate does not hold for the machine
+	ErrInvalidCertificate = errors.New("invalid certificate")
+
+	// Replaying a recorded run made a move other than the one recorded
+	ErrReplayMismatch = errors.New("replay does not match recording")
+
+	// A machine behaves differently after being standardized, serialized or reconstructed
+	ErrRoundTripMismatch = errors.New("round trip does not match")
+
+	// The Universal Machine shows a figure other than the one the machine prints
+	ErrUniversalMismatch = errors.New("universal machine disagrees")
+
+	// An option is out of range
+	ErrInvalidOption = errors.New("invalid option")
+
+	// A machine exports an m-configuration it does not define, or one exported by another machine
+	ErrInvalidExport = errors.New("invalid export")
+
+	// No machine is registered under the name
+	ErrUnknownMachine = errors.New("unknown machine")
+
+	// A machine cannot be expressed in another form (i.e. another simulator's format, or mirrored)
+	ErrUnsupportedMachine = errors.New("unsupported machine")
+
+	// A machine cannot be run backwards, as more than one complete configuration may lead to another
+	ErrNotReversible = errors.New("not reversible")
+
+	// A weight of a probabilistic machine is negative, or every m-configuration weighs zero
+	ErrInvalidWeight = errors.New("invalid weight")
+)
@@ -0,0 +1,172 @@
+package turing
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("NoMatchingConfiguration", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"0"}, []string{"R"}, "b"},
+			},
+			StrictHalt: true,
+		})
+		if _, err := m.RunUntilHalt(10); !errors.Is(err, ErrNoMatchingConfiguration) {
+			t.Errorf("got %v, want %v", err, ErrNoMatchingConfiguration)
+		}
+	})
+
+	t.Run("StepLimit", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"R"}, "b"},
+			},
+		})
+		if _, err := m.RunUntilHalt(10); !errors.Is(err, ErrStepLimit) {
+			t.Errorf("got %v, want %v", err, ErrStepLimit)
+		}
+	})
+
+	t.Run("NotWellDefined", func(t *testing.T) {
+		if _, err := NewMachineFromDescriptionNumber("1"); !errors.Is(err, ErrNotWellDefined) {
+			t.Errorf("got %v, want %v", err, ErrNotWellDefined)
+		}
+	})
+
+	t.Run("UnknownMFunction", func(t *testing.T) {
+		_, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{}, "missing(b, x)"},
+			},
+			PossibleSymbols: []string{"x"},
+		})
+		if !errors.Is(err, ErrUnknownMFunction) {
+			t.Errorf("got %v, want %v", err, ErrUnknownMFunction)
+		}
+	})
+
+	t.Run("InvalidOperation", func(t *testing.T) {
+		_, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{"P"}, "b"},
+			},
+		})
+		if !errors.Is(err, ErrInvalidOperation) {
+			t.Errorf("got %v, want %v", err, ErrInvalidOperation)
+		}
+	})
+
+	t.Run("InvalidMachineOperation", func(t *testing.T) {
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "Rb"}, "b"},
+			},
+		}
+		if err := input.Validate(); !errors.Is(err, ErrInvalidOperation) {
+			t.Errorf("got %v, want %v", err, ErrInvalidOperation)
+		}
+		m := NewMachine(input)
+		if moves, err := m.RunUntilHalt(10); !errors.Is(err, ErrInvalidOperation) || moves != 1 {
+			t.Errorf("got %v after %d moves, want %v immediately", err, moves, ErrInvalidOperation)
+		}
+		turingtest.TapePrefix(t, m.TapeString(), "")
+	})
+
+	t.Run("InvalidMachineOperationAtRuntime", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "b"},
+			},
+			StrictHalt: true,
+		})
+		// The machine shares its rows with the input, so changing them skips validation
+		m.mConfigurations[0].Operations[1] = "X"
+		if _, err := m.RunUntilHalt(10); !errors.Is(err, ErrInvalidOperation) {
+			t.Errorf("got %v, want %v", err, ErrInvalidOperation)
+		}
+	})
+
+	t.Run("TapeLimit", func(t *testing.T) {
+		for _, operation := range []string{"R", "L"} {
+			m := NewMachine(MachineInput{
+				MConfigurations: []MConfiguration{
+					{"b", []string{" "}, []string{"P0", operation}, "b"},
+				},
+				MaxTapeSquares: 5,
+			})
+			if _, err := m.RunUntilHalt(100); !errors.Is(err, ErrTapeLimit) {
+				t.Errorf("got %v, want %v", err, ErrTapeLimit)
+			}
+			if len(m.Tape()) != 5 || m.TapeString() != "00000" {
+				t.Errorf("got tape %q, want 5 squares", m.TapeString())
+			}
+		}
+	})
+
+	t.Run("LeftBound", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{"P0", "L"}, "b"},
+			},
+			Tape:           Tape{"x", "x", "x"},
+			StartingSquare: 2,
+			LeftBound:      StrictLeftBound,
+		})
+		if moves, err := m.RunUntilHalt(100); !errors.Is(err, ErrLeftBound) || moves != 3 {
+			t.Errorf("got %v after %d moves, want %v after 3", err, moves, ErrLeftBound)
+		}
+		if m.TapeString() != "000" || m.Head() != -2 {
+			t.Errorf("got tape %q and head %d, want 000 and -2", m.TapeString(), m.Head())
+		}
+	})
+
+	t.Run("UniversalMachineCompiles", func(t *testing.T) {
+		mConfigurations := append(StandardSkeletons(), configuration...)
+		mConfigurations = append(mConfigurations, begin...)
+		mConfigurations = append(mConfigurations, anfang...)
+		mConfigurations = append(mConfigurations, kom...)
+		mConfigurations = append(mConfigurations, kmp...)
+		mConfigurations = append(mConfigurations, similar...)
+		mConfigurations = append(mConfigurations, mark...)
+		mConfigurations = append(mConfigurations, show...)
+		mConfigurations = append(mConfigurations, instruction...)
+		_, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations:        mConfigurations,
+			StartingMConfiguration: "b",
+			PossibleSymbols:        possibleSymbolsForUniversalMachine,
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("NotReversible", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "b"},
+			},
+		})
+		if _, err := Inverse(m); !errors.Is(err, ErrNotReversible) {
+			t.Errorf("got %v, want %v", err, ErrNotReversible)
+		}
+	})
+
+	t.Run("InvalidWeight", func(t *testing.T) {
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "halt"},
+				{"b", []string{" "}, []string{"P1"}, "halt"},
+			},
+		}
+		for _, weights := range [][]float64{{1, -1}, {0, 0}, {math.NaN()}} {
+			if _, err := NewProbabilisticMachine(ProbabilisticMachineInput{MachineInput: input, Weights: weights}); !errors.Is(err, ErrInvalidWeight) {
+				t.Errorf("got %v for weights %v, want %v", err, weights, ErrInvalidWeight)
+			}
+		}
+	})
+}
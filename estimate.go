@@ -0,0 +1,128 @@
+package turing
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+type (
+	// How many m-configurations and rows compiling an abbreviated table produces (see `EstimateAbbreviatedTable`)
+	ExpansionEstimate struct {
+		// The amount of m-configurations (m-function calls with distinct parameters) in the compiled table
+		MConfigurations int
+
+		// The amount of rows in the compiled table
+		Rows int
+
+		// The m-functions, most rows first
+		Functions []MFunctionEstimate
+
+		// Whether the estimate stopped at the limit, so the compiled table is at least this large
+		Truncated bool
+	}
+
+	// How many m-configurations and rows an m-function of an abbreviated table expands into
+	MFunctionEstimate struct {
+		// The m-function's name
+		Name string
+
+		// The amount of parameters the m-function takes
+		Arity int
+
+		// The amount of distinct parameter signatures it is called with, each becoming an m-configuration
+		Signatures int
+
+		// The amount of rows those m-configurations have
+		Rows int
+	}
+)
+
+// Counts the m-configurations and rows compiling the abbreviated table would produce, without building
+// them, so that exponential blowups (an m-function passing itself ever larger parameters, say) can be
+// spotted before committing to compilation. The counts are exact, unless more than `limit` m-configurations
+// are found (and `limit` is greater than zero), in which case the estimate stops and is `Truncated`.
+// Like `CompileAbbreviatedTable`, an error wrapping `ErrUnknownMFunction` is returned if the table calls
+// an m-function it does not define.
+func EstimateAbbreviatedTable(input AbbreviatedTableInput, limit int) (ExpansionEstimate, error) {
+	at := &abbreviatedTable{input: input}
+	estimate := ExpansionEstimate{}
+	functions := map[string]*MFunctionEstimate{}
+	mFunctionsBySignature := map[string][]MConfiguration{}
+
+	queue := []string{}
+	seen := map[string]bool{}
+	enqueue := func(name string, params []string) {
+		call := composeMFunction(name, params)
+		if !seen[call] {
+			seen[call] = true
+			queue = append(queue, call)
+		}
+	}
+	for _, mConfiguration := range input.MConfigurations {
+		if name, params := parseMFunction(mConfiguration.Name); len(params) == 0 {
+			enqueue(name, params)
+		}
+	}
+
+	for len(queue) > 0 {
+		if limit > 0 && estimate.MConfigurations >= limit {
+			estimate.Truncated = true
+			break
+		}
+		name, params := parseMFunction(queue[0])
+		queue = queue[1:]
+
+		key := name + "/" + strconv.Itoa(len(params))
+		mFunctions, ok := mFunctionsBySignature[key]
+		if !ok {
+			mFunctions = at.findMFunctions(name, len(params))
+			mFunctionsBySignature[key] = mFunctions
+		}
+		if len(mFunctions) == 0 {
+			if len(params) > 0 && at.err == nil {
+				at.err = fmt.Errorf("%w: %s", ErrUnknownMFunction, composeMFunction(name, params))
+			}
+			continue
+		}
+
+		function, ok := functions[key]
+		if !ok {
+			function = &MFunctionEstimate{Name: name, Arity: len(params)}
+			functions[key] = function
+		}
+		function.Signatures++
+		estimate.MConfigurations++
+
+		// Mirrors `interpretMFunction`, only counting the rows
+		for _, mFunction := range mFunctions {
+			_, mFunctionParams := parseMFunction(mFunction.Name)
+			substitutionMap := createSubstitutionMap(mFunctionParams, params)
+			for _, scanned := range at.scannedSymbolBindings(mFunction.Symbols, mFunctionParams, substitutionMap) {
+				rowSubstitutionMap := maps.Clone(substitutionMap)
+				maps.Copy(rowSubstitutionMap, scanned.bindings)
+				finalMFunctionName, finalMFunctionParams := parseMFunction(mFunction.FinalMConfiguration)
+				substitutedFinalMFunctionName := at.substituteFinalMConfigurationName(finalMFunctionName, rowSubstitutionMap)
+				substitutedFinalMFunctionParams := at.substituteFinalMConfigurationParams(finalMFunctionParams, rowSubstitutionMap)
+				if len(substitutedFinalMFunctionParams) == 0 {
+					enqueue(parseMFunction(substitutedFinalMFunctionName))
+				} else {
+					enqueue(substitutedFinalMFunctionName, substitutedFinalMFunctionParams)
+				}
+				function.Rows++
+				estimate.Rows++
+			}
+		}
+	}
+
+	estimate.Functions = []MFunctionEstimate{}
+	for _, function := range functions {
+		estimate.Functions = append(estimate.Functions, *function)
+	}
+	slices.SortFunc(estimate.Functions, func(a, b MFunctionEstimate) int {
+		return cmp.Or(b.Rows-a.Rows, cmp.Compare(a.Name, b.Name), a.Arity-b.Arity)
+	})
+	return estimate, at.err
+}
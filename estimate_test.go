@@ -0,0 +1,72 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEstimateAbbreviatedTable(t *testing.T) {
+	input := AbbreviatedTableInput{
+		MConfigurations: append([]MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe"}, "ce2(k, x, y)"},
+			{"k", []string{"*", " "}, []string{}, "c(halt, halt, x)"},
+		}, StandardSkeletons()...),
+		PossibleSymbols: []string{"e", "0", "1", "x", "y"},
+	}
+	estimate, err := EstimateAbbreviatedTable(input, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := CompileAbbreviatedTable(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.Rows != len(compiled.MConfigurations) {
+		t.Errorf("got %d rows, want %d", estimate.Rows, len(compiled.MConfigurations))
+	}
+	if names := len(mConfigurationNames(compiled)); estimate.MConfigurations != names {
+		t.Errorf("got %d m-configurations, want %d", estimate.MConfigurations, names)
+	}
+	if estimate.Truncated {
+		t.Error("got truncated, want exact")
+	}
+	rows, signatures := 0, 0
+	for _, function := range estimate.Functions {
+		rows += function.Rows
+		signatures += function.Signatures
+	}
+	if rows != estimate.Rows || signatures != estimate.MConfigurations {
+		t.Errorf("got %d rows and %d signatures across functions, want %d and %d", rows, signatures, estimate.Rows, estimate.MConfigurations)
+	}
+	// `f` is called by nearly every other m-function, and its `f1` has the most rows
+	if top := estimate.Functions[0]; top.Name != "f1" || top.Arity != 3 {
+		t.Errorf("got %s/%d, want f1/3 to expand into the most rows", top.Name, top.Arity)
+	}
+
+	t.Run("Truncated", func(t *testing.T) {
+		// Each call passes itself a larger parameter, so compiling would never finish
+		estimate, err := EstimateAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{}, "grow(b)"},
+				{"grow(C)", []string{"*", " "}, []string{"R"}, "grow(grow(C))"},
+			},
+		}, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !estimate.Truncated || estimate.MConfigurations != 100 {
+			t.Errorf("got %d m-configurations (truncated %t), want 100 (truncated)", estimate.MConfigurations, estimate.Truncated)
+		}
+	})
+
+	t.Run("UnknownMFunction", func(t *testing.T) {
+		_, err := EstimateAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, []string{}, "nope(b)"},
+			},
+		}, 0)
+		if !errors.Is(err, ErrUnknownMFunction) {
+			t.Errorf("got %v, want %v", err, ErrUnknownMFunction)
+		}
+	})
+}
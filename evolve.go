@@ -0,0 +1,259 @@
+package turing
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+type (
+	// Scores a machine, higher being better
+	Fitness func(MachineInput) float64
+
+	// Configures a genetic search (see `Evolve`)
+	EvolutionOptions struct {
+		// The amount of m-configurations of every machine (named 0...n-1). Defaults to 2.
+		States int
+
+		// The symbols machines may read and print. The None symbol must come first.
+		// Defaults to `0` (None) and `1`, as in the busy beaver search.
+		Symbols []string
+
+		// If `true`, machines may move to the `halt` m-configuration
+		Halting bool
+
+		// The amount of machines in each generation. Defaults to 50.
+		PopulationSize int
+
+		// The amount of generations. Defaults to 100.
+		Generations int
+
+		// The probability that a child is mutated after crossover. Defaults to 0.5.
+		MutationRate float64
+
+		// The seed for the random number generator, so searches can be reproduced
+		Seed int64
+
+		// The score being maximized. Required.
+		Fitness Fitness
+	}
+
+	// The outcome of a genetic search
+	EvolutionResult struct {
+		// The fittest machine found
+		Best MachineInput
+
+		// The fitness of the best machine
+		Fitness float64
+
+		// The generation in which the best machine was found
+		Generation int
+	}
+
+	// A machine in a population, with its fitness
+	individual struct {
+		mConfigurations []MConfiguration
+		fitness         float64
+	}
+)
+
+const (
+	defaultEvolutionStates         int     = 2
+	defaultEvolutionPopulationSize int     = 50
+	defaultEvolutionGenerations    int     = 100
+	defaultEvolutionMutationRate   float64 = 0.5
+)
+
+// Returns a copy of the m-configurations with one randomly chosen row changed: either the symbol
+// it prints, the direction it moves, or its final m-configuration (one of the names in the table,
+// or `halt` if `halting`).
+func Mutate(mConfigurations []MConfiguration, symbols []string, halting bool, random *rand.Rand) []MConfiguration {
+	mutated := cloneMConfigurations(mConfigurations)
+	if len(mutated) == 0 {
+		return mutated
+	}
+	row := &mutated[random.Intn(len(mutated))]
+
+	switch random.Intn(3) {
+	case 0:
+		for i, operation := range row.Operations {
+			if len(operation) > 0 && operationCode(operation[0]) == printOp && len(symbols) > 0 {
+				row.Operations[i] = string(printOp) + symbols[random.Intn(len(symbols))]
+				return mutated
+			}
+		}
+	case 1:
+		for i, operation := range row.Operations {
+			switch operation {
+			case string(leftOp):
+				row.Operations[i] = string(rightOp)
+				return mutated
+			case string(rightOp):
+				row.Operations[i] = string(leftOp)
+				return mutated
+			}
+		}
+	}
+
+	names := []string{}
+	for _, mConfiguration := range mutated {
+		if !slices.Contains(names, mConfiguration.Name) {
+			names = append(names, mConfiguration.Name)
+		}
+	}
+	if halting {
+		names = append(names, haltMConfigurationName)
+	}
+	row.FinalMConfiguration = names[random.Intn(len(names))]
+	return mutated
+}
+
+// Returns a child taking the rows before a random point from `a` and the rows after it from `b`.
+// Both tables should list the same rows in the same order (as machines from `Evolve` do).
+func Crossover(a []MConfiguration, b []MConfiguration, random *rand.Rand) []MConfiguration {
+	length := min(len(a), len(b))
+	point := random.Intn(length + 1)
+	child := cloneMConfigurations(a[:point])
+	return append(child, cloneMConfigurations(b[point:length])...)
+}
+
+// Runs a genetic search for the machine maximizing the fitness. Each generation the fitter half
+// of the population survives, and the rest is replaced by (possibly mutated) children of survivors.
+func Evolve(options EvolutionOptions) EvolutionResult {
+	options = options.withDefaults()
+	random := rand.New(rand.NewSource(options.Seed))
+
+	population := []individual{}
+	for i := 0; i < options.PopulationSize; i++ {
+		population = append(population, individual{mConfigurations: options.randomMConfigurations(random)})
+	}
+
+	result := EvolutionResult{}
+	for generation := 0; generation < options.Generations; generation++ {
+		for i := range population {
+			population[i].fitness = options.Fitness(options.machineInput(population[i].mConfigurations))
+		}
+		sort.SliceStable(population, func(i, j int) bool {
+			return population[i].fitness > population[j].fitness
+		})
+		if generation == 0 || population[0].fitness > result.Fitness {
+			result = EvolutionResult{
+				Best:       options.machineInput(population[0].mConfigurations),
+				Fitness:    population[0].fitness,
+				Generation: generation,
+			}
+		}
+
+		survivors := max(1, len(population)/2)
+		for i := survivors; i < len(population); i++ {
+			child := Crossover(options.tournament(population[:survivors], random), options.tournament(population[:survivors], random), random)
+			if random.Float64() < options.MutationRate {
+				child = Mutate(child, options.Symbols, options.Halting, random)
+			}
+			population[i] = individual{mConfigurations: child}
+		}
+	}
+	return result
+}
+
+// Returns a fitness counting the `1`'s printed by machines that halt within `maxMoves` moves
+func OnesFitness(maxMoves int) Fitness {
+	return func(input MachineInput) float64 {
+		m := NewMachine(input)
+		m.MoveN(maxMoves)
+		if !m.halted {
+			return 0
+		}
+		var count int
+		for _, square := range m.tape {
+			if square == "1" {
+				count++
+			}
+		}
+		return float64(count)
+	}
+}
+
+// Returns a fitness counting how many of the machine's first figures (computed within
+// `maxMoves` moves) agree with the prefix
+func PrefixFitness(prefix []string, maxMoves int) Fitness {
+	return func(input MachineInput) float64 {
+		m := NewMachine(input)
+		m.MoveN(maxMoves)
+		var count int
+		for i, figure := range m.fSquareSymbols() {
+			if i >= len(prefix) || figure != prefix[i] {
+				break
+			}
+			count++
+		}
+		return float64(count)
+	}
+}
+
+// Returns the options with defaults filled in
+func (o EvolutionOptions) withDefaults() EvolutionOptions {
+	if o.States <= 0 {
+		o.States = defaultEvolutionStates
+	}
+	if len(o.Symbols) == 0 {
+		o.Symbols = []string{"0", "1"}
+	}
+	if o.PopulationSize <= 0 {
+		o.PopulationSize = defaultEvolutionPopulationSize
+	}
+	if o.Generations <= 0 {
+		o.Generations = defaultEvolutionGenerations
+	}
+	if o.MutationRate <= 0 {
+		o.MutationRate = defaultEvolutionMutationRate
+	}
+	return o
+}
+
+// Returns random m-configurations in the shape enumerated by the busy beaver search
+func (o EvolutionOptions) randomMConfigurations(random *rand.Rand) []MConfiguration {
+	finals := o.States
+	if o.Halting {
+		finals++
+	}
+	mConfigurations := []MConfiguration{}
+	for i := 0; i < o.States; i++ {
+		for _, symbol := range o.Symbols {
+			final := strconv.Itoa(random.Intn(finals))
+			if final == strconv.Itoa(o.States) {
+				final = haltMConfigurationName
+			}
+			mConfigurations = append(mConfigurations, MConfiguration{
+				Name:                strconv.Itoa(i),
+				Symbols:             []string{symbol},
+				Operations:          []string{string(printOp) + o.Symbols[random.Intn(len(o.Symbols))], string([]operationCode{leftOp, rightOp}[random.Intn(2)])},
+				FinalMConfiguration: final,
+			})
+		}
+	}
+	return mConfigurations
+}
+
+// Returns the fitter of two random individuals
+func (o EvolutionOptions) tournament(population []individual, random *rand.Rand) []MConfiguration {
+	a, b := population[random.Intn(len(population))], population[random.Intn(len(population))]
+	if b.fitness > a.fitness {
+		return b.mConfigurations
+	}
+	return a.mConfigurations
+}
+
+// Returns a runnable MachineInput for the m-configurations
+func (o EvolutionOptions) machineInput(mConfigurations []MConfiguration) MachineInput {
+	input := MachineInput{
+		MConfigurations: cloneMConfigurations(mConfigurations),
+		PossibleSymbols: o.Symbols[1:],
+		NoneSymbol:      o.Symbols[0],
+	}
+	if o.Halting {
+		input.HaltingMConfigurations = []string{haltMConfigurationName}
+	}
+	return input
+}
@@ -0,0 +1,84 @@
+package turing
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMutate(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	original := []MConfiguration{
+		{"0", []string{"0"}, []string{"P1", "R"}, "1"},
+		{"0", []string{"1"}, []string{"P1", "L"}, "1"},
+		{"1", []string{"0"}, []string{"P1", "L"}, "0"},
+		{"1", []string{"1"}, []string{"P1", "R"}, "halt"},
+	}
+	snapshot := cloneMConfigurations(original)
+	for i := 0; i < 20; i++ {
+		mutated := Mutate(original, []string{"0", "1"}, true, random)
+		differences := 0
+		for j := range mutated {
+			if !reflect.DeepEqual(mutated[j], original[j]) {
+				differences++
+			}
+		}
+		if differences > 1 {
+			t.Errorf("got %d rows changed, want at most 1", differences)
+		}
+	}
+	if !reflect.DeepEqual(original, snapshot) {
+		t.Error("expected the original to be unchanged")
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	a := []MConfiguration{
+		{"0", []string{"0"}, []string{"P1", "R"}, "0"},
+		{"0", []string{"1"}, []string{"P1", "R"}, "0"},
+	}
+	b := []MConfiguration{
+		{"0", []string{"0"}, []string{"P0", "L"}, "halt"},
+		{"0", []string{"1"}, []string{"P0", "L"}, "halt"},
+	}
+	for i := 0; i < 10; i++ {
+		child := Crossover(a, b, random)
+		if len(child) != 2 {
+			t.Fatalf("got %d rows, want 2", len(child))
+		}
+		if reflect.DeepEqual(child[0], b[0]) && reflect.DeepEqual(child[1], a[1]) {
+			t.Error("expected rows from a to come before rows from b")
+		}
+	}
+}
+
+func TestEvolveBusyBeaver(t *testing.T) {
+	result := Evolve(EvolutionOptions{
+		States:  2,
+		Halting: true,
+		Seed:    1,
+		Fitness: OnesFitness(100),
+	})
+	if result.Fitness != 4 {
+		t.Errorf("got fitness %v, want 4", result.Fitness)
+	}
+	m := NewMachine(result.Best)
+	if _, err := m.RunUntilHalt(100); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEvolvePrefix(t *testing.T) {
+	prefix := []string{"1", "1", "1", "1"}
+	result := Evolve(EvolutionOptions{
+		States:      1,
+		Symbols:     []string{" ", "1"},
+		Generations: 10,
+		Seed:        1,
+		Fitness:     PrefixFitness(prefix, 100),
+	})
+	if result.Fitness != 4 {
+		t.Errorf("got fitness %v, want 4", result.Fitness)
+	}
+}
@@ -0,0 +1,216 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// A square holding both a figure and a mark, rather than the F-square and E-square Turing
+	// interleaves on the tape
+	FigureMark struct {
+		Figure string
+		Mark   string
+	}
+
+	// A tape of squares each holding a figure and a mark (see `FigureMarkTapeOf`)
+	FigureMarkTape []FigureMark
+
+	// Input for a machine whose squares hold a figure and a mark (see `NewFigureMarkMachine`). Rows
+	// read and write the figure and mark of the scanned square separately, so m-functions that
+	// manage marks need not step between F-squares and E-squares, and the head travels half as far.
+	FigureMarkTableInput struct {
+		// The rows of the machine
+		MConfigurations []FigureMarkMConfiguration
+
+		// The figures that may be printed (besides ` `)
+		Figures []string
+
+		// The marks that may be printed (besides ` `)
+		Marks []string
+
+		// The tape the machine starts with
+		Tape FigureMarkTape
+
+		// If provided, the m-configuration the machine starts with
+		StartingMConfiguration string
+	}
+
+	// A row of a machine whose squares hold a figure and a mark
+	FigureMarkMConfiguration struct {
+		// The name of the m-configuration
+		Name string
+
+		// The figure the row matches, or `*` for any (including ` `)
+		Figure string
+
+		// The mark the row matches, or `*` for any (including ` `)
+		Mark string
+
+		// Operations, any of `F<figure>` and `M<mark>` (print), `EF` and `EM` (erase), `L`, and `R`
+		Operations []string
+
+		// The m-configuration to move to
+		FinalMConfiguration string
+	}
+)
+
+const (
+	// Separates the figure and mark of a square's symbol
+	figureMarkSeparator string = "|"
+
+	// Operation codes of a machine whose squares hold a figure and a mark
+	figureOp operationCode = 'F'
+	markOp   operationCode = 'M'
+)
+
+// Returns the tape with each F-square and the E-square to its right combined into a single square
+func FigureMarkTapeOf(tape Tape) FigureMarkTape {
+	figureMarkTape := FigureMarkTape{}
+	for i := 0; i < len(tape); i += 2 {
+		square := FigureMark{tape[i], none}
+		if i+1 < len(tape) {
+			square.Mark = tape[i+1]
+		}
+		figureMarkTape = append(figureMarkTape, square)
+	}
+	return figureMarkTape
+}
+
+// Returns the tape with figures and marks on interleaved F-squares and E-squares, as Turing writes it
+func (t FigureMarkTape) Interleaved() Tape {
+	tape := Tape{}
+	for _, square := range t {
+		tape = append(tape, square.Figure, square.Mark)
+	}
+	return tape
+}
+
+// Returns the tape as the symbols a machine built by `NewFigureMarkMachine` scans
+func (t FigureMarkTape) Symbols() Tape {
+	tape := Tape{}
+	for _, square := range t {
+		tape = append(tape, square.Symbol())
+	}
+	return tape
+}
+
+// Returns the tape of a machine built by `NewFigureMarkMachine`
+func FigureMarkTapeFromSymbols(tape Tape) FigureMarkTape {
+	figureMarkTape := FigureMarkTape{}
+	for _, symbol := range tape {
+		figureMarkTape = append(figureMarkTape, ParseFigureMarkSymbol(symbol))
+	}
+	return figureMarkTape
+}
+
+// Returns the symbol of the square. A square with neither a figure nor a mark is ` `.
+func (fm FigureMark) Symbol() string {
+	if fm.Figure == none && fm.Mark == none {
+		return none
+	}
+	return fm.Figure + figureMarkSeparator + fm.Mark
+}
+
+// Returns the figure and mark of a square's symbol (see `Symbol`)
+func ParseFigureMarkSymbol(symbol string) FigureMark {
+	figure, mark, ok := strings.Cut(symbol, figureMarkSeparator)
+	if !ok {
+		return FigureMark{none, none}
+	}
+	return FigureMark{figure, mark}
+}
+
+// Compiles the machine to MachineInput, with a symbol for every combination of figure and mark.
+// Each row is expanded for every square it matches. Operations following a move are performed by
+// intermediate m-configurations, as the square moved to is not known until it is scanned.
+func NewFigureMarkMachine(input FigureMarkTableInput) MachineInput {
+	figures := append([]string{none}, input.Figures...)
+	marks := append([]string{none}, input.Marks...)
+	squares := []FigureMark{}
+	for _, figure := range figures {
+		for _, mark := range marks {
+			squares = append(squares, FigureMark{figure, mark})
+		}
+	}
+
+	mConfigurations := []MConfiguration{}
+	for i, row := range input.MConfigurations {
+		// Split the operations into segments, each ending with a move (but for the last)
+		segments := [][]string{{}}
+		for _, operation := range row.Operations {
+			segments[len(segments)-1] = append(segments[len(segments)-1], operation)
+			if operation == string(leftOp) || operation == string(rightOp) {
+				segments = append(segments, []string{})
+			}
+		}
+		if len(segments) > 1 && len(segments[len(segments)-1]) == 0 {
+			segments = segments[:len(segments)-1]
+		}
+
+		name := row.Name
+		for j, segment := range segments {
+			final := row.FinalMConfiguration
+			if j < len(segments)-1 {
+				final = fmt.Sprintf("%s.%d.%d", row.Name, i, j+1)
+			}
+			for _, square := range squares {
+				if j == 0 && !(matchesFigureMark(row.Figure, square.Figure) && matchesFigureMark(row.Mark, square.Mark)) {
+					continue
+				}
+				mConfigurations = append(mConfigurations, MConfiguration{
+					Name:                name,
+					Symbols:             []string{square.Symbol()},
+					Operations:          figureMarkOperations(square, segment),
+					FinalMConfiguration: final,
+				})
+			}
+			name = final
+		}
+	}
+
+	possibleSymbols := []string{}
+	for _, square := range squares[1:] {
+		possibleSymbols = append(possibleSymbols, square.Symbol())
+	}
+	return MachineInput{
+		MConfigurations:        mConfigurations,
+		Tape:                   input.Tape.Symbols(),
+		StartingMConfiguration: input.StartingMConfiguration,
+		PossibleSymbols:        possibleSymbols,
+	}
+}
+
+// Returns true if the figure or mark of a row matches that of the square
+func matchesFigureMark(pattern string, s string) bool {
+	return pattern == any || pattern == s
+}
+
+// Returns the operations of a segment of a row, performed on the square
+func figureMarkOperations(square FigureMark, segment []string) []string {
+	operations := []string{}
+	printed := square
+	for _, operation := range segment {
+		switch {
+		case operation == string(eraseOp)+string(figureOp):
+			printed.Figure = none
+		case operation == string(eraseOp)+string(markOp):
+			printed.Mark = none
+		case len(operation) > 1 && operationCode(operation[0]) == figureOp:
+			printed.Figure = operation[1:]
+		case len(operation) > 1 && operationCode(operation[0]) == markOp:
+			printed.Mark = operation[1:]
+		default:
+			operations = append(operations, operation)
+		}
+	}
+	if printed != square {
+		if symbol := printed.Symbol(); symbol == none {
+			operations = slices.Insert(operations, 0, string(eraseOp))
+		} else {
+			operations = slices.Insert(operations, 0, string(printOp)+symbol)
+		}
+	}
+	return operations
+}
@@ -0,0 +1,52 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestFigureMarkTape(t *testing.T) {
+	tape := Tape{"e", "e", "0", "x", "1", " ", "0"}
+	figureMarkTape := FigureMarkTapeOf(tape)
+	expected := FigureMarkTape{{"e", "e"}, {"0", "x"}, {"1", " "}, {"0", " "}}
+	if !reflect.DeepEqual(figureMarkTape, expected) {
+		t.Errorf("got %v, want %v", figureMarkTape, expected)
+	}
+	turingtest.Equal(t, figureMarkTape.Interleaved().String(""), "ee0x1 0 ")
+
+	symbols := figureMarkTape.Symbols()
+	turingtest.Equal(t, symbols.String(","), "e|e,0|x,1| ,0| ")
+	if !reflect.DeepEqual(FigureMarkTapeFromSymbols(symbols), figureMarkTape) {
+		t.Errorf("got %v, want %v", FigureMarkTapeFromSymbols(symbols), figureMarkTape)
+	}
+	if symbol := (FigureMark{none, none}).Symbol(); symbol != none {
+		t.Errorf("got %q for a blank square, want %q", symbol, none)
+	}
+}
+
+func TestFigureMarkMachine(t *testing.T) {
+	// Marks every `0` with `x` and every `1` with `y`, then returns to the start and erases the first mark
+	input := NewFigureMarkMachine(FigureMarkTableInput{
+		MConfigurations: []FigureMarkMConfiguration{
+			{"b", "0", "*", []string{"Mx", "R"}, "b"},
+			{"b", "1", "*", []string{"My", "R"}, "b"},
+			{"b", " ", "*", []string{"L"}, "r"},
+			{"r", " ", " ", []string{"R", "EM"}, "halt"},
+			{"r", "*", "*", []string{"L"}, "r"},
+		},
+		Figures: []string{"0", "1"},
+		Marks:   []string{"x", "y"},
+		Tape:    FigureMarkTapeOf(Tape{"0", " ", "1", " ", "0"}),
+	})
+	m := NewMachine(input)
+	m.MoveN(100)
+	actual := FigureMarkTapeFromSymbols(m.Tape()).Interleaved()
+	turingtest.TapePrefix(t, actual.String(""), "  0 1y0x")
+
+	// The head travels along squares, not F-squares and E-squares
+	if m.moves != 9 {
+		t.Errorf("got %d moves, want 9", m.moves)
+	}
+}
@@ -0,0 +1,196 @@
+package turing
+
+import (
+	"strings"
+	"unicode"
+)
+
+type (
+	// Selects how `CompleteConfiguration` writes the complete configuration
+	CompleteConfigurationStyle int
+
+	// Selects how `TapeString` and `FormatCompleteConfiguration` write the tape. The zero value writes the
+	// squares one after the other, which is ambiguous if they may bear symbols longer than a character.
+	TapeFormat struct {
+		// Written between squares
+		Separator string
+
+		// If provided, written immediately before the scanned square
+		HeadMarker string
+
+		// If provided, written in place of blank squares (bearing the None symbol)
+		Blank string
+
+		// If greater than zero, the most columns (see `SymbolWidth`) written. Longer tapes are cut to the
+		// squares around the scanned square, and `…` is written where squares were left out.
+		MaxWidth int
+	}
+)
+
+const (
+	// Turing's single-line form, with the m-configuration written before the scanned
+	// square (i.e. `0b1`)
+	InlineStyle CompleteConfigurationStyle = iota
+
+	// The single-line form preceded by a colon, so that successive complete configurations
+	// can be concatenated into Turing's colon-separated series (i.e. `:b0:0c1`)
+	SuccessiveStyle
+
+	// The m-configuration and the scanned square (relative to the square originally scanned, see
+	// `Head`) written separately from the tape (i.e. `state=b head=1 tape=01`)
+	AnnotatedStyle
+)
+
+const (
+	ansiReverse string = "\x1b[7m"
+	ansiBold    string = "\x1b[1m"
+	ansiReset   string = "\x1b[0m"
+
+	// Written where squares were left out of a tape too wide to write in full
+	ellipsis string = "…"
+)
+
+// Returns the tape on one line and the current m-configuration labeled beneath the scanned square.
+// Every square is padded to the width of the widest symbol (see `SymbolWidth`), so symbols such as `::`,
+// `S12` or `🐢` do not shift the squares after them. If `color` is `true`, the scanned square and the m-configuration are
+// highlighted with ANSI escape codes.
+func (m *Machine) AlignedConfiguration(color bool) string {
+	squares := m.tape
+	if m.scannedSquare >= len(squares) {
+		squares = append(Tape{}, squares...)
+		for len(squares) <= m.scannedSquare {
+			squares = append(squares, m.squareSymbol(len(squares)))
+		}
+	}
+
+	width := 1
+	for _, square := range squares {
+		width = max(width, SymbolWidth(square))
+	}
+
+	var tapeLine strings.Builder
+	var headLine strings.Builder
+	tapeLine.WriteString("|")
+	for i, square := range squares {
+		cell := " " + square + strings.Repeat(" ", width-SymbolWidth(square)) + " "
+		if i == m.scannedSquare {
+			headLine.WriteString("  ^")
+			if color {
+				cell = ansiReverse + cell + ansiReset
+			}
+		} else if i < m.scannedSquare {
+			headLine.WriteString(strings.Repeat(" ", width+3))
+		}
+		tapeLine.WriteString(cell + "|")
+	}
+
+	name := m.currentMConfigurationName
+	if color {
+		name = ansiBold + name + ansiReset
+	}
+	headLine.WriteString(" " + name)
+	return tapeLine.String() + "\n" + headLine.String()
+}
+
+// Returns the amount of terminal columns the symbol takes up. Combining marks (such as the accent in a
+// decomposed `é`), joiners and variation selectors take up none, and wide characters (such as CJK
+// ideographs and most emoji) take up two.
+func SymbolWidth(symbol string) int {
+	width := 0
+	for _, char := range symbol {
+		switch {
+		case unicode.In(char, unicode.Mn, unicode.Me, unicode.Cf), unicode.Is(unicode.Variation_Selector, char):
+		case isWideRune(char):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// Returns true if the character is East Asian Wide or Fullwidth, or an emoji presented as such
+func isWideRune(char rune) bool {
+	switch {
+	case char >= 0x1100 && char <= 0x115F, // Hangul Jamo
+		char >= 0x2E80 && char <= 0x303E, // CJK Radicals through CJK Symbols and Punctuation
+		char >= 0x3041 && char <= 0x33FF, // Hiragana through CJK Compatibility
+		char >= 0x3400 && char <= 0x4DBF, // CJK Unified Ideographs Extension A
+		char >= 0x4E00 && char <= 0x9FFF, // CJK Unified Ideographs
+		char >= 0xA000 && char <= 0xA4CF, // Yi
+		char >= 0xAC00 && char <= 0xD7A3, // Hangul Syllables
+		char >= 0xF900 && char <= 0xFAFF, // CJK Compatibility Ideographs
+		char >= 0xFE30 && char <= 0xFE4F, // CJK Compatibility Forms
+		char >= 0xFF00 && char <= 0xFF60, // Fullwidth Forms
+		char >= 0xFFE0 && char <= 0xFFE6,
+		char >= 0x1F300 && char <= 0x1F64F, // Miscellaneous Symbols and Pictographs, Emoticons
+		char >= 0x1F680 && char <= 0x1F6FF, // Transport and Map Symbols
+		char >= 0x1F900 && char <= 0x1F9FF, // Supplemental Symbols and Pictographs
+		char >= 0x20000 && char <= 0x3FFFD: // CJK Unified Ideographs Extension B onwards
+		return true
+	}
+	return false
+}
+
+// Returns the tape written in the format. If `mConfiguration` is provided it is written as a square of
+// its own before the scanned square (as in Turing's complete configurations).
+func (m *Machine) formatTape(format TapeFormat, mConfiguration string) string {
+	squares := []string{}
+	scanned := -1
+	for i := 0; i < len(m.tape) || i == m.scannedSquare; i++ {
+		if i == m.scannedSquare {
+			scanned = len(squares)
+			if len(mConfiguration) != 0 {
+				squares = append(squares, mConfiguration)
+			}
+		}
+		if i == len(m.tape) {
+			break
+		}
+		square := m.tape[i]
+		if square == m.noneSymbol && len(format.Blank) != 0 {
+			square = format.Blank
+		}
+		if i == m.scannedSquare && len(mConfiguration) == 0 {
+			square = format.HeadMarker + square
+		}
+		squares = append(squares, square)
+	}
+	if len(mConfiguration) != 0 && scanned >= 0 {
+		squares[scanned] = format.HeadMarker + squares[scanned]
+	}
+
+	formatted := strings.Join(squares, format.Separator)
+	if format.MaxWidth <= 0 || SymbolWidth(formatted) <= format.MaxWidth || len(squares) == 0 {
+		return formatted
+	}
+
+	// Widen the window around the scanned square, one square at a time on either side, while it fits
+	// (leaving room for an ellipsis on both sides)
+	scanned = max(min(scanned, len(squares)-1), 0)
+	start, end := scanned, scanned+1
+	width := SymbolWidth(squares[scanned])
+	separatorWidth := SymbolWidth(format.Separator)
+	maxWidth := format.MaxWidth - 2*(SymbolWidth(ellipsis)+separatorWidth)
+	for grew := true; grew; {
+		grew = false
+		if end < len(squares) && width+separatorWidth+SymbolWidth(squares[end]) <= maxWidth {
+			width += separatorWidth + SymbolWidth(squares[end])
+			end++
+			grew = true
+		}
+		if start > 0 && width+separatorWidth+SymbolWidth(squares[start-1]) <= maxWidth {
+			start--
+			width += separatorWidth + SymbolWidth(squares[start])
+			grew = true
+		}
+	}
+	formatted = strings.Join(squares[start:end], format.Separator)
+	if start > 0 {
+		formatted = ellipsis + format.Separator + formatted
+	}
+	if end < len(squares) {
+		formatted += format.Separator + ellipsis
+	}
+	return formatted
+}
@@ -0,0 +1,140 @@
+package turing
+
+import (
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestAlignedConfiguration(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"R"}, "c"},
+		},
+		Tape:            Tape{"::", "S12", "0"},
+		PossibleSymbols: []string{"::", "S12", "0"},
+	})
+	m.Move()
+
+	expected := "| ::  | S12 | 0   |\n" +
+		"        ^ c"
+	if aligned := m.AlignedConfiguration(false); aligned != expected {
+		t.Errorf("got\n%s\nwant\n%s", aligned, expected)
+	}
+
+	colored := "| ::  |" + ansiReverse + " S12 " + ansiReset + "| 0   |\n" +
+		"        ^ " + ansiBold + "c" + ansiReset
+	if aligned := m.AlignedConfiguration(true); aligned != colored {
+		t.Errorf("got %q, want %q", aligned, colored)
+	}
+}
+
+func TestAlignedConfigurationBeyondTape(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"R"}, "b"},
+		},
+		Tape:            Tape{"0"},
+		PossibleSymbols: []string{"0"},
+	})
+	m.Move()
+
+	expected := "| 0 |   |\n" +
+		"      ^ b"
+	if aligned := m.AlignedConfiguration(false); aligned != expected {
+		t.Errorf("got\n%s\nwant\n%s", aligned, expected)
+	}
+}
+
+func TestAlignedConfigurationUnicode(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"R"}, "c"},
+		},
+		Tape:            Tape{"ə", "🐢", "e\u0301", "0"},
+		PossibleSymbols: []string{"ə", "🐢", "e\u0301", "0"},
+	})
+	m.Move()
+
+	expected := "| ə  | 🐢 | e\u0301  | 0  |\n" +
+		"       ^ c"
+	if aligned := m.AlignedConfiguration(false); aligned != expected {
+		t.Errorf("got\n%s\nwant\n%s", aligned, expected)
+	}
+}
+
+func TestSymbolWidth(t *testing.T) {
+	for symbol, expected := range map[string]int{
+		"":         0,
+		"0":        1,
+		"S12":      3,
+		"ə":        1,
+		"e\u0301":  1,
+		"🐢":        2,
+		"❤\ufe0f":  1,
+		"数":        2,
+		"👩\u200d💻": 4,
+	} {
+		t.Run(symbol, func(t *testing.T) {
+			if width := SymbolWidth(symbol); width != expected {
+				t.Errorf("got %d, want %d", width, expected)
+			}
+		})
+	}
+}
+
+func TestCompleteConfigurationStyles(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "b"},
+		},
+	})
+	m.Move()
+
+	turingtest.Equal(t, m.CompleteConfiguration(), "0c")
+	turingtest.Equal(t, m.CompleteConfiguration(InlineStyle), "0c")
+	turingtest.Equal(t, m.CompleteConfiguration(SuccessiveStyle), ":0c")
+	turingtest.Equal(t, m.CompleteConfiguration(AnnotatedStyle), "state=c head=1 tape=0")
+
+	series := m.CompleteConfiguration(SuccessiveStyle)
+	m.Move()
+	series += m.CompleteConfiguration(SuccessiveStyle)
+	turingtest.Equal(t, series, ":0c:0 b")
+
+	m = NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*"}, []string{"L"}, "b"},
+		},
+		Tape:           Tape{"1", "0", "1"},
+		StartingSquare: 2,
+	})
+	m.Move()
+	turingtest.Equal(t, m.CompleteConfiguration(AnnotatedStyle), "state=b head=-1 tape=101")
+}
+
+func TestTapeFormat(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{}, []string{"R"}, "b"},
+		},
+		Tape: Tape{"S12", "::", " ", "1", "0", "1", "1", "0"},
+	})
+	m.MoveN(1)
+
+	// Without a separator the squares run together
+	turingtest.Equal(t, m.TapeString(), "S12:: 10110")
+	turingtest.Equal(t, m.TapeString(TapeFormat{Separator: "|", HeadMarker: ">", Blank: "_"}), "S12|>::|_|1|0|1|1|0")
+	turingtest.Equal(t, m.FormatCompleteConfiguration(InlineStyle, TapeFormat{Separator: " "}), "S12 b ::   1 0 1 1 0")
+	turingtest.Equal(t, m.FormatCompleteConfiguration(SuccessiveStyle, TapeFormat{Separator: ",", HeadMarker: "*"}), ":S12,*b,::, ,1,0,1,1,0")
+	turingtest.Equal(t, m.FormatCompleteConfiguration(AnnotatedStyle, TapeFormat{Separator: ",", Blank: "_"}), "state=b head=1 tape=S12,::,_,1,0,1,1,0")
+
+	t.Run("MaxWidth", func(t *testing.T) {
+		m.MoveN(3)
+		// Cut around the scanned square (`0`, the fifth)
+		turingtest.Equal(t, m.TapeString(TapeFormat{Separator: " ", HeadMarker: ">", MaxWidth: 11}), "… 1 >0 1 …")
+		turingtest.Equal(t, m.TapeString(TapeFormat{MaxWidth: 4}), "…01…")
+		// Wide enough to be written in full
+		turingtest.Equal(t, m.TapeString(TapeFormat{MaxWidth: 11}), "S12:: 10110")
+	})
+}
@@ -0,0 +1,128 @@
+package turing
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// A call of an m-function, such as `f(C, B, a)`. Generated at random (see `RandomMFunctionCall`)
+	// by `testing/quick`.
+	MFunctionCall string
+)
+
+const (
+	// The letters used for random names and symbols
+	randomLetters string = "abcdefghijklmnopqrstuvwxyz"
+)
+
+// Returns a random machine with between 1 and `size` m-configurations (named `q1`, `q2`, ...) over
+// up to three symbols, besides ` ` (None). Every m-configuration has a row for ` ` and rows for some
+// of the other symbols, printing or erasing and moving at random. Rows move to `halt` now and then.
+func RandomMachineInput(random *rand.Rand, size int) MachineInput {
+	symbols := []string{}
+	for i := 0; i <= random.Intn(3); i++ {
+		symbols = append(symbols, strconv.Itoa(i))
+	}
+	return MachineInput{
+		MConfigurations: RandomMConfigurations(random, 1+random.Intn(max(size, 1)), symbols),
+		PossibleSymbols: symbols,
+	}
+}
+
+// Returns random rows for `n` m-configurations (named `q1`, `q2`, ...) over the symbols (see
+// `RandomMachineInput`)
+func RandomMConfigurations(random *rand.Rand, n int, symbols []string) []MConfiguration {
+	names := []string{}
+	for i := 1; i <= n; i++ {
+		names = append(names, mConfigurationNamePrefix+strconv.Itoa(i))
+	}
+	mConfigurations := []MConfiguration{}
+	for _, name := range names {
+		for i, symbol := range append([]string{none}, symbols...) {
+			if i > 0 && random.Intn(3) == 0 {
+				continue
+			}
+			operations := []string{}
+			switch random.Intn(3) {
+			case 0:
+				operations = append(operations, string(printOp)+symbols[random.Intn(len(symbols))])
+			case 1:
+				operations = append(operations, string(eraseOp))
+			}
+			if move := random.Intn(3); move < 2 {
+				operations = append(operations, string([]operationCode{leftOp, rightOp}[move]))
+			}
+			final := names[random.Intn(len(names))]
+			if random.Intn(2*len(names)) == 0 {
+				final = haltMConfigurationName
+			}
+			mConfigurations = append(mConfigurations, MConfiguration{name, []string{symbol}, operations, final})
+		}
+	}
+	return mConfigurations
+}
+
+// Returns a random well-defined S.D. with between 1 and `size` m-configurations over up to three
+// symbols. No two rows share an m-configuration and symbol.
+func RandomStandardDescription(random *rand.Rand, size int) StandardDescription {
+	count, symbols := 1+random.Intn(max(size, 1)), 1+random.Intn(3)
+	var sd strings.Builder
+	for i := 1; i <= count; i++ {
+		for symbol := 0; symbol <= symbols; symbol++ {
+			if (i > 1 || symbol > 0) && random.Intn(2) == 0 {
+				continue
+			}
+			sd.WriteString(string(semicolon) + string(d) + strings.Repeat(string(a), i))
+			sd.WriteString(string(d) + strings.Repeat(string(c), symbol))
+			sd.WriteString(string(d) + strings.Repeat(string(c), random.Intn(symbols+1)))
+			sd.WriteByte([]byte{l, r, n}[random.Intn(3)])
+			sd.WriteString(string(d) + strings.Repeat(string(a), 1+random.Intn(count)))
+		}
+	}
+	return StandardDescription(sd.String())
+}
+
+// Returns a random call of an m-function, with parameters that are names, symbols, or calls
+// nested at most `size` deep
+func RandomMFunctionCall(random *rand.Rand, size int) string {
+	name := randomName(random)
+	if size <= 0 || random.Intn(3) == 0 {
+		return name
+	}
+	params := []string{}
+	for i := 0; i <= random.Intn(3); i++ {
+		if random.Intn(2) == 0 {
+			params = append(params, RandomMFunctionCall(random, size-1))
+		} else {
+			params = append(params, randomName(random))
+		}
+	}
+	return composeMFunction(name, params)
+}
+
+// Returns one to three random letters
+func randomName(random *rand.Rand) string {
+	var name strings.Builder
+	for i := 0; i <= random.Intn(3); i++ {
+		name.WriteByte(randomLetters[random.Intn(len(randomLetters))])
+	}
+	return name.String()
+}
+
+// Generates a random machine for `testing/quick` (see `RandomMachineInput`)
+func (MachineInput) Generate(random *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomMachineInput(random, size))
+}
+
+// Generates a random S.D. for `testing/quick` (see `RandomStandardDescription`)
+func (StandardDescription) Generate(random *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomStandardDescription(random, size))
+}
+
+// Generates a random call of an m-function for `testing/quick` (see `RandomMFunctionCall`)
+func (MFunctionCall) Generate(random *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(MFunctionCall(RandomMFunctionCall(random, min(size, 4))))
+}
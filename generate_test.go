@@ -0,0 +1,86 @@
+package turing
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestQuickStandardDescription(t *testing.T) {
+	// Every S.D. describes the same machine as its D.N.
+	if err := quick.Check(func(sd StandardDescription) bool {
+		fromSD, err := NewMachineFromStandardDescription(sd)
+		if err != nil {
+			return false
+		}
+		fromDN, err := NewMachineFromDescriptionNumber(toDescriptionNumber(sd))
+		return err == nil && reflect.DeepEqual(fromSD, fromDN)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickCanonicalize(t *testing.T) {
+	if err := quick.Check(func(input MachineInput) bool {
+		canonical := Canonicalize(input)
+		return reflect.DeepEqual(Canonicalize(canonical), canonical) && Fingerprint(canonical) == Fingerprint(input)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickAssembly(t *testing.T) {
+	if err := quick.Check(func(input MachineInput) bool {
+		compiled, err := CompileAssembly(DecompileAssembly(input), nil)
+		return err == nil && reflect.DeepEqual(compiled, input)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickMFunctionCall(t *testing.T) {
+	if err := quick.Check(func(call MFunctionCall) bool {
+		return composeMFunction(parseMFunction(string(call))) == string(call)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzStandardDescription(f *testing.F) {
+	for seed := int64(0); seed < 10; seed++ {
+		f.Add(seed, 4)
+	}
+	f.Fuzz(func(t *testing.T, seed int64, size int) {
+		sd := RandomStandardDescription(rand.New(rand.NewSource(seed)), size%10)
+		input, err := NewMachineFromStandardDescription(sd)
+		if err != nil {
+			t.Fatalf("got %v for %s", err, sd)
+		}
+		m := NewMachine(input)
+		m.MoveN(100)
+	})
+}
+
+func FuzzMachineInput(f *testing.F) {
+	for seed := int64(0); seed < 10; seed++ {
+		f.Add(seed, 4)
+	}
+	f.Fuzz(func(t *testing.T, seed int64, size int) {
+		input := RandomMachineInput(rand.New(rand.NewSource(seed)), size%10)
+		once, err := Mirror(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mirrored, err := Mirror(once)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, mirroredM := NewMachine(input), NewMachine(mirrored)
+		m.MoveN(100)
+		mirroredM.MoveN(100)
+		if m.CompleteConfiguration() != mirroredM.CompleteConfiguration() {
+			t.Errorf("got %s mirrored twice, want %s", mirroredM.CompleteConfiguration(), m.CompleteConfiguration())
+		}
+	})
+}
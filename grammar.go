@@ -0,0 +1,170 @@
+package turing
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// An unrestricted (type-0) grammar whose derivations simulate a machine. Each sentential
+	// form is a complete configuration of the standardized machine, delimited by end markers,
+	// with the m-configuration written immediately before the scanned square.
+	Grammar struct {
+		// The sentential form derivations begin with (the machine's first complete configuration)
+		Start SententialForm
+
+		// The productions, tried in order
+		Productions []Production
+
+		// The mapping from the grammar's symbols to the machine's symbols
+		SymbolMap SymbolMap
+	}
+
+	// A production rewrites an occurrence of Left to Right
+	Production struct {
+		Left  SententialForm
+		Right SententialForm
+	}
+
+	// A string of grammar symbols
+	SententialForm []string
+)
+
+const (
+	grammarLeftMarker  string = "["
+	grammarRightMarker string = "]"
+)
+
+// Returns a grammar whose derivations are the complete configurations of the machine. The machine
+// is first standardized, so every m-configuration prints exactly one symbol and moves at most once.
+func (input MachineInput) Grammar() Grammar {
+	st := NewStandardTable(input)
+	standardInput := st.MachineInput
+	symbols := standardSymbols(st.SymbolMap)
+	blank := standardInput.NoneSymbol
+
+	productions := []Production{}
+	states := []string{}
+	for _, mConfiguration := range standardInput.MConfigurations {
+		state := mConfiguration.Name
+		scanned := mConfiguration.Symbols[0]
+		printed := mConfiguration.Operations[0][1:]
+		next := mConfiguration.FinalMConfiguration
+		if !slices.Contains(states, state) {
+			states = append(states, state)
+		}
+
+		switch operationCode(mConfiguration.Operations[1][0]) {
+		case rightOp:
+			productions = append(productions, Production{
+				Left:  SententialForm{state, scanned},
+				Right: SententialForm{printed, next},
+			})
+		case leftOp:
+			for _, symbol := range symbols {
+				productions = append(productions, Production{
+					Left:  SententialForm{symbol, state, scanned},
+					Right: SententialForm{next, symbol, printed},
+				})
+			}
+			productions = append(productions, Production{
+				Left:  SententialForm{grammarLeftMarker, state, scanned},
+				Right: SententialForm{grammarLeftMarker, next, blank, printed},
+			})
+		default:
+			productions = append(productions, Production{
+				Left:  SententialForm{state, scanned},
+				Right: SententialForm{next, printed},
+			})
+		}
+	}
+
+	// The tape is extended with blank squares as the machine moves past its right end
+	for _, state := range states {
+		productions = append(productions, Production{
+			Left:  SententialForm{state, grammarRightMarker},
+			Right: SententialForm{state, blank, grammarRightMarker},
+		})
+	}
+
+	start := SententialForm{grammarLeftMarker, startingMConfigurationName(standardInput)}
+	start = append(start, standardInput.Tape...)
+	if len(standardInput.Tape) == 0 {
+		start = append(start, blank)
+	}
+	start = append(start, grammarRightMarker)
+
+	return Grammar{
+		Start:       start,
+		Productions: productions,
+		SymbolMap:   st.SymbolMap,
+	}
+}
+
+// Derives at most `maxSteps` sentential forms from the start, each time applying the first
+// production (in order) that matches at the leftmost position. Returns every sentential form
+// of the derivation, including the start. The derivation ends early if no production applies,
+// which happens exactly when the machine halts.
+func (g Grammar) Derive(maxSteps int) []SententialForm {
+	forms := []SententialForm{g.Start}
+	form := g.Start
+	for step := 0; step < maxSteps; step++ {
+		next, ok := g.rewrite(form)
+		if !ok {
+			break
+		}
+		forms = append(forms, next)
+		form = next
+	}
+	return forms
+}
+
+// Applies the first production that matches at the leftmost position
+func (g Grammar) rewrite(form SententialForm) (SententialForm, bool) {
+	for i := range form {
+		for _, production := range g.Productions {
+			if i+len(production.Left) <= len(form) && slices.Equal(form[i:i+len(production.Left)], production.Left) {
+				rewritten := slices.Clone(form[:i])
+				rewritten = append(rewritten, production.Right...)
+				return append(rewritten, form[i+len(production.Left):]...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Returns the tape described by the sentential form, translated to the machine's symbols
+func (g Grammar) Tape(form SententialForm) string {
+	tape := Tape{}
+	for _, symbol := range form {
+		if _, ok := g.SymbolMap[symbol]; ok {
+			tape = append(tape, symbol)
+		}
+	}
+	return g.SymbolMap.TranslateTape(tape)
+}
+
+// Returns the production in the form `q1 S0 -> S1 q2`
+func (p Production) String() string {
+	return p.Left.String() + " -> " + p.Right.String()
+}
+
+// Returns the symbols of the sentential form separated by spaces
+func (form SententialForm) String() string {
+	return strings.Join(form, " ")
+}
+
+// Returns the standardized symbols in order (S0, S1, ...)
+func standardSymbols(symbolMap SymbolMap) []string {
+	symbols := []string{}
+	for symbol := range symbolMap {
+		symbols = append(symbols, symbol)
+	}
+	slices.SortFunc(symbols, func(a, b string) int {
+		i, _ := strconv.Atoi(a[len(mConfigurationSymbolPrefix):])
+		j, _ := strconv.Atoi(b[len(mConfigurationSymbolPrefix):])
+		return i - j
+	})
+	return symbols
+}
@@ -0,0 +1,53 @@
+package turing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestGrammarExample1(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	g := input.Grammar()
+	if g.Start.String() != "[ q1 S0 ]" {
+		t.Errorf("got %s, want [ q1 S0 ]", g.Start)
+	}
+	if g.Productions[0].String() != "q1 S0 -> S1 q2" {
+		t.Errorf("got %s, want q1 S0 -> S1 q2", g.Productions[0])
+	}
+
+	forms := g.Derive(100)
+	if len(forms) != 101 {
+		t.Errorf("got %d sentential forms, want 101", len(forms))
+	}
+	turingtest.TapePrefix(t, strings.TrimRight(g.Tape(forms[len(forms)-1]), " "), "0 1 0 1 0 1 0 1 0 1 0 1 0 1 0 1 0 1 0 1 0 1 0 1 0")
+}
+
+func TestGrammarLeftAndHalt(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"0"}, []string{"L", "P1"}, "c"},
+			{"c", []string{"1"}, []string{"R", "R", "P1"}, "halt"},
+		},
+		Tape:            []string{"0"},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	m := NewMachine(input)
+	m.MoveN(10)
+
+	g := input.Grammar()
+	forms := g.Derive(100)
+	if len(forms) == 101 {
+		t.Fatal("expected derivation to end when the machine halts")
+	}
+	turingtest.TapePrefix(t, g.Tape(forms[len(forms)-1]), m.TapeString())
+}
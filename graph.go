@@ -0,0 +1,204 @@
+package turing
+
+import "slices"
+
+type (
+	// The transition graph of a machine. Nodes are m-configurations, and there is an
+	// Edge for every row of the table.
+	Graph struct {
+		// The m-configuration names, in the order they first appear in the table. This
+		// includes final m-configurations that have no rows of their own (i.e. `halt`).
+		Nodes []string
+
+		// The transitions between m-configurations
+		Edges []Edge
+	}
+
+	// A single transition in the Graph
+	Edge struct {
+		// The m-configuration the transition is taken from
+		From string
+
+		// The symbols that cause the transition to be taken
+		Symbols []string
+
+		// The operations performed during the transition
+		Operations []string
+
+		// The m-configuration the transition leads to
+		To string
+	}
+)
+
+// Returns the transition graph of the machine
+func (input MachineInput) Graph() Graph {
+	g := Graph{
+		Nodes: []string{},
+		Edges: []Edge{},
+	}
+	for _, mConfiguration := range input.MConfigurations {
+		g.addNode(mConfiguration.Name)
+		g.addNode(mConfiguration.FinalMConfiguration)
+		g.Edges = append(g.Edges, Edge{
+			From:       mConfiguration.Name,
+			Symbols:    mConfiguration.Symbols,
+			Operations: mConfiguration.Operations,
+			To:         mConfiguration.FinalMConfiguration,
+		})
+	}
+	return g
+}
+
+// Returns the distinct m-configurations directly reachable from the node
+func (g Graph) Successors(node string) []string {
+	successors := []string{}
+	for _, edge := range g.Edges {
+		if edge.From == node && !slices.Contains(successors, edge.To) {
+			successors = append(successors, edge.To)
+		}
+	}
+	return successors
+}
+
+// Returns the distinct successors of every node, building them in a single pass over the edges
+// rather than scanning the edges for each node as `Successors` does
+func (g Graph) successorsByNode() map[string][]string {
+	successors := map[string][]string{}
+	for _, edge := range g.Edges {
+		if !slices.Contains(successors[edge.From], edge.To) {
+			successors[edge.From] = append(successors[edge.From], edge.To)
+		}
+	}
+	return successors
+}
+
+// Returns the edges leaving the node
+func (g Graph) EdgesFrom(node string) []Edge {
+	edges := []Edge{}
+	for _, edge := range g.Edges {
+		if edge.From == node {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Adds a node if it does not already exist
+func (g *Graph) addNode(node string) {
+	if !slices.Contains(g.Nodes, node) {
+		g.Nodes = append(g.Nodes, node)
+	}
+}
+
+// Returns the strongly connected components of the graph (in reverse topological order),
+// each listing its nodes in the order they appear in Nodes.
+func (g Graph) StronglyConnectedComponents() [][]string {
+	t := &tarjan{
+		graph:      g,
+		successors: g.successorsByNode(),
+		index:      map[string]int{},
+		lowLink:    map[string]int{},
+		onStack:    map[string]bool{},
+	}
+	for _, node := range g.Nodes {
+		if _, visited := t.index[node]; !visited {
+			t.strongConnect(node)
+		}
+	}
+	return t.components
+}
+
+// Returns the simple cycles of the graph (each listed from its earliest node in Nodes),
+// stopping after `limit` cycles have been found as their number can grow exponentially.
+func (g Graph) SimpleCycles(limit int) [][]string {
+	order := map[string]int{}
+	for i, node := range g.Nodes {
+		order[node] = i
+	}
+	component := map[string]int{}
+	for i, nodes := range g.StronglyConnectedComponents() {
+		for _, node := range nodes {
+			component[node] = i
+		}
+	}
+
+	successors := g.successorsByNode()
+	cycles := [][]string{}
+	for _, start := range g.Nodes {
+		path := []string{start}
+		onPath := map[string]bool{start: true}
+		var search func(node string)
+		search = func(node string) {
+			for _, successor := range successors[node] {
+				if len(cycles) >= limit {
+					return
+				}
+				// Only nodes after the start in the same component can take part in its cycles
+				if component[successor] != component[start] || order[successor] < order[start] {
+					continue
+				}
+				if successor == start {
+					cycles = append(cycles, slices.Clone(path))
+				} else if !onPath[successor] {
+					path = append(path, successor)
+					onPath[successor] = true
+					search(successor)
+					onPath[successor] = false
+					path = path[:len(path)-1]
+				}
+			}
+		}
+		search(start)
+	}
+	return cycles
+}
+
+// Helper struct for Tarjan's strongly connected components algorithm
+type tarjan struct {
+	graph      Graph
+	successors map[string][]string
+	count      int
+	index      map[string]int
+	lowLink    map[string]int
+	stack      []string
+	onStack    map[string]bool
+	components [][]string
+}
+
+// Visits the node, and completes a component if the node is its root
+func (t *tarjan) strongConnect(node string) {
+	t.index[node] = t.count
+	t.lowLink[node] = t.count
+	t.count++
+	t.stack = append(t.stack, node)
+	t.onStack[node] = true
+
+	for _, successor := range t.successors[node] {
+		if _, visited := t.index[successor]; !visited {
+			t.strongConnect(successor)
+			t.lowLink[node] = min(t.lowLink[node], t.lowLink[successor])
+		} else if t.onStack[successor] {
+			t.lowLink[node] = min(t.lowLink[node], t.index[successor])
+		}
+	}
+
+	if t.lowLink[node] == t.index[node] {
+		members := map[string]bool{}
+		for {
+			member := t.stack[len(t.stack)-1]
+			t.stack = t.stack[:len(t.stack)-1]
+			t.onStack[member] = false
+			members[member] = true
+			if member == node {
+				break
+			}
+		}
+		component := []string{}
+		for _, n := range t.graph.Nodes {
+			if members[n] {
+				component = append(component, n)
+			}
+		}
+		t.components = append(t.components, component)
+	}
+}
@@ -0,0 +1,57 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	g := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0"}, "b"},
+			{"b", []string{"0"}, []string{"R", "R", "P1"}, "b"},
+			{"b", []string{"1"}, []string{"R", "R", "P0"}, "c"},
+		},
+	}.Graph()
+
+	if !reflect.DeepEqual(g.Nodes, []string{"b", "c"}) {
+		t.Errorf("got %v, want [b c]", g.Nodes)
+	}
+	if len(g.Edges) != 3 {
+		t.Errorf("got %d edges, want 3", len(g.Edges))
+	}
+	if !reflect.DeepEqual(g.Successors("b"), []string{"b", "c"}) {
+		t.Errorf("got %v, want [b c]", g.Successors("b"))
+	}
+	if len(g.EdgesFrom("c")) != 0 {
+		t.Errorf("got %v, want no edges", g.EdgesFrom("c"))
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "b"},
+			{"c", []string{"0"}, []string{"R"}, "d"},
+			{"d", []string{" "}, []string{"R"}, "d"},
+			{"d", []string{"0"}, []string{}, "halt"},
+		},
+	}.Graph()
+
+	components := g.StronglyConnectedComponents()
+	expected := [][]string{{"halt"}, {"d"}, {"b", "c"}}
+	if !reflect.DeepEqual(components, expected) {
+		t.Errorf("got %v, want %v", components, expected)
+	}
+
+	cycles := g.SimpleCycles(10)
+	expectedCycles := [][]string{{"b", "c"}, {"d"}}
+	if !reflect.DeepEqual(cycles, expectedCycles) {
+		t.Errorf("got %v, want %v", cycles, expectedCycles)
+	}
+
+	if len(g.SimpleCycles(1)) != 1 {
+		t.Errorf("expected cycles to be limited")
+	}
+}
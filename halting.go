@@ -0,0 +1,175 @@
+package turing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// The outcome of a bounded halting analysis
+	HaltingVerdict int
+
+	// Evidence for the outcome of a bounded halting analysis
+	HaltingCertificate struct {
+		// Whether the machine halts, never halts, or could not be decided
+		Verdict HaltingVerdict
+
+		// If the machine halts, the amount of moves it took. Otherwise the amount
+		// of moves that were explored.
+		Moves int
+
+		// If the machine never halts, the move after which its configuration first repeats
+		LoopStart int
+
+		// If the machine never halts, the amount of moves between repetitions
+		Period int
+
+		// If the machine never halts, the squares the head moved between repetitions (zero unless the
+		// configuration repeats translated along the tape)
+		Offset int
+
+		// If the machine never halts, the repeated configuration: the m-configuration, the scanned
+		// square within the non-blank squares, and the non-blank squares, separated by `|`
+		Configuration string
+	}
+
+	// The first move a configuration (up to translation) was seen, and where the head was
+	seenConfiguration struct {
+		moves int
+		head  int
+	}
+)
+
+const (
+	// The machine exceeded the tape window or the configuration limit
+	HaltingUnknown HaltingVerdict = iota
+	// The machine halts
+	Halts
+	// The machine repeats a configuration (up to translation) and so never halts
+	NeverHalts
+)
+
+// Decides whether a machine halts by exploring its configurations. Configurations are compared
+// up to translation of the tape, and as the machine is deterministic any repeated configuration
+// proves that it never halts. The tape is bounded by `window` squares (the span of non-blank
+// squares and the head), and at most `maxConfigurations` configurations are explored.
+// Small machines (i.e. busy beaver candidates) can be decided exhaustively. A repeated configuration
+// only proves anything on a tape that is blank and unbounded in both directions, so machines with a
+// LeftBound, a MaxTapeSquares or a BackgroundPattern are never found to never halt. Nor is a machine
+// stopped with an error (see `Err`), such as running out of tape, found to halt.
+func DecideHalting(input MachineInput, window int, maxConfigurations int) HaltingCertificate {
+	m := NewMachine(input)
+	seen := map[string]seenConfiguration{}
+	repeats := isTranslationInvariant(input)
+	for moves := 0; moves < maxConfigurations; moves++ {
+		key, span := m.translatedConfiguration()
+		if span > window {
+			return HaltingCertificate{Verdict: HaltingUnknown, Moves: moves}
+		}
+		if first, ok := seen[key]; ok && repeats {
+			return HaltingCertificate{
+				Verdict:       NeverHalts,
+				Moves:         moves,
+				LoopStart:     first.moves,
+				Period:        moves - first.moves,
+				Offset:        m.Head() - first.head,
+				Configuration: key,
+			}
+		}
+		seen[key] = seenConfiguration{moves, m.Head()}
+
+		m.Move()
+		if m.halted && m.err != nil {
+			return HaltingCertificate{Verdict: HaltingUnknown, Moves: m.moves}
+		}
+		if m.halted {
+			return HaltingCertificate{Verdict: Halts, Moves: m.moves}
+		}
+	}
+	return HaltingCertificate{Verdict: HaltingUnknown, Moves: maxConfigurations}
+}
+
+// Checks the certificate by simulating the machine independently of the analysis that produced it.
+// A machine that halts must halt without an error after exactly `Moves` moves. A machine that never halts must be in
+// `Configuration` after `LoopStart` moves and again, `Offset` squares along, after another `Period`
+// moves, so it repeats forever (which cannot be shown for machines with a LeftBound, a MaxTapeSquares
+// or a BackgroundPattern, see `DecideHalting`). An error wrapping `ErrInvalidCertificate` is returned
+// otherwise.
+func VerifyCertificate(input MachineInput, certificate HaltingCertificate) error {
+	m := NewMachine(input)
+	switch certificate.Verdict {
+	case Halts:
+		for i := 0; i <= certificate.Moves && !m.halted; i++ {
+			m.Move()
+		}
+		if !m.halted {
+			return fmt.Errorf("%w: did not halt after %d moves", ErrInvalidCertificate, certificate.Moves)
+		}
+		if m.err != nil {
+			return fmt.Errorf("%w: stopped with an error: %w", ErrInvalidCertificate, m.err)
+		}
+		if m.moves != certificate.Moves {
+			return fmt.Errorf("%w: halted after %d moves, not %d", ErrInvalidCertificate, m.moves, certificate.Moves)
+		}
+		return nil
+	case NeverHalts:
+		if !isTranslationInvariant(input) {
+			return fmt.Errorf("%w: a repeated configuration does not prove the machine never halts on its tape", ErrInvalidCertificate)
+		}
+		if certificate.Period <= 0 {
+			return fmt.Errorf("%w: period %d is not positive", ErrInvalidCertificate, certificate.Period)
+		}
+		if m.MoveN(certificate.LoopStart); m.halted {
+			return fmt.Errorf("%w: halted after %d moves", ErrInvalidCertificate, m.moves)
+		}
+		start, _ := m.translatedConfiguration()
+		head := m.Head()
+		if m.MoveN(certificate.Period); m.halted {
+			return fmt.Errorf("%w: halted after %d moves", ErrInvalidCertificate, m.moves)
+		}
+		end, _ := m.translatedConfiguration()
+		if start != certificate.Configuration || end != certificate.Configuration {
+			return fmt.Errorf("%w: configuration %q after %d moves and %q after %d moves, not %q", ErrInvalidCertificate,
+				start, certificate.LoopStart, end, certificate.LoopStart+certificate.Period, certificate.Configuration)
+		}
+		if offset := m.Head() - head; offset != certificate.Offset {
+			return fmt.Errorf("%w: head moved %d squares, not %d", ErrInvalidCertificate, offset, certificate.Offset)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: undecided", ErrInvalidCertificate)
+}
+
+// Returns true if the machine behaves the same wherever its tape's non-blank squares lie: its tape is
+// blank beyond them and unbounded in both directions, so a configuration repeated up to translation
+// repeats forever
+func isTranslationInvariant(input MachineInput) bool {
+	return input.LeftBound == UnboundedTape && input.MaxTapeSquares <= 0 && len(input.BackgroundPattern) == 0
+}
+
+// Returns a key that identifies the complete configuration up to translation of the tape,
+// as well as the amount of squares spanned by the non-blank squares and the head.
+func (m *Machine) translatedConfiguration() (string, int) {
+	start, end := m.scannedSquare, m.scannedSquare
+	for i, square := range m.tape {
+		if square != m.noneSymbol {
+			start = min(start, i)
+			end = max(end, i)
+		}
+	}
+
+	var key strings.Builder
+	key.WriteString(m.currentMConfigurationName)
+	key.WriteString("|")
+	key.WriteString(strconv.Itoa(m.scannedSquare - start))
+	for i := start; i <= end; i++ {
+		key.WriteString("|")
+		if i >= 0 && i < len(m.tape) {
+			key.WriteString(m.tape[i])
+		} else {
+			key.WriteString(m.noneSymbol)
+		}
+	}
+	return key.String(), end - start + 1
+}
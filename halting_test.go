@@ -0,0 +1,168 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecideHaltingBusyBeaver(t *testing.T) {
+	certificate := DecideHalting(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"a", []string{"0"}, []string{"P1", "R"}, "b"},
+			{"a", []string{"1"}, []string{"P1", "L"}, "b"},
+			{"b", []string{"0"}, []string{"P1", "L"}, "a"},
+			{"b", []string{"1"}, []string{"P1", "R"}, "halt"},
+		},
+		PossibleSymbols: []string{"1"},
+		NoneSymbol:      "0",
+	}, 10, 1000)
+	if certificate.Verdict != Halts || certificate.Moves != 6 {
+		t.Errorf("got %+v, want to halt after 6 moves", certificate)
+	}
+}
+
+func TestDecideHaltingLoop(t *testing.T) {
+	certificate := DecideHalting(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"L"}, "b"},
+			{"b", []string{"0"}, []string{"R"}, "c"},
+		},
+	}, 10, 1000)
+	if certificate.Verdict != NeverHalts || certificate.Period != 2 {
+		t.Errorf("got %+v, want to never halt with period 2", certificate)
+	}
+}
+
+func TestDecideHaltingTranslatedLoop(t *testing.T) {
+	certificate := DecideHalting(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+	}, 10, 1000)
+	if certificate.Verdict != NeverHalts || certificate.Period != 1 {
+		t.Errorf("got %+v, want to never halt with period 1", certificate)
+	}
+}
+
+func TestDecideHaltingBoundedTape(t *testing.T) {
+	// Repeats up to translation until moving left of the tape, which stops it with an error
+	bounded := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"L"}, "b"},
+		},
+		Tape:           Tape{" ", " ", " ", " "},
+		StartingSquare: 3,
+		LeftBound:      StrictLeftBound,
+	}
+	certificate := DecideHalting(bounded, 10, 1000)
+	if certificate.Verdict != HaltingUnknown {
+		t.Errorf("got %+v, want unknown", certificate)
+	}
+	certificate.Verdict = Halts
+	if err := VerifyCertificate(bounded, certificate); !errors.Is(err, ErrInvalidCertificate) {
+		t.Errorf("got %v, want %v", err, ErrInvalidCertificate)
+	}
+
+	limited := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+		MaxTapeSquares: 5,
+	}
+	patterned := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+		BackgroundPattern: []string{" ", " ", "1"},
+	}
+	for name, input := range map[string]MachineInput{"MaxTapeSquares": limited, "BackgroundPattern": patterned} {
+		t.Run(name, func(t *testing.T) {
+			if certificate := DecideHalting(input, 10, 1000); certificate.Verdict == NeverHalts {
+				t.Errorf("got %+v, want not to never halt", certificate)
+			}
+			unbounded := DecideHalting(MachineInput{MConfigurations: input.MConfigurations}, 10, 1000)
+			if err := VerifyCertificate(input, unbounded); !errors.Is(err, ErrInvalidCertificate) {
+				t.Errorf("got %v, want ErrInvalidCertificate", err)
+			}
+		})
+	}
+}
+
+func TestDecideHaltingTapeLimit(t *testing.T) {
+	// Loops forever, but runs out of tape first
+	certificate := DecideHalting(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"P1", "R"}, "b"},
+		},
+		MaxTapeSquares: 4,
+	}, 10, 1000)
+	if certificate.Verdict != HaltingUnknown {
+		t.Errorf("got %+v, want unknown", certificate)
+	}
+}
+
+func TestDecideHaltingUnknown(t *testing.T) {
+	certificate := DecideHalting(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "b"},
+		},
+	}, 10, 1000)
+	if certificate.Verdict != HaltingUnknown {
+		t.Errorf("got %+v, want unknown", certificate)
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	busyBeaver := getBusyBeaverMachineInput([]MConfiguration{
+		{"a", []string{"0"}, []string{"P1", "R"}, "b"},
+		{"a", []string{"1"}, []string{"P1", "L"}, "b"},
+		{"b", []string{"0"}, []string{"P1", "L"}, "a"},
+		{"b", []string{"1"}, []string{"P1", "R"}, "halt"},
+	})
+	cycler := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"L"}, "b"},
+			{"b", []string{"0"}, []string{"R"}, "c"},
+		},
+	}
+	translatedCycler := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+
+	for _, input := range []MachineInput{busyBeaver, cycler, translatedCycler} {
+		certificate := DecideHalting(input, 10, 1000)
+		if err := VerifyCertificate(input, certificate); err != nil {
+			t.Errorf("got %v verifying %+v", err, certificate)
+		}
+	}
+
+	t.Run("TranslatedCycler", func(t *testing.T) {
+		certificate := DecideHalting(translatedCycler, 10, 1000)
+		if certificate.Offset != 1 || certificate.Configuration != "b|0| " {
+			t.Errorf("got %+v", certificate)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, certificate := range []HaltingCertificate{
+			{Verdict: Halts, Moves: 5},
+			{Verdict: Halts, Moves: 7},
+			{Verdict: NeverHalts, LoopStart: 0, Period: 2, Configuration: "a|0|0"},
+			{Verdict: HaltingUnknown},
+		} {
+			if err := VerifyCertificate(busyBeaver, certificate); !errors.Is(err, ErrInvalidCertificate) {
+				t.Errorf("got %v verifying %+v, want ErrInvalidCertificate", err, certificate)
+			}
+		}
+		certificate := DecideHalting(translatedCycler, 10, 1000)
+		certificate.Offset = 0
+		if err := VerifyCertificate(translatedCycler, certificate); !errors.Is(err, ErrInvalidCertificate) {
+			t.Errorf("got %v, want ErrInvalidCertificate", err)
+		}
+	})
+}
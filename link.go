@@ -0,0 +1,139 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Returns a machine made of the fragments' tables, like object files linked into one program. A fragment
+// moves into another through the m-configurations the other exports (see `Exports`), and `halt` halts
+// whichever fragment moves to it. Every other m-configuration is private to its fragment, and is renamed
+// (by appending `.` and the fragment's index) if another fragment uses the same name. The fragments' symbols
+// are reconciled: the PossibleSymbols are merged, and each fragment's None symbol is renamed to that of
+// the first fragment. The machine starts as the first fragment does (its entry point), and takes its
+// Tape and other options from it. An error wrapping `ErrInvalidExport` is returned if a fragment exports
+// an m-configuration it does not define or that another fragment exports, and one wrapping
+// `ErrSymbolCollision` if a fragment uses the first fragment's None symbol as a symbol.
+func LinkMachines(fragments ...MachineInput) (MachineInput, error) {
+	if len(fragments) == 0 {
+		return MachineInput{}, nil
+	}
+	noneSymbol := fragments[0].NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+
+	// The exported m-configurations keep their names
+	exportedBy := map[string]int{}
+	for i, fragment := range fragments {
+		for _, name := range fragment.Exports {
+			if !slices.ContainsFunc(fragment.MConfigurations, func(mConfiguration MConfiguration) bool {
+				return mConfiguration.Name == name
+			}) {
+				return MachineInput{}, fmt.Errorf("%w: fragment %d does not define %s", ErrInvalidExport, i, name)
+			}
+			if j, ok := exportedBy[name]; ok && j != i {
+				return MachineInput{}, fmt.Errorf("%w: fragments %d and %d both export %s", ErrInvalidExport, j, i, name)
+			}
+			exportedBy[name] = i
+		}
+	}
+
+	// Every name each fragment uses, and how often each is used across fragments
+	used := make([][]string, len(fragments))
+	users := map[string]int{}
+	for i, fragment := range fragments {
+		used[i] = fragmentMConfigurationNames(fragment)
+		for _, name := range used[i] {
+			users[name]++
+		}
+	}
+	taken := map[string]bool{}
+	for name := range users {
+		taken[name] = true
+	}
+
+	linked := fragments[0]
+	linked.MConfigurations = []MConfiguration{}
+	linked.PossibleSymbols = []string{}
+	linked.HaltingMConfigurations = nil
+	linked.BeepMConfigurations = nil
+	linked.Exports = nil
+	for i, fragment := range fragments {
+		fragmentNoneSymbol := fragment.NoneSymbol
+		if len(fragmentNoneSymbol) == 0 {
+			fragmentNoneSymbol = none
+		}
+		if fragmentNoneSymbol != noneSymbol {
+			reconciled, err := RemapSymbols(fragment, map[string]string{fragmentNoneSymbol: noneSymbol})
+			if err != nil {
+				return MachineInput{}, err
+			}
+			fragment = reconciled
+		}
+
+		// A name is the fragment's own unless it is exported (by another fragment, if not defined here)
+		names := map[string]string{}
+		defined := map[string]bool{}
+		for _, mConfiguration := range fragment.MConfigurations {
+			defined[mConfiguration.Name] = true
+		}
+		for _, name := range used[i] {
+			names[name] = name
+			j, exported := exportedBy[name]
+			if name == haltMConfigurationName || (exported && (j == i || !defined[name])) {
+				continue
+			}
+			if users[name] > 1 || exported {
+				renamed := name + "." + strconv.Itoa(i)
+				for taken[renamed] {
+					renamed += "'"
+				}
+				taken[renamed] = true
+				names[name] = renamed
+			}
+		}
+
+		for _, mConfiguration := range cloneMConfigurations(fragment.MConfigurations) {
+			mConfiguration.Name = names[mConfiguration.Name]
+			mConfiguration.FinalMConfiguration = names[mConfiguration.FinalMConfiguration]
+			linked.MConfigurations = append(linked.MConfigurations, mConfiguration)
+		}
+		if i == 0 {
+			linked.StartingMConfiguration = names[startingMConfigurationName(fragment)]
+		}
+		for _, symbol := range fragment.PossibleSymbols {
+			if !slices.Contains(linked.PossibleSymbols, symbol) {
+				linked.PossibleSymbols = append(linked.PossibleSymbols, symbol)
+			}
+		}
+		for _, name := range fragment.HaltingMConfigurations {
+			linked.HaltingMConfigurations = append(linked.HaltingMConfigurations, names[name])
+		}
+		for _, name := range fragment.BeepMConfigurations {
+			linked.BeepMConfigurations = append(linked.BeepMConfigurations, names[name])
+		}
+		linked.Exports = append(linked.Exports, fragment.Exports...)
+	}
+	return linked, nil
+}
+
+// Returns every m-configuration name the machine uses, defined or moved to, in order of first appearance
+func fragmentMConfigurationNames(input MachineInput) []string {
+	names := []string{}
+	use := func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	use(startingMConfigurationName(input))
+	for _, mConfiguration := range input.MConfigurations {
+		use(mConfiguration.Name)
+		use(mConfiguration.FinalMConfiguration)
+	}
+	for _, name := range slices.Concat(input.HaltingMConfigurations, input.BeepMConfigurations) {
+		use(name)
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestLinkMachines(t *testing.T) {
+	// Turing's first example, split between two fragments that each define `c`
+	zero := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "one"},
+		},
+		PossibleSymbols: []string{"0"},
+		Exports:         []string{"b"},
+	}
+	one := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"one", []string{"_"}, []string{"P1", "R"}, "c"},
+			{"c", []string{"_"}, []string{"R"}, "b"},
+		},
+		PossibleSymbols: []string{"1"},
+		NoneSymbol:      "_",
+		Exports:         []string{"one"},
+	}
+	linked, err := LinkMachines(zero, one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c.0"},
+			{"c.0", []string{" "}, []string{"R"}, "one"},
+			{"one", []string{" "}, []string{"P1", "R"}, "c.1"},
+			{"c.1", []string{" "}, []string{"R"}, "b"},
+		},
+		StartingMConfiguration: "b",
+		PossibleSymbols:        []string{"0", "1"},
+		Exports:                []string{"b", "one"},
+	}
+	if diff := Diff(expected, linked); !diff.Empty() {
+		t.Errorf("got differences\n%s", diff.String())
+	}
+	turingtest.Equal(t, linked.StartingMConfiguration, "b")
+	turingtest.Equal(t, linked.NoneSymbol, "")
+
+	m := NewMachine(linked)
+	m.MoveN(8)
+	turingtest.Equal(t, m.TapeString(), "0 1 0 1 ")
+
+	t.Run("Errors", func(t *testing.T) {
+		undefined := zero
+		undefined.Exports = []string{"d"}
+		if _, err := LinkMachines(undefined, one); !errors.Is(err, ErrInvalidExport) {
+			t.Errorf("got %v, want %v", err, ErrInvalidExport)
+		}
+		twice := one
+		twice.Exports = []string{"c"}
+		again := zero
+		again.Exports = []string{"c"}
+		if _, err := LinkMachines(again, twice); !errors.Is(err, ErrInvalidExport) {
+			t.Errorf("got %v, want %v", err, ErrInvalidExport)
+		}
+		blank := one
+		blank.PossibleSymbols = []string{"1", " "}
+		if _, err := LinkMachines(zero, blank); !errors.Is(err, ErrSymbolCollision) {
+			t.Errorf("got %v, want %v", err, ErrSymbolCollision)
+		}
+	})
+}
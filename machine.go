@@ -1,6 +1,8 @@
 package turing
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -17,6 +19,10 @@ type (
 		// and divided into sections (called "squares") each capable of bearing a "symbol".
 		Tape Tape
 
+		// The square of the Tape the machine scans first (defaults to the first square). The Tape is
+		// padded with blanks if the square lies outside it.
+		StartingSquare int
+
 		// The m-configuration that the machine should start with. If empty the first m-configuration
 		// in the list is chosen.
 		StartingMConfiguration string
@@ -31,6 +37,53 @@ type (
 
 		// If `true`, the machine's complete configurations are printed at the end of each move.
 		Debug bool
+
+		// If `true`, halting because no m-configuration matches the scanned symbol is recorded
+		// as an error (see `Err`), unless the machine halted in the `halt` m-configuration
+		// or one of the HaltingMConfigurations.
+		StrictHalt bool
+
+		// The machine halts as soon as it moves to one of these m-configurations (or starts in one).
+		// Machines may also halt explicitly with the `H` operation.
+		HaltingMConfigurations []string
+
+		// If greater than zero, the head position is recorded every `HeadTrajectoryInterval`
+		// moves (see `HeadTrajectory`).
+		HeadTrajectoryInterval int
+
+		// If `true`, every square written by a Print or Erase operation is recorded (see `TapeWrites`).
+		RecordTapeWrites bool
+
+		// If provided, squares beyond the Tape bear this pattern (repeated in both directions
+		// from the square originally scanned) rather than the None symbol.
+		BackgroundPattern []string
+
+		// If greater than zero, the machine halts with an error wrapping `ErrTapeLimit` (see `Err`)
+		// rather than grow the tape beyond this many squares.
+		MaxTapeSquares int
+
+		// The machine "beeps" whenever it makes a move from one of these m-configurations (see `LastBeep`)
+		BeepMConfigurations []string
+
+		// If greater than zero, the blank squares (bearing the None symbol, or the BackgroundPattern) at
+		// either end of the tape are dropped every `TrimTapeInterval` moves, so that long runs only hold
+		// the squares in use rather than every square ever visited. Squares between the square originally
+		// scanned and the scanned square are kept, so `Head` and `Square` are unaffected.
+		TrimTapeInterval int `json:",omitempty"`
+
+		// Whether the tape has a leftmost square: the first square of the Tape, or the starting square if
+		// it lies left of the Tape. Defaults to a tape that grows to the left as needed.
+		LeftBound LeftBound `json:",omitempty"`
+
+		// The m-configurations other machines may move to once linked with this one (see `LinkMachines`).
+		Exports []string `json:",omitempty"`
+
+		// If provided, receives spans for compiling the machine's abbreviated table, standardizing it,
+		// and every `SpanRunInterval` moves the machine makes with `MoveN` (and the methods using it).
+		Tracer Tracer `json:"-"`
+
+		// The amount of moves in each run span (defaults to 10000)
+		SpanRunInterval int `json:"-"`
 	}
 
 	// Turing's Machine
@@ -38,30 +91,110 @@ type (
 		// See corresponding input field
 		mConfigurations []MConfiguration
 
+		// Finds the row of `mConfigurations` for an m-configuration and scanned symbol
+		index mConfigurationIndex
+
 		// See corresponding input field
 		tape []string
 
 		// See corresponding input field
 		possibleSymbols []string
 
+		// See corresponding input field
+		haltingMConfigurations []string
+
 		// See corresponding input field
 		noneSymbol string
 
 		// See corresponding input field
 		debug bool
 
+		// See corresponding input field
+		strictHalt bool
+
+		// See corresponding input field
+		headTrajectoryInterval int
+
+		// See corresponding input field
+		recordTapeWrites bool
+
+		// See corresponding input field
+		backgroundPattern []string
+
+		// See corresponding input field
+		maxTapeSquares int
+
+		// See corresponding input field
+		beepMConfigurations []string
+
+		// See corresponding input field
+		trimTapeInterval int
+
+		// See corresponding input field
+		leftBound LeftBound
+
+		// The leftmost square, relative to the square originally scanned (see `LeftBound`)
+		leftmostSquare int
+
+		// See corresponding input field
+		tracer Tracer
+
+		// See corresponding input field
+		spanRunInterval int
+
+		// The run span still open, and the moves made during it (see `tracedMoveN`)
+		runSpan      Span
+		runSpanMoves int
+
 		// At any moment there is just one square, say the r-th, bearing the symbol S(r)
 		// which is "in the machine". We may call this square the "scanned square".
 		// The symbol on the scanned square may be called the "scanned symbol".
 		// The "scanned symbol" is the only one of which the machine is, so to speak, "directly aware".
 		scannedSquare int
 
+		// The tape grows to the left as needed, so we keep track of where the square that
+		// was originally scanned is. Squares are numbered relative to it.
+		origin int
+
 		// The current m-configuration of the machine.
 		currentMConfigurationName string
 
-		// Stores whether the machine has "halted" or not. A machine only halts if it cannot
-		// find an m-configuration.
+		// The amount of moves the machine has made.
+		moves int
+
+		// The recorded head positions (see `HeadTrajectoryInterval`).
+		headTrajectory []int
+
+		// The recorded tape writes (see `RecordTapeWrites`).
+		tapeWrites []TapeWrite
+
+		// The move the machine last beeped on (see `BeepMConfigurations`).
+		lastBeep int
+
+		// Stores whether the machine has "halted" or not. A machine halts when it cannot find an
+		// m-configuration, moves to `halt` or one of the HaltingMConfigurations, performs the `H`
+		// operation, or stops with an error (see `err`).
 		halted bool
+
+		// The reason the machine halted, if it was not intentional.
+		err error
+	}
+
+	// The rows of a table by m-configuration and symbol, so that finding the row for the scanned
+	// symbol does not scan the whole table (see `findMConfiguration`)
+	mConfigurationIndex struct {
+		// The first row of each m-configuration naming each symbol exactly
+		exact map[indexKey]int
+
+		// The rows of each m-configuration matching symbols they do not name (with `*`, `!x` or no
+		// symbols at all), in order
+		wildcards map[string][]int
+	}
+
+	// An m-configuration and a symbol it scans
+	indexKey struct {
+		name   string
+		symbol string
 	}
 
 	// An m-configuration contains four components
@@ -69,7 +202,7 @@ type (
 		// The possible behaviour of the machine at any moment is determined by the m-configuration qn...
 		Name string
 
-		// ...and the scanned symbol S(r)
+		// ...and the scanned symbol S(r). If empty, any symbol (including ` ` (None)) is matched.
 		Symbols []string
 
 		// In some of the configurations in which the scanned square is blank (i.e. bears no symbol)
@@ -85,8 +218,59 @@ type (
 	// Our "tape" is a slice of strings because squares can contain multiple characters
 	Tape []string
 
+	// A single write to the tape (see `RecordTapeWrites`)
+	TapeWrite struct {
+		// The move (counting from 0) during which the square was written
+		Move int `json:"move"`
+
+		// The square written, relative to the square originally scanned
+		Square int `json:"square"`
+
+		// The symbol on the square before it was written
+		Old string `json:"old"`
+
+		// The symbol on the square after it was written
+		New string `json:"new"`
+	}
+
 	// Well-known single-character codes used in an m-configuration's operations.
 	operationCode byte
+
+	// Describes why the machine stopped moving
+	StopReason int
+
+	// Describes how the machine treats moving left of the leftmost square (see `LeftBound`)
+	LeftBound int
+
+	// Describes the result of moving the machine (see `MoveDetailed`)
+	MoveReport struct {
+		// The amount of moves the machine took
+		Moves int
+
+		// Whether the machine has halted
+		Halted bool
+
+		// Why the machine stopped moving
+		Reason StopReason
+
+		// The error recorded if the machine halted unintentionally (see `StrictHalt`)
+		Err error
+
+		// The m-configuration the machine stopped in
+		MConfiguration string
+
+		// The scanned square, relative to the square originally scanned
+		Head int
+	}
+
+	// Returned when a machine in strict mode halts because no m-configuration matched.
+	NoMatchingConfigurationError struct {
+		// The m-configuration the machine was in
+		MConfiguration string
+
+		// The scanned symbol
+		Symbol string
+	}
 )
 
 const (
@@ -94,17 +278,66 @@ const (
 	leftOp  operationCode = 'L'
 	eraseOp operationCode = 'E'
 	printOp operationCode = 'P'
+	noOp    operationCode = 'N'
+	haltOp  operationCode = 'H'
 
 	none string = " "
 	not  string = "!"
 	any  string = "*"
 )
 
+const (
+	// The machine made all of the moves it was asked to make
+	StoppedAtMoveLimit StopReason = iota
+	// The machine halted
+	StoppedHalted
+	// The machine halted unintentionally (see `Err`)
+	StoppedOnError
+	// The context the machine was run with was canceled (see `Run`)
+	StoppedCanceled
+	// The deadline of the context the machine was run with passed (see `Run`)
+	StoppedDeadlineExceeded
+)
+
+const (
+	// The amount of moves `Run` makes between checking whether its context is done
+	runContextInterval int = 1000
+)
+
+const (
+	// The tape grows to the left as needed
+	UnboundedTape LeftBound = iota
+	// Moving left of the leftmost square halts the machine with an error wrapping `ErrLeftBound` (see `Err`)
+	StrictLeftBound
+	// Moving left of the leftmost square leaves the machine scanning it, as if the move had not been made
+	ClampedLeftBound
+)
+
+const (
+	// By convention, machines halt intentionally by moving to this (undefined) m-configuration.
+	haltMConfigurationName string = "halt"
+)
+
 // Returns a new Machine
 func NewMachine(input MachineInput) *Machine {
 	m := &Machine{
-		mConfigurations: input.MConfigurations,
-		debug:           input.Debug,
+		mConfigurations:        input.MConfigurations,
+		index:                  newMConfigurationIndex(input.MConfigurations),
+		debug:                  input.Debug,
+		strictHalt:             input.StrictHalt,
+		headTrajectoryInterval: input.HeadTrajectoryInterval,
+		recordTapeWrites:       input.RecordTapeWrites,
+		backgroundPattern:      input.BackgroundPattern,
+		maxTapeSquares:         input.MaxTapeSquares,
+		beepMConfigurations:    input.BeepMConfigurations,
+		haltingMConfigurations: input.HaltingMConfigurations,
+		trimTapeInterval:       input.TrimTapeInterval,
+		leftBound:              input.LeftBound,
+		tracer:                 input.Tracer,
+		spanRunInterval:        input.SpanRunInterval,
+	}
+	if m.spanRunInterval <= 0 {
+		m.spanRunInterval = defaultSpanRunInterval
 	}
 
 	// Use first m-configuration if starting m-configuration not specified
@@ -121,22 +354,85 @@ func NewMachine(input MachineInput) *Machine {
 		m.noneSymbol = input.NoneSymbol
 	}
 
-	// Initialize tape if nil
-	if input.Tape == nil {
+	// Copy the tape so machines constructed from the same input do not share squares
+	m.tape = slices.Clone([]string(input.Tape))
+	if m.tape == nil {
 		m.tape = []string{}
+	}
+
+	// Begin scanning the starting square, which becomes the square originally scanned
+	if input.StartingSquare < 0 {
+		padding := []string{}
+		for square := 0; square < -input.StartingSquare; square++ {
+			padding = append(padding, m.backgroundSymbol(square))
+		}
+		m.tape = append(padding, m.tape...)
 	} else {
-		m.tape = input.Tape
+		m.scannedSquare = input.StartingSquare
+		m.origin = input.StartingSquare
+		for len(m.tape) < m.scannedSquare {
+			m.tape = append(m.tape, m.backgroundSymbol(len(m.tape)-m.origin))
+		}
 	}
+	m.leftmostSquare = -m.origin
 
 	if m.debug {
 		m.printMConfigurationsForDebug()
 	}
 
+	// A machine starting in a halting m-configuration never moves
+	if m.isHaltingMConfiguration(m.currentMConfigurationName) {
+		m.halted = true
+	}
+
+	// A machine with invalid operations is halted before it begins
+	if err := input.Validate(); err != nil {
+		m.halted = true
+		m.err = err
+	}
+
+	m.recordHeadTrajectory()
+
 	return m
 }
 
+// Returns an error wrapping `ErrInvalidOperation` if any operation is not one of
+// `R`, `L`, `E`, `N`, `H`, or `P` followed by a symbol.
+func (input MachineInput) Validate() error {
+	for _, mConfiguration := range input.MConfigurations {
+		for _, operation := range mConfiguration.Operations {
+			if !isValidOperation(operation) {
+				return fmt.Errorf("%w: %q in m-configuration %s", ErrInvalidOperation, operation, mConfiguration.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns true if the operation is well-formed
+func isValidOperation(operation string) bool {
+	if len(operation) == 0 {
+		return false
+	}
+	switch operationCode(operation[0]) {
+	case rightOp, leftOp, eraseOp, noOp, haltOp:
+		return len(operation) == 1
+	case printOp:
+		return len(operation) > 1
+	}
+	return false
+}
+
 // Moves the machine n times and stops early if halted. Returns the amount of moves the machine took.
 func (m *Machine) MoveN(n int) int {
+	if m.tracer != nil {
+		return m.tracedMoveN(n)
+	}
+	return m.moveN(n)
+}
+
+// Moves the machine n times and stops early if halted, without tracing
+func (m *Machine) moveN(n int) int {
 	for i := 1; i <= n; i++ {
 		m.Move()
 		if m.halted {
@@ -146,6 +442,59 @@ func (m *Machine) MoveN(n int) int {
 	return n
 }
 
+// Moves the machine until it halts, or at most `maxMoves` times. Returns the amount of moves
+// the machine took, and the error recorded if the machine halted unintentionally (see `StrictHalt`).
+// If the machine did not halt `ErrStepLimit` is returned.
+func (m *Machine) RunUntilHalt(maxMoves int) (int, error) {
+	moves := m.MoveN(maxMoves)
+	if !m.halted {
+		return moves, fmt.Errorf("%w after %d moves", ErrStepLimit, moves)
+	}
+	return moves, m.err
+}
+
+// Moves the machine until it halts, or the context is canceled or its deadline passes, so that a runaway
+// machine can be stopped from another goroutine. Returns the amount of moves the machine took, and why it
+// stopped. The context is checked every thousand moves. To pace the moves, limit them or observe each
+// one, use a Runner instead.
+func (m *Machine) Run(ctx context.Context) (int, StopReason) {
+	start := m.moves
+	for !m.halted {
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return m.moves - start, StoppedDeadlineExceeded
+			}
+			return m.moves - start, StoppedCanceled
+		}
+		m.MoveN(runContextInterval)
+	}
+	return m.moves - start, m.stopReason()
+}
+
+// Moves the machine n times and stops early if halted. Returns a report describing where and why the machine stopped.
+func (m *Machine) MoveDetailed(n int) MoveReport {
+	moves := m.MoveN(n)
+	return MoveReport{
+		Moves:          moves,
+		Halted:         m.halted,
+		Reason:         m.stopReason(),
+		Err:            m.err,
+		MConfiguration: m.currentMConfigurationName,
+		Head:           m.Head(),
+	}
+}
+
+// Returns why the machine stopped moving
+func (m *Machine) stopReason() StopReason {
+	if m.err != nil {
+		return StoppedOnError
+	}
+	if m.halted {
+		return StoppedHalted
+	}
+	return StoppedAtMoveLimit
+}
+
 // Moves the machine once
 func (m *Machine) Move() {
 	if m.halted {
@@ -153,20 +502,41 @@ func (m *Machine) Move() {
 	}
 
 	// Scan symbol from the tape
-	symbol := m.scan()
+	symbol, ok := m.scan()
+	if !ok {
+		return
+	}
 
 	// Find the the correct m-configuration depending on the scanned synbol
 	mConfiguration, shouldHalt := m.findMConfiguration(m.currentMConfigurationName, symbol)
 
 	// If an m-configuration could not be found, halt the machine
 	if shouldHalt {
-		m.halted = true
+		m.halt(symbol)
 		return
 	}
 
+	m.apply(mConfiguration)
+}
+
+// Halts the machine because no m-configuration matches the scanned symbol
+func (m *Machine) halt(symbol string) {
+	m.halted = true
+	if m.strictHalt && m.currentMConfigurationName != haltMConfigurationName && !m.isHaltingMConfiguration(m.currentMConfigurationName) {
+		m.err = &NoMatchingConfigurationError{
+			MConfiguration: m.currentMConfigurationName,
+			Symbol:         symbol,
+		}
+	}
+}
+
+// Performs the operations of the m-configuration and moves to its final m-configuration
+func (m *Machine) apply(mConfiguration MConfiguration) {
 	// Perform operations
 	for _, operation := range mConfiguration.Operations {
-		m.performOperation(operation)
+		if !m.performOperation(operation) {
+			return
+		}
 	}
 
 	if m.debug {
@@ -174,7 +544,84 @@ func (m *Machine) Move() {
 	}
 
 	// Move to specified final-m-configuration
+	if slices.Contains(m.beepMConfigurations, m.currentMConfigurationName) {
+		m.lastBeep = m.moves + 1
+	}
 	m.currentMConfigurationName = mConfiguration.FinalMConfiguration
+	m.moves++
+
+	// Halt if we have moved to a halting m-configuration
+	if m.isHaltingMConfiguration(m.currentMConfigurationName) {
+		m.halted = true
+	}
+
+	m.recordHeadTrajectory()
+	m.trimTapeIfNeeded()
+}
+
+// Drops the blank squares at either end of the tape if needed (see `TrimTapeInterval`)
+func (m *Machine) trimTapeIfNeeded() {
+	if m.trimTapeInterval <= 0 || m.moves%m.trimTapeInterval != 0 {
+		return
+	}
+	start, end := 0, len(m.tape)
+	for start < min(m.origin, m.scannedSquare) && m.tape[start] == m.backgroundSymbol(start-m.origin) {
+		start++
+	}
+	for end > max(m.origin, m.scannedSquare)+1 && m.tape[end-1] == m.backgroundSymbol(end-1-m.origin) {
+		end--
+	}
+	if start == 0 && end == len(m.tape) {
+		return
+	}
+	// Copied so the dropped squares can be freed
+	m.tape = slices.Clone(m.tape[start:end])
+	m.scannedSquare -= start
+	m.origin -= start
+}
+
+// Returns true if the machine halts upon reaching the m-configuration
+func (m *Machine) isHaltingMConfiguration(name string) bool {
+	return slices.Contains(m.haltingMConfigurations, name)
+}
+
+// Returns the error recorded if the machine halted unintentionally (see `StrictHalt`)
+func (m *Machine) Err() error {
+	return m.err
+}
+
+// Returns the name of the m-configuration the machine is in
+func (m *Machine) CurrentMConfiguration() string {
+	return m.currentMConfigurationName
+}
+
+// Returns the scanned square, relative to the square originally scanned
+func (m *Machine) Head() int {
+	return m.scannedSquare - m.origin
+}
+
+// Returns the index within `Tape` of the square originally scanned. Squares are otherwise
+// identified by their position relative to it (as `Head` is), which is unaffected by the tape
+// growing to the left.
+func (m *Machine) Origin() int {
+	return m.origin
+}
+
+// Returns the symbol on the square, given its position relative to the square originally scanned.
+// Squares beyond the tape bear the None symbol (or the BackgroundPattern).
+func (m *Machine) Square(square int) string {
+	return m.squareSymbol(m.origin + square)
+}
+
+// Returns the move (counting from one) the machine last made from one of the BeepMConfigurations,
+// or zero if it has not
+func (m *Machine) LastBeep() int {
+	return m.lastBeep
+}
+
+// Returns true if the machine has halted
+func (m *Machine) Halted() bool {
+	return m.halted
 }
 
 // Returns the Machine's Tape
@@ -182,90 +629,252 @@ func (m *Machine) Tape() Tape {
 	return m.tape
 }
 
-// Return the Tape represented as a string
-func (m *Machine) TapeString() string {
-	return strings.Join([]string(m.tape), "")
+// Return the Tape represented as a string. The squares are written one after the other, unless a
+// format is given (see `TapeFormat`).
+func (m *Machine) TapeString(format ...TapeFormat) string {
+	if len(format) == 0 {
+		return strings.Join([]string(m.tape), "")
+	}
+	return m.formatTape(format[0], "")
 }
 
-// Returns the machine's Complete Configuration of the single-line form
-func (m *Machine) CompleteConfiguration() string {
-	var completeConfiguration strings.Builder
-	for i, square := range m.tape {
-		if i == m.scannedSquare {
-			completeConfiguration.WriteString(m.currentMConfigurationName)
-		}
-		completeConfiguration.WriteString(square)
+// Returns the machine's Complete Configuration, by default of the single-line form (see
+// `CompleteConfigurationStyle` for the others)
+func (m *Machine) CompleteConfiguration(style ...CompleteConfigurationStyle) string {
+	if len(style) != 0 {
+		return m.FormatCompleteConfiguration(style[0], TapeFormat{})
+	}
+	return m.FormatCompleteConfiguration(InlineStyle, TapeFormat{})
+}
+
+// Returns the machine's Complete Configuration in the style, with the tape written in the format (see
+// `TapeFormat`). In the single-line forms, the m-configuration is written as a square of its own.
+func (m *Machine) FormatCompleteConfiguration(style CompleteConfigurationStyle, format TapeFormat) string {
+	switch style {
+	case SuccessiveStyle:
+		return ":" + m.formatTape(format, m.currentMConfigurationName)
+	case AnnotatedStyle:
+		return fmt.Sprintf("state=%s head=%d tape=%s", m.currentMConfigurationName, m.Head(), m.formatTape(format, ""))
 	}
-	if m.scannedSquare == len(m.tape) {
-		completeConfiguration.WriteString(m.currentMConfigurationName)
+	return m.formatTape(format, m.currentMConfigurationName)
+}
+
+// Returns the recorded head positions (see `HeadTrajectoryInterval`). The first position
+// is recorded before any moves are made. Positions are relative to the square originally scanned.
+func (m *Machine) HeadTrajectory() []int {
+	return m.headTrajectory
+}
+
+// Returns the recorded head positions as CSV, with a header row
+func (m *Machine) HeadTrajectoryCSV() string {
+	var csv strings.Builder
+	csv.WriteString("move,square\n")
+	for i, square := range m.headTrajectory {
+		csv.WriteString(fmt.Sprintf("%d,%d\n", i*m.headTrajectoryInterval, square))
+	}
+	return csv.String()
+}
+
+// Returns the recorded tape writes (see `RecordTapeWrites`)
+func (m *Machine) TapeWrites() []TapeWrite {
+	return m.tapeWrites
+}
+
+// Records the head position if needed
+func (m *Machine) recordHeadTrajectory() {
+	if m.headTrajectoryInterval > 0 && m.moves%m.headTrajectoryInterval == 0 {
+		m.headTrajectory = append(m.headTrajectory, m.scannedSquare-m.origin)
 	}
-	return completeConfiguration.String()
 }
 
-// Scans the tape for the scanned symbol
-func (m *Machine) scan() string {
-	m.extendTapeIfNeeded()
-	return m.tape[m.scannedSquare]
+// Scans the tape for the scanned symbol. Returns false if the tape could not be extended to it.
+func (m *Machine) scan() (string, bool) {
+	if !m.extendTapeIfNeeded() {
+		return "", false
+	}
+	return m.tape[m.scannedSquare], true
 }
 
-// The Machine's Tape is infinite, so we extend it as-needed
-func (m *Machine) extendTapeIfNeeded() {
+// The Machine's Tape is infinite, so we extend it as-needed. If the tape may not grow any
+// further (see `MaxTapeSquares`), the machine halts and false is returned.
+func (m *Machine) extendTapeIfNeeded() bool {
+	if m.maxTapeSquares > 0 && len(m.tape) >= m.maxTapeSquares && (m.scannedSquare < 0 || m.scannedSquare >= len(m.tape)) {
+		m.halted = true
+		m.err = fmt.Errorf("%w: %d squares", ErrTapeLimit, m.maxTapeSquares)
+		return false
+	}
 	if m.scannedSquare >= len(m.tape) {
-		m.tape = append(m.tape, m.noneSymbol)
+		m.tape = append(m.tape, m.backgroundSymbol(len(m.tape)-m.origin))
 	}
 	if m.scannedSquare < 0 {
-		m.tape = append([]string{m.noneSymbol}, m.tape...)
+		m.tape = append([]string{m.backgroundSymbol(-m.origin - 1)}, m.tape...)
 		m.scannedSquare++
+		m.origin++
+	}
+	return true
+}
+
+// Returns the symbol on the square given its index within the tape (which may be beyond it)
+func (m *Machine) squareSymbol(square int) string {
+	if square >= 0 && square < len(m.tape) {
+		return m.tape[square]
 	}
+	return m.backgroundSymbol(square - m.origin)
+}
+
+// Returns the symbol an unvisited square bears, given its position relative to the square originally scanned
+func (m *Machine) backgroundSymbol(square int) string {
+	return backgroundSymbol(m.backgroundPattern, m.noneSymbol, square)
+}
+
+// Returns the symbol of the pattern (or the None symbol if there is none) at the square, relative to
+// the square originally scanned
+func backgroundSymbol(pattern []string, noneSymbol string, square int) string {
+	if len(pattern) == 0 {
+		return noneSymbol
+	}
+	i := square % len(pattern)
+	if i < 0 {
+		i += len(pattern)
+	}
+	return pattern[i]
 }
 
 // Find the appropriate full m-configuration given the current m-configuration name and the scanned symbol
 func (m *Machine) findMConfiguration(mConfigurationName string, symbol string) (MConfiguration, bool) {
-	for _, mConfiguration := range m.mConfigurations {
-		if mConfiguration.Name == mConfigurationName {
-			// Scenario 1: The provided symbol is contained exactly in the m-configuration
-			if slices.Contains(mConfiguration.Symbols, symbol) {
-				return mConfiguration, false
+	i := m.findRow(mConfigurationName, symbol)
+	if i < 0 {
+		return MConfiguration{}, true
+	}
+	return m.mConfigurations[i], false
+}
+
+// Returns the index of the first row of the m-configuration matching the symbol, or -1 if there is none
+func (m *Machine) findRow(mConfigurationName string, symbol string) int {
+	row, ok := m.index.exact[indexKey{mConfigurationName, symbol}]
+	if !ok {
+		row = -1
+	}
+	// A row matching the symbol without naming it takes precedence if it comes first
+	for _, wildcard := range m.index.wildcards[mConfigurationName] {
+		if row >= 0 && wildcard > row {
+			break
+		}
+		if m.matches(m.mConfigurations[wildcard], symbol) {
+			return wildcard
+		}
+	}
+	return row
+}
+
+// Indexes the rows by the m-configuration and the symbols they name
+func newMConfigurationIndex(mConfigurations []MConfiguration) mConfigurationIndex {
+	index := mConfigurationIndex{
+		exact:     map[indexKey]int{},
+		wildcards: map[string][]int{},
+	}
+	for i, mConfiguration := range mConfigurations {
+		wildcard := len(mConfiguration.Symbols) == 0
+		for _, symbol := range mConfiguration.Symbols {
+			if symbol == any || strings.Contains(symbol, not) {
+				wildcard = true
+				continue
 			}
+			key := indexKey{mConfiguration.Name, symbol}
+			if _, ok := index.exact[key]; !ok {
+				index.exact[key] = i
+			}
+		}
+		if wildcard {
+			index.wildcards[mConfiguration.Name] = append(index.wildcards[mConfiguration.Name], i)
+		}
+	}
+	return index
+}
+
+// Returns true if the m-configuration's symbols match the scanned symbol
+func (m *Machine) matches(mConfiguration MConfiguration, symbol string) bool {
+	// Scenario 0: The m-configuration has no symbols, which is shorthand for `*` and ` ` (None)
+	if len(mConfiguration.Symbols) == 0 {
+		return true
+	}
+
+	// Scenario 1: The provided symbol is contained exactly in the m-configuration
+	if slices.Contains(mConfiguration.Symbols, symbol) {
+		return true
+	}
+
+	if symbol != m.noneSymbol {
+		// Scenario 2: The m-configuration contains `*`
+		// Note that `*` does not include ` ` (None), which must be specified manually
+		if slices.Contains(mConfiguration.Symbols, any) {
+			return true
+		}
 
-			if symbol != m.noneSymbol {
-				// Scenario 2: The m-configuration contains `*`
-				// Note that `*` does not include ` ` (None), which must be specified manually
-				if slices.Contains(mConfiguration.Symbols, any) {
-					return mConfiguration, false
-				}
-
-				// Scenario 3: The MConfiguration contains `!x` where `x` is not the provided symbol
-				// Note that `!` does not include ` ` (None), which must be specified manually
-				notSymbols := []string{}
-				// First loop is required in the scenario we have multiple (`!x` and `!y`)
-				for _, mConfigurationSymbol := range mConfiguration.Symbols {
-					if strings.Contains(mConfigurationSymbol, not) {
-						notSymbols = append(notSymbols, mConfigurationSymbol[1:])
-					}
-				}
-				if len(notSymbols) > 0 && !slices.Contains(notSymbols, symbol) {
-					return mConfiguration, false
-				}
+		// Scenario 3: The MConfiguration contains `!x` where `x` is not the provided symbol
+		// Note that `!` does not include ` ` (None), which must be specified manually
+		notSymbols := []string{}
+		// First loop is required in the scenario we have multiple (`!x` and `!y`)
+		for _, mConfigurationSymbol := range mConfiguration.Symbols {
+			if strings.Contains(mConfigurationSymbol, not) {
+				notSymbols = append(notSymbols, mConfigurationSymbol[1:])
 			}
 		}
+		if len(notSymbols) > 0 && !slices.Contains(notSymbols, symbol) {
+			return true
+		}
 	}
-	return MConfiguration{}, true
+	return false
 }
 
-// Perform an operation
-func (m *Machine) performOperation(operation string) {
-	m.extendTapeIfNeeded()
+// Perform an operation. Returns false if the tape may not grow any further, or the operation stopped
+// the machine with an error. `NewMachine` halts machines with invalid operations before they begin, but
+// the machine shares its rows with the MachineInput, so a caller changing them afterwards can hand it an
+// operation that was never validated. In strict mode such an operation halts the machine.
+func (m *Machine) performOperation(operation string) bool {
+	if !m.extendTapeIfNeeded() {
+		return false
+	}
+	if m.strictHalt && !isValidOperation(operation) {
+		m.halted = true
+		m.err = fmt.Errorf("%w: %q in m-configuration %s", ErrInvalidOperation, operation, m.currentMConfigurationName)
+		return false
+	}
 	switch operationCode(operation[0]) {
 	case rightOp:
 		m.scannedSquare++
 	case leftOp:
+		if m.leftBound != UnboundedTape && m.scannedSquare-m.origin == m.leftmostSquare {
+			if m.leftBound == StrictLeftBound {
+				m.halted = true
+				m.err = fmt.Errorf("%w: in m-configuration %s", ErrLeftBound, m.currentMConfigurationName)
+				return false
+			}
+			break
+		}
 		m.scannedSquare--
 	case eraseOp:
-		m.tape[m.scannedSquare] = m.noneSymbol
+		m.write(m.noneSymbol)
 	case printOp:
-		m.tape[m.scannedSquare] = string(operation[1:])
+		m.write(string(operation[1:]))
+	case haltOp:
+		// The machine finishes the move, and then halts
+		m.halted = true
 	}
+	return true
+}
+
+// Writes the symbol on the scanned square
+func (m *Machine) write(symbol string) {
+	if m.recordTapeWrites {
+		m.tapeWrites = append(m.tapeWrites, TapeWrite{
+			Move:   m.moves,
+			Square: m.scannedSquare - m.origin,
+			Old:    m.tape[m.scannedSquare],
+			New:    symbol,
+		})
+	}
+	m.tape[m.scannedSquare] = symbol
 }
 
 // Prints the m-configurations of the machine nicely for debugging
@@ -277,16 +886,29 @@ func (m *Machine) printMConfigurationsForDebug() {
 
 // Prints the complete configuration for the machine nicely for debugging
 func (m *Machine) printCompleteConfigurationForDebug() {
-	for _, square := range m.tape {
-		fmt.Print(strings.Repeat("-", len(square)))
-	}
-	fmt.Println("-")
-	fmt.Println(m.TapeString())
-	for i, square := range m.tape {
-		if i >= m.scannedSquare {
-			break
-		}
-		fmt.Print(strings.Repeat(" ", len(square)))
+	fmt.Println(m.AlignedConfiguration(false))
+}
+
+func (e *NoMatchingConfigurationError) Error() string {
+	return fmt.Sprintf("%s: %s with symbol %q", ErrNoMatchingConfiguration, e.MConfiguration, e.Symbol)
+}
+
+func (e *NoMatchingConfigurationError) Unwrap() error {
+	return ErrNoMatchingConfiguration
+}
+
+func (r StopReason) String() string {
+	switch r {
+	case StoppedAtMoveLimit:
+		return "move limit"
+	case StoppedHalted:
+		return "halted"
+	case StoppedOnError:
+		return "error"
+	case StoppedCanceled:
+		return "canceled"
+	case StoppedDeadlineExceeded:
+		return "deadline exceeded"
 	}
-	fmt.Println(m.currentMConfigurationName)
+	return "unknown"
 }
@@ -1,8 +1,15 @@
 package turing
 
 import (
-	"strings"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/planetlambert/turing/turingtest"
 )
 
 func TestMachineExample1(t *testing.T) {
@@ -15,7 +22,7 @@ func TestMachineExample1(t *testing.T) {
 		},
 	})
 	m.MoveN(50)
-	checkTape(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
+	turingtest.TapePrefix(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
 }
 
 func TestMachineExample1Short(t *testing.T) {
@@ -27,7 +34,20 @@ func TestMachineExample1Short(t *testing.T) {
 		},
 	})
 	m.MoveN(50)
-	checkTape(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
+	turingtest.TapePrefix(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1 0 1")
+}
+
+func TestMachineEmptySymbols(t *testing.T) {
+	// No symbols matches any symbol, including ` ` (None)
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{}, []string{"P0", "R", "R"}, "c"},
+			{"c", nil, []string{"P1", "R", "R"}, "b"},
+		},
+		Tape: Tape{" ", " ", "x"},
+	})
+	m.MoveN(50)
+	turingtest.TapePrefix(t, m.TapeString(), "0 1 0 1 0 1 0 1 0 1")
 }
 
 func TestMachineExample2(t *testing.T) {
@@ -47,35 +67,467 @@ func TestMachineExample2(t *testing.T) {
 	})
 
 	m.Move()
-	checkCompleteConfiguration(t, m.CompleteConfiguration(), "eeo0 0")
+	turingtest.Equal(t, m.CompleteConfiguration(), "eeo0 0")
 	m.Move()
-	checkCompleteConfiguration(t, m.CompleteConfiguration(), "eeq0 0")
+	turingtest.Equal(t, m.CompleteConfiguration(), "eeq0 0")
 	m.Move()
-	checkCompleteConfiguration(t, m.CompleteConfiguration(), "ee0 q0")
+	turingtest.Equal(t, m.CompleteConfiguration(), "ee0 q0")
 	m.Move()
-	checkCompleteConfiguration(t, m.CompleteConfiguration(), "ee0 0 q")
+	turingtest.Equal(t, m.CompleteConfiguration(), "ee0 0 q")
 	m.Move()
-	checkCompleteConfiguration(t, m.CompleteConfiguration(), "ee0 0p 1")
+	turingtest.Equal(t, m.CompleteConfiguration(), "ee0 0p 1")
 	// ...
 
 	m.MoveN(200)
-	checkTape(t, m.TapeString(), "ee0 0 1 0 1 1 0 1 1 1 0 1 1 1 1")
+	turingtest.TapePrefix(t, m.TapeString(), "ee0 0 1 0 1 1 0 1 1 1 0 1 1 1 1")
 }
 
-func checkTape(t *testing.T, tape string, expectedStart string) {
-	if !strings.HasPrefix(tape, expectedStart) {
-		var actual string
-		if len(expectedStart)+10 <= len(tape) {
-			actual = tape[0 : len(expectedStart)+10]
-		} else {
-			actual = tape
+func TestMachineStrictHalt(t *testing.T) {
+	t.Run("Intentional", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "halt"},
+			},
+			StrictHalt: true,
+		})
+		moves, err := m.RunUntilHalt(10)
+		if err != nil {
+			t.Error(err)
 		}
-		t.Errorf("got %s, want %s", actual, expectedStart)
+		if moves != 2 {
+			t.Errorf("got %d moves, want 2", moves)
+		}
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "c"},
+				{"c", []string{"1"}, []string{"R"}, "c"},
+			},
+			StrictHalt: true,
+		})
+		_, err := m.RunUntilHalt(10)
+		var noMatch *NoMatchingConfigurationError
+		if !errors.As(err, &noMatch) {
+			t.Fatalf("got %v, want NoMatchingConfigurationError", err)
+		}
+		if noMatch.MConfiguration != "c" || noMatch.Symbol != "0" {
+			t.Errorf("got %s and %q, want c and \"0\"", noMatch.MConfiguration, noMatch.Symbol)
+		}
+	})
+
+	t.Run("NotStrict", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "c"},
+			},
+		})
+		if _, err := m.RunUntilHalt(10); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestMachineExplicitHalt(t *testing.T) {
+	t.Run("HaltOperation", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"P1", "H"}, "b"},
+			},
+			StrictHalt: true,
+		})
+		report := m.MoveDetailed(10)
+		if report.Moves != 2 || report.Reason != StoppedHalted {
+			t.Errorf("got %d moves and %s, want 2 and halted", report.Moves, report.Reason)
+		}
+		turingtest.TapePrefix(t, m.TapeString(), "01")
+	})
+
+	t.Run("HaltingMConfigurations", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "done"},
+				{"done", []string{" "}, []string{"P1", "R"}, "b"},
+			},
+			HaltingMConfigurations: []string{"done"},
+			StrictHalt:             true,
+		})
+		moves, err := m.RunUntilHalt(10)
+		if err != nil {
+			t.Error(err)
+		}
+		if moves != 1 {
+			t.Errorf("got %d moves, want 1", moves)
+		}
+		turingtest.TapePrefix(t, m.TapeString(), "0")
+	})
+
+	t.Run("StartsHalted", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "b"},
+			},
+			HaltingMConfigurations: []string{"b"},
+		})
+		m.MoveN(10)
+		if m.moves != 0 {
+			t.Errorf("got %d moves, want none", m.moves)
+		}
+	})
+}
+
+func TestMachineHeadTrajectory(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"L"}, "c"},
+			{"c", []string{" "}, []string{"L"}, "d"},
+			{"d", []string{" "}, []string{"R", "R", "R"}, "b"},
+		},
+		HeadTrajectoryInterval: 2,
+	})
+	m.MoveN(6)
+	if !reflect.DeepEqual(m.HeadTrajectory(), []int{0, -2, 0, 2}) {
+		t.Errorf("got %v, want [0 -2 0 2]", m.HeadTrajectory())
+	}
+	expectedCSV := "move,square\n0,0\n2,-2\n4,0\n6,2\n"
+	if m.HeadTrajectoryCSV() != expectedCSV {
+		t.Errorf("got %q, want %q", m.HeadTrajectoryCSV(), expectedCSV)
+	}
+}
+
+func TestMachineTapeWrites(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "L", "P1"}, "c"},
+			{"c", []string{"1"}, []string{"E"}, "halt"},
+		},
+		RecordTapeWrites: true,
+	})
+	m.MoveN(10)
+	expected := []TapeWrite{
+		{Move: 0, Square: 0, Old: " ", New: "0"},
+		{Move: 0, Square: -1, Old: " ", New: "1"},
+		{Move: 1, Square: -1, Old: "1", New: " "},
+	}
+	if !reflect.DeepEqual(m.TapeWrites(), expected) {
+		t.Errorf("got %v, want %v", m.TapeWrites(), expected)
 	}
 }
 
-func checkCompleteConfiguration(t *testing.T, actual string, expected string) {
-	if actual != expected {
-		t.Errorf("got %s, want %s", actual, expected)
+func TestMachineMoveDetailed(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "L"}, "c"},
+			{"c", []string{" "}, []string{"L"}, "d"},
+		},
+	}
+
+	report := NewMachine(input).MoveDetailed(1)
+	expected := MoveReport{Moves: 1, Reason: StoppedAtMoveLimit, MConfiguration: "c", Head: -1}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("got %+v, want %+v", report, expected)
 	}
+
+	report = NewMachine(input).MoveDetailed(10)
+	expected = MoveReport{Moves: 3, Halted: true, Reason: StoppedHalted, MConfiguration: "d", Head: -2}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("got %+v, want %+v", report, expected)
+	}
+
+	input.StrictHalt = true
+	report = NewMachine(input).MoveDetailed(10)
+	if report.Reason != StoppedOnError || report.Err == nil {
+		t.Errorf("got %+v, want an error", report)
+	}
+
+	if (MoveReport{}).Reason.String() != "move limit" {
+		t.Errorf("got %s, want move limit for a zero MoveReport", (MoveReport{}).Reason)
+	}
+}
+
+func TestMachineBackgroundPattern(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"0"}, []string{"P1", "R"}, "b"},
+			{"b", []string{"1"}, []string{"R"}, "b"},
+		},
+		BackgroundPattern: []string{"0", "1", "1"},
+	}
+	m := NewMachine(input)
+	m.MoveN(6)
+	turingtest.TapePrefix(t, m.TapeString(), "111111")
+
+	input.MConfigurations = []MConfiguration{
+		{"b", []string{"*"}, []string{"L"}, "b"},
+	}
+	m = NewMachine(input)
+	m.MoveN(4)
+	turingtest.TapePrefix(t, m.TapeString(), "0110")
+}
+
+func TestMachineIntrospection(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"L"}, "c"},
+			{"c", []string{" "}, []string{"L", "H"}, "b"},
+		},
+	})
+	if m.CurrentMConfiguration() != "b" || m.Head() != 0 || m.Halted() {
+		t.Errorf("got %s, %d and %t, want b, 0 and false", m.CurrentMConfiguration(), m.Head(), m.Halted())
+	}
+	m.Move()
+	if m.CurrentMConfiguration() != "c" || m.Head() != -1 || m.Halted() {
+		t.Errorf("got %s, %d and %t, want c, -1 and false", m.CurrentMConfiguration(), m.Head(), m.Halted())
+	}
+	m.Move()
+	if m.CurrentMConfiguration() != "b" || m.Head() != -2 || !m.Halted() {
+		t.Errorf("got %s, %d and %t, want b, -2 and true", m.CurrentMConfiguration(), m.Head(), m.Halted())
+	}
+}
+
+func TestMachineStartingSquare(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Px", "R"}, "b"},
+		},
+		Tape:            Tape{"0", "1"},
+		PossibleSymbols: []string{"0", "1", "x"},
+	}
+
+	for _, test := range []struct {
+		startingSquare int
+		expected       string
+	}{
+		{1, "0xx"},
+		{4, "01  xx"},
+		{-2, "xx01"},
+	} {
+		t.Run(strconv.Itoa(test.startingSquare), func(t *testing.T) {
+			input.StartingSquare = test.startingSquare
+			m := NewMachine(input)
+			if m.Head() != 0 {
+				t.Errorf("got head %d, want 0", m.Head())
+			}
+			m.MoveN(2)
+			turingtest.Equal(t, m.TapeString(), test.expected)
+		})
+	}
+}
+
+func TestMachineOrigin(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"P0", "L"}, "c"},
+			{"c", []string{"*", " "}, []string{"P1", "L"}, "b"},
+		},
+		Tape:            Tape{"x"},
+		PossibleSymbols: []string{"0", "1", "x"},
+	})
+	m.MoveN(3)
+	if m.Origin() != 2 || m.Head() != -3 {
+		t.Errorf("got origin %d and head %d, want 2 and -3", m.Origin(), m.Head())
+	}
+	for square, expected := range map[int]string{-3: " ", -2: "0", -1: "1", 0: "0", 1: " "} {
+		if symbol := m.Square(square); symbol != expected {
+			t.Errorf("got %q at %d, want %q", symbol, square, expected)
+		}
+	}
+}
+
+func TestMachineTrimTape(t *testing.T) {
+	// Moves 10 squares right, then left forever
+	mConfigurations := []MConfiguration{}
+	for i := 0; i < 10; i++ {
+		mConfigurations = append(mConfigurations, MConfiguration{strconv.Itoa(i), []string{"*", " "}, []string{"R"}, strconv.Itoa(i + 1)})
+	}
+	mConfigurations = append(mConfigurations, MConfiguration{"10", []string{"*", " "}, []string{"L"}, "10"})
+	input := MachineInput{
+		MConfigurations: mConfigurations,
+		Tape:            Tape{"x"},
+		PossibleSymbols: []string{"x"},
+	}
+
+	m := NewMachine(input)
+	m.MoveN(30)
+	if len(m.Tape()) != 20 {
+		t.Errorf("got %d squares, want 20", len(m.Tape()))
+	}
+
+	input.TrimTapeInterval = 5
+	m = NewMachine(input)
+	m.MoveN(30)
+	// The squares right of the square originally scanned are dropped
+	if len(m.Tape()) != 10 || m.Origin() != 9 || m.Head() != -10 {
+		t.Errorf("got %d squares, origin %d and head %d, want 10, 9 and -10", len(m.Tape()), m.Origin(), m.Head())
+	}
+	if m.Square(0) != "x" || m.Square(5) != " " {
+		t.Errorf("got %q and %q, want x and \" \"", m.Square(0), m.Square(5))
+	}
+
+	// The squares left of the square originally scanned are dropped
+	mirrored, err := Mirror(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = NewMachine(mirrored)
+	m.MoveN(30)
+	if len(m.Tape()) != 10 || m.Origin() != 0 || m.Head() != 10 {
+		t.Errorf("got %d squares, origin %d and head %d, want 10, 0 and 10", len(m.Tape()), m.Origin(), m.Head())
+	}
+
+	// Squares bearing the BackgroundPattern are blank
+	input.BackgroundPattern = []string{"0", "1"}
+	m = NewMachine(input)
+	m.MoveN(30)
+	turingtest.Equal(t, m.TapeString(), "101010101x")
+}
+
+func TestMachineClampedLeftBound(t *testing.T) {
+	// Moves left twice, then prints and moves right
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"L"}, "c"},
+			{"c", []string{"*", " "}, []string{"L"}, "e"},
+			{"e", []string{"*", " "}, []string{"P1", "R"}, "e"},
+		},
+		Tape:            Tape{"0", "0"},
+		StartingSquare:  1,
+		PossibleSymbols: []string{"0", "1"},
+	}
+	m := NewMachine(input)
+	m.MoveN(4)
+	turingtest.Equal(t, m.TapeString(), "110")
+
+	input.LeftBound = ClampedLeftBound
+	m = NewMachine(input)
+	m.MoveN(4)
+	turingtest.Equal(t, m.TapeString(), "11")
+	if m.Head() != 1 || m.Err() != nil {
+		t.Errorf("got head %d and %v, want 1 and no error", m.Head(), m.Err())
+	}
+
+	// The leftmost square is the starting square if it lies left of the Tape
+	input.StartingSquare = -1
+	m = NewMachine(input)
+	m.MoveN(4)
+	turingtest.Equal(t, m.TapeString(), "110")
+}
+
+func TestMachineBeeps(t *testing.T) {
+	m := NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "d"},
+			{"d", []string{" "}, []string{"R"}, "d"},
+		},
+		BeepMConfigurations: []string{"c"},
+	})
+	if m.LastBeep() != 0 {
+		t.Errorf("got last beep %d, want 0", m.LastBeep())
+	}
+	m.MoveN(10)
+	if m.LastBeep() != 2 {
+		t.Errorf("got last beep %d, want 2", m.LastBeep())
+	}
+}
+
+func TestMachineFindRow(t *testing.T) {
+	// Rows matching symbols they do not name take precedence over later rows naming them
+	precedence := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"0"}, []string{"P1"}, "b"},
+			{"b", []string{"*"}, []string{"P0"}, "c"},
+			{"b", []string{"1", " "}, []string{"R"}, "c"},
+			{"c", []string{"!0", " "}, []string{"L"}, "b"},
+			{"c", []string{"0"}, []string{"R"}, "c"},
+			{"c", []string{}, []string{"E"}, "b"},
+			{"d", []string{" ", "0"}, []string{"R"}, "d"},
+			{"d", []string{"0"}, []string{"L"}, "d"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	registry := StandardMachineRegistry()
+	example, _ := registry.Lookup("turing-example-2")
+	champion, _ := registry.Lookup("bb2-champion")
+	universal := NewUniversalMachine(UniversalMachineInput{
+		StandardDescription: NewStandardTable(example.Input).StandardDescription,
+	})
+
+	for _, input := range []MachineInput{precedence, example.Input, champion.Input, universal} {
+		m := NewMachine(input)
+		symbols := append([]string{m.noneSymbol, "unknown"}, machineSymbols(input)...)
+		names := append(mConfigurationNames(input), "unknown")
+		for _, name := range names {
+			for _, symbol := range symbols {
+				want := slices.IndexFunc(input.MConfigurations, func(mConfiguration MConfiguration) bool {
+					return mConfiguration.Name == name && m.matches(mConfiguration, symbol)
+				})
+				if got := m.findRow(name, symbol); got != want {
+					t.Errorf("got row %d for %s scanning %q, want row %d", got, name, symbol, want)
+				}
+			}
+		}
+	}
+}
+
+func TestMachineRun(t *testing.T) {
+	forever := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"R"}, "b"},
+		},
+	}
+
+	t.Run("Halted", func(t *testing.T) {
+		champion, _ := StandardMachineRegistry().Lookup("bb2-champion")
+		moves, reason := NewMachine(champion.Input).Run(context.Background())
+		if moves != 6 || reason != StoppedHalted {
+			t.Errorf("got %d moves (%s), want 6 moves (%s)", moves, reason, StoppedHalted)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		m := NewMachine(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0"}, "b"},
+			},
+			StrictHalt: true,
+		})
+		if moves, reason := m.Run(context.Background()); moves != 1 || reason != StoppedOnError {
+			t.Errorf("got %d moves (%s), want 1 move (%s)", moves, reason, StoppedOnError)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		m := NewMachine(forever)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		moves, reason := m.Run(ctx)
+		if moves == 0 || reason != StoppedCanceled {
+			t.Errorf("got %d moves (%s), want some moves (%s)", moves, reason, StoppedCanceled)
+		}
+		if m.Halted() {
+			t.Error("got a halted machine, want it to be able to continue")
+		}
+	})
+
+	t.Run("AlreadyCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if moves, reason := NewMachine(forever).Run(ctx); moves != 0 || reason != StoppedCanceled {
+			t.Errorf("got %d moves (%s), want 0 moves (%s)", moves, reason, StoppedCanceled)
+		}
+	})
+
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, reason := NewMachine(forever).Run(ctx); reason != StoppedDeadlineExceeded {
+			t.Errorf("got %s, want %s", reason, StoppedDeadlineExceeded)
+		}
+	})
 }
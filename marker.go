@@ -0,0 +1,51 @@
+package turing
+
+// The m-functions below mark, unmark and move marks over the figures of a tape laid out as Turing's
+// machines lay theirs out: beginning with `e e`, with each figure on an F-square and its mark (if any)
+// on the E-square to its right. They find their way with `f`, so are used along with `StandardSkeletons`.
+// A mark printed over another replaces it.
+var (
+	// `mkr(C, b, d, a)`. Every figure after the first `b`, up to the next `d` (or the end of the
+	// figures), is marked `a` -> `C`. If there is no `b` -> `C`.
+	markRegion = []MConfiguration{
+		{"mkr(C, b, d, a)", []string{"*", " "}, []string{}, "f(mkr1(C, d, a), C, b)"},
+		{"mkr1(C, d, a)", []string{"*", " "}, []string{"R", "R"}, "mkr2(C, d, a)"},
+		{"mkr2(C, d, a)", []string{"d", " "}, []string{}, "C"},
+		{"mkr2(C, d, a)", []string{"!d"}, []string{"R", "Pa", "R"}, "mkr2(C, d, a)"},
+	}
+
+	// `umr(C, b, d)`. The marks of every figure after the first `b`, up to the next `d` (or the end
+	// of the figures), are erased -> `C`. If there is no `b` -> `C`.
+	unmarkRegion = []MConfiguration{
+		{"umr(C, b, d)", []string{"*", " "}, []string{}, "f(umr1(C, d), C, b)"},
+		{"umr1(C, d)", []string{"*", " "}, []string{"R", "R"}, "umr2(C, d)"},
+		{"umr2(C, d)", []string{"d", " "}, []string{}, "C"},
+		{"umr2(C, d)", []string{"!d"}, []string{"R", "E", "R"}, "umr2(C, d)"},
+	}
+
+	// `mvm(C, B, a)`. The first mark `a` is moved on to the next figure -> `C`. If there is no `a`,
+	// or no figure after it (in which case the `a` is erased) -> `B`. To move every mark `a` to
+	// the symbols marked `b` instead, use `re(B, a, b)`.
+	moveMark = []MConfiguration{
+		{"mvm(C, B, a)", []string{"*", " "}, []string{}, "f(mvm1(C, B, a), B, a)"},
+		{"mvm1(C, B, a)", []string{"*", " "}, []string{"E", "R"}, "mvm2(C, B, a)"},
+		{"mvm2(C, B, a)", []string{"*"}, []string{"R", "Pa"}, "C"},
+		{"mvm2(C, B, a)", []string{" "}, []string{}, "B"},
+	}
+)
+
+// Returns the marker m-functions (`mkr`, `umr` and `mvm`), which mark a delimited region of the
+// figures with a letter, erase the marks of such a region and move a mark on to the next figure,
+// as Turing's Universal Machine does by hand (see `con` and `mk`). They are to be added to an
+// abbreviated table's m-configurations along with `StandardSkeletons`.
+func MarkerSkeletons() []MConfiguration {
+	mConfigurations := []MConfiguration{}
+	for _, skeleton := range [][]MConfiguration{
+		markRegion,
+		unmarkRegion,
+		moveMark,
+	} {
+		mConfigurations = append(mConfigurations, cloneMConfigurations(skeleton)...)
+	}
+	return mConfigurations
+}
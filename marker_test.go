@@ -0,0 +1,66 @@
+package turing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestMarkerSkeletons(t *testing.T) {
+	run := func(t *testing.T, call string, tape []string) string {
+		t.Helper()
+		mConfigurations := []MConfiguration{
+			{"b", []string{"*", " "}, []string{}, call},
+			printAndHalt,
+		}
+		mConfigurations = append(mConfigurations, MarkerSkeletons()...)
+		mConfigurations = append(mConfigurations, StandardSkeletons()...)
+		input, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+			MConfigurations:        mConfigurations,
+			Tape:                   tape,
+			PossibleSymbols:        []string{"e", ":", ";", "0", "1", "x", "y", "z"},
+			StartingMConfiguration: "b",
+			HaltingMConfigurations: []string{"halt"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewMachine(input)
+		if _, err := m.RunUntilHalt(10000); err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimRight(m.TapeString()[m.Origin():], none)
+	}
+
+	t.Run("MarkRegion", func(t *testing.T) {
+		tape := []string{"e", "e", ":", " ", "0", " ", "1", " ", "0", " ", ";", " ", "1"}
+		turingtest.Equal(t, run(t, "mkr(halt, :, ;, x)", tape), "ee: 0x1x0x; 1")
+	})
+
+	t.Run("MarkRegionToTheEnd", func(t *testing.T) {
+		tape := []string{"e", "e", "0", " ", ":", " ", "1", " ", "0"}
+		turingtest.Equal(t, run(t, "mkr(halt, :, ;, x)", tape), "ee0 : 1x0x")
+	})
+
+	t.Run("UnmarkRegion", func(t *testing.T) {
+		tape := []string{"e", "e", ":", " ", "0", "x", "1", "y", ";", " ", "1", "x"}
+		turingtest.Equal(t, run(t, "umr(halt, :, ;)", tape), "ee: 0 1 ; 1x")
+	})
+
+	t.Run("MoveMark", func(t *testing.T) {
+		tape := []string{"e", "e", "0", "x", "1", " ", "0"}
+		turingtest.Equal(t, run(t, "mvm(halt, ph(z), x)", tape), "ee0 1x0")
+		turingtest.Equal(t, run(t, "mvm(mvm(halt, ph(z), x), ph(z), x)", tape), "ee0 1 0x")
+	})
+
+	t.Run("MoveMarkPastTheEnd", func(t *testing.T) {
+		tape := []string{"e", "e", "0", " ", "1", "x"}
+		turingtest.Equal(t, run(t, "mvm(halt, ph(z), x)", tape), "ee0 1 z")
+	})
+
+	t.Run("MoveMarkMissing", func(t *testing.T) {
+		tape := []string{"e", "e", "0", " ", "1"}
+		turingtest.Equal(t, run(t, "mvm(halt, ph(z), x)", tape), "ee0 1  z")
+	})
+}
@@ -0,0 +1,227 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// A Markov normal algorithm: an ordered list of string-rewriting rules. Each step rewrites the
+	// leftmost occurrence of the left side of the first rule that has one, and the algorithm stops
+	// after a terminal rule, or once no rule applies.
+	MarkovAlgorithm struct {
+		// The symbols words are written with. Each is a single character.
+		Alphabet []string
+
+		// The rules, tried in order
+		Rules []MarkovRule
+	}
+
+	// A rule of a Markov algorithm. Its sides are words, and the left side may be empty (which
+	// occurs at the start of every word).
+	MarkovRule struct {
+		Left  string
+		Right string
+
+		// If `true`, the algorithm stops after applying the rule
+		Terminal bool
+	}
+)
+
+const (
+	// Marks the square the machine is matching or rewriting at (see `Compile`)
+	markovMark string = "'"
+)
+
+// Runs the algorithm on the word for at most `maxSteps` rewrites. Returns the word, and whether
+// the algorithm stopped.
+func (ma MarkovAlgorithm) Apply(word string, maxSteps int) (string, bool) {
+	for step := 0; step < maxSteps; step++ {
+		applied := false
+		for _, rule := range ma.Rules {
+			if i := strings.Index(word, rule.Left); i != -1 {
+				word = word[:i] + rule.Right + word[i+len(rule.Left):]
+				if rule.Terminal {
+					return word, true
+				}
+				applied = true
+				break
+			}
+		}
+		if !applied {
+			return word, true
+		}
+	}
+	return word, false
+}
+
+// Compiles the algorithm to a machine that rewrites the word written on its tape (with a square
+// for each symbol, beginning with the scanned square), halting once the algorithm stops. An
+// error wrapping `ErrNotInAlphabet` is returned if a rule has a symbol outside of the alphabet.
+//
+// For each rule in turn, the machine marks each square of the word (by writing the symbol with a
+// `'`) and compares the rule's left side with the squares from the mark onwards. Once it matches,
+// the left side is deleted and the right side inserted at the mark by shifting the rest of the word.
+func (ma MarkovAlgorithm) Compile() (MachineInput, error) {
+	for _, rule := range ma.Rules {
+		for _, symbol := range strings.Split(rule.Left+rule.Right, "") {
+			if !slices.Contains(ma.Alphabet, symbol) {
+				return MachineInput{}, fmt.Errorf("%w: %q in rule %s -> %s", ErrNotInAlphabet, symbol, rule.Left, rule.Right)
+			}
+		}
+	}
+
+	marked := func(symbol string) string {
+		if symbol == none {
+			return markovMark
+		}
+		return symbol + markovMark
+	}
+	symbols := slices.Clone(ma.Alphabet)
+	markedSymbols := []string{}
+	for _, symbol := range symbols {
+		markedSymbols = append(markedSymbols, marked(symbol))
+	}
+
+	mConfigurations := []MConfiguration{}
+	row := func(name string, symbol string, operations []string, final string) {
+		mConfigurations = append(mConfigurations, MConfiguration{name, []string{symbol}, operations, final})
+	}
+	home := func(i int) string {
+		if i == len(ma.Rules) {
+			return haltMConfigurationName
+		}
+		return fmt.Sprintf("home%d", i)
+	}
+
+	for i, rule := range ma.Rules {
+		left, right := strings.Split(rule.Left, ""), strings.Split(rule.Right, "")
+		if len(rule.Left) == 0 {
+			left = []string{}
+		}
+		if len(rule.Right) == 0 {
+			right = []string{}
+		}
+		begin := fmt.Sprintf("begin%d", i)
+		match := func(k int) string { return fmt.Sprintf("match%d.%d", i, k) }
+		found := fmt.Sprintf("found%d", i)
+		retreat := fmt.Sprintf("retreat%d", i)
+		remove := func(j int) string { return fmt.Sprintf("remove%d.%d", i, j) }
+		removeEnd := func(j int) string { return fmt.Sprintf("removeEnd%d.%d", i, j) }
+		removeLast := func(j int) string { return fmt.Sprintf("removeLast%d.%d", i, j) }
+		carryLeft := func(j int, symbol string) string { return fmt.Sprintf("carryLeft%d.%d.%s", i, j, symbol) }
+		insert := func(j int) string { return fmt.Sprintf("insert%d.%d", i, j) }
+		carryRight := func(j int, symbol string) string { return fmt.Sprintf("carryRight%d.%d.%s", i, j, symbol) }
+		returnToMark := func(j int) string { return fmt.Sprintf("return%d.%d", i, j) }
+		done := fmt.Sprintf("done%d", i)
+		next := home(0)
+		if rule.Terminal {
+			next = haltMConfigurationName
+		}
+
+		// Move to the start of the word, and mark it
+		for _, symbol := range symbols {
+			row(home(i), symbol, []string{string(leftOp)}, home(i))
+		}
+		row(home(i), none, []string{string(rightOp)}, begin)
+		for _, symbol := range append(slices.Clone(symbols), none) {
+			row(begin, symbol, []string{string(printOp) + marked(symbol)}, match(0))
+		}
+
+		// Compare the left side from the mark onwards. If it does not match, move the mark right,
+		// or try the next rule once the mark passes the end of the word.
+		if len(left) == 0 {
+			for _, symbol := range append(slices.Clone(markedSymbols), markovMark) {
+				row(match(0), symbol, []string{}, remove(0))
+			}
+		}
+		for k, expected := range left {
+			final := match(k + 1)
+			if k == len(left)-1 {
+				final = found
+			}
+			if k == 0 {
+				for _, symbol := range symbols {
+					if symbol == expected {
+						row(match(k), marked(symbol), []string{string(rightOp)}, final)
+					} else {
+						row(match(k), marked(symbol), []string{string(printOp) + symbol, string(rightOp)}, begin)
+					}
+				}
+				row(match(k), markovMark, []string{string(eraseOp), string(leftOp)}, home(i+1))
+				continue
+			}
+			for _, symbol := range append(slices.Clone(symbols), none) {
+				if symbol == expected {
+					row(match(k), symbol, []string{string(rightOp)}, final)
+				} else {
+					row(match(k), symbol, []string{string(leftOp)}, retreat)
+				}
+			}
+		}
+		row(found, none, []string{string(leftOp)}, found)
+		for _, symbol := range symbols {
+			row(found, symbol, []string{string(leftOp)}, found)
+			row(found, marked(symbol), []string{}, remove(0))
+			row(retreat, symbol, []string{string(leftOp)}, retreat)
+			row(retreat, marked(symbol), []string{string(printOp) + symbol, string(rightOp)}, begin)
+		}
+
+		// Remove the left side, a symbol at a time, by carrying the rest of the word left onto the mark
+		for j := 0; j < len(left); j++ {
+			final := remove(j + 1)
+			if j == len(left)-1 {
+				final = insert(len(right))
+			}
+			for _, symbol := range symbols {
+				row(remove(j), marked(symbol), []string{string(rightOp)}, removeEnd(j))
+				row(removeEnd(j), symbol, []string{string(rightOp)}, removeEnd(j))
+				row(removeLast(j), symbol, []string{string(eraseOp), string(leftOp)}, carryLeft(j, symbol))
+				row(removeLast(j), marked(symbol), []string{string(printOp) + markovMark}, final)
+				for _, carried := range symbols {
+					row(carryLeft(j, carried), symbol, []string{string(printOp) + carried, string(leftOp)}, carryLeft(j, symbol))
+					row(carryLeft(j, carried), marked(symbol), []string{string(printOp) + marked(carried)}, final)
+				}
+			}
+			row(removeEnd(j), none, []string{string(leftOp)}, removeLast(j))
+		}
+		if len(left) == 0 {
+			for _, symbol := range append(slices.Clone(markedSymbols), markovMark) {
+				row(remove(0), symbol, []string{}, insert(len(right)))
+			}
+		}
+
+		// Insert the right side, last symbol first, by carrying the rest of the word right from the mark
+		for j := len(right); j > 0; j-- {
+			inserted := right[j-1]
+			for _, symbol := range symbols {
+				row(insert(j), marked(symbol), []string{string(printOp) + marked(inserted), string(rightOp)}, carryRight(j, symbol))
+				for _, carried := range symbols {
+					row(carryRight(j, carried), symbol, []string{string(printOp) + carried, string(rightOp)}, carryRight(j, symbol))
+				}
+				row(carryRight(j, symbol), none, []string{string(printOp) + symbol}, returnToMark(j))
+				row(returnToMark(j), symbol, []string{string(leftOp)}, returnToMark(j))
+				row(returnToMark(j), marked(symbol), []string{}, insert(j-1))
+			}
+			row(insert(j), markovMark, []string{string(printOp) + marked(inserted)}, insert(j-1))
+		}
+		for _, symbol := range symbols {
+			row(insert(0), marked(symbol), []string{}, done)
+			row(done, marked(symbol), []string{string(printOp) + symbol}, next)
+		}
+		row(insert(0), markovMark, []string{}, done)
+		row(done, markovMark, []string{string(eraseOp), string(leftOp)}, next)
+	}
+	if len(ma.Rules) == 0 {
+		for _, symbol := range append(slices.Clone(symbols), none) {
+			row(home(0), symbol, []string{}, haltMConfigurationName)
+		}
+	}
+
+	return MachineInput{
+		MConfigurations:        mConfigurations,
+		StartingMConfiguration: home(0),
+		PossibleSymbols:        append(append(slices.Clone(symbols), markedSymbols...), markovMark),
+	}, nil
+}
@@ -0,0 +1,89 @@
+package turing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	// Converts a binary number to unary (written with `|`)
+	markovBinaryToUnary = MarkovAlgorithm{
+		Alphabet: []string{"0", "1", "|"},
+		Rules: []MarkovRule{
+			{Left: "|0", Right: "0||"},
+			{Left: "1", Right: "0|"},
+			{Left: "0", Right: ""},
+		},
+	}
+
+	// Adds unary numbers separated by `+`
+	markovUnaryAddition = MarkovAlgorithm{
+		Alphabet: []string{"1", "+"},
+		Rules: []MarkovRule{
+			{Left: "1+", Right: "+1"},
+			{Left: "+", Right: "", Terminal: true},
+		},
+	}
+
+	// Writes `ab` in front of the word, then stops
+	markovPrefix = MarkovAlgorithm{
+		Alphabet: []string{"a", "b"},
+		Rules: []MarkovRule{
+			{Left: "", Right: "ab", Terminal: true},
+		},
+	}
+)
+
+func TestMarkovAlgorithmApply(t *testing.T) {
+	if word, stopped := markovBinaryToUnary.Apply("101", 100); word != "|||||" || !stopped {
+		t.Errorf("got %q (stopped %t), want |||||", word, stopped)
+	}
+}
+
+func TestMarkovAlgorithmCompile(t *testing.T) {
+	for _, test := range []struct {
+		algorithm MarkovAlgorithm
+		words     []string
+	}{
+		{markovBinaryToUnary, []string{"101", "1", "0", "110", ""}},
+		{markovUnaryAddition, []string{"11+111", "+1", "1+", "+"}},
+		{markovPrefix, []string{"", "ba"}},
+	} {
+		input, err := test.algorithm.Compile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, word := range test.words {
+			t.Run(word, func(t *testing.T) {
+				expected, _ := test.algorithm.Apply(word, 1000)
+				input.Tape = ParseTape(word, "")
+				m := NewMachine(input)
+				if _, err := m.RunUntilHalt(100000); err != nil {
+					t.Fatal(err)
+				}
+				if actual := strings.Trim(m.TapeString(), none); actual != expected {
+					t.Errorf("got %q, want %q", actual, expected)
+				}
+			})
+		}
+	}
+}
+
+func TestMarkovAlgorithmStandardize(t *testing.T) {
+	input, _ := markovUnaryAddition.Compile()
+	input.Tape = ParseTape("1+11", "")
+	st := NewStandardTable(input)
+	m := NewMachine(st.MachineInput)
+	m.MoveN(100000)
+	if actual := strings.Trim(st.SymbolMap.TranslateTape(m.Tape()), none); actual != "111" {
+		t.Errorf("got %q, want 111", actual)
+	}
+}
+
+func TestMarkovAlgorithmNotInAlphabet(t *testing.T) {
+	_, err := MarkovAlgorithm{Alphabet: []string{"a"}, Rules: []MarkovRule{{Left: "a", Right: "b"}}}.Compile()
+	if !errors.Is(err, ErrNotInAlphabet) {
+		t.Errorf("got %v, want ErrNotInAlphabet", err)
+	}
+}
@@ -0,0 +1,62 @@
+package turing
+
+type (
+	// Measurements of a single run of a machine (see `Measure`)
+	Metrics struct {
+		// The amount of moves the machine made
+		Moves int `json:"moves"`
+
+		// Whether the machine halted within the limit
+		Halted bool `json:"halted"`
+
+		// The amount of squares bearing a symbol (i.e. `1` for machines with a blank `0`) at the end of the run
+		Ones int `json:"ones"`
+
+		// The amount of distinct squares the machine scanned
+		SquaresVisited int `json:"squaresVisited"`
+
+		// The furthest the machine scanned from the square originally scanned, in either direction
+		MaxExcursion int `json:"maxExcursion"`
+	}
+
+	// Scores a run of a machine, higher being better (i.e. the objective of a busy beaver search)
+	Score func(Metrics) int
+)
+
+// Runs the machine for at most `limit` moves (stopping early if it halts), and measures the run
+func Measure(input MachineInput, limit int) Metrics {
+	m := NewMachine(input)
+	visited := map[int]bool{m.Head(): true}
+	metrics := Metrics{}
+	for i := 0; i < limit && !m.halted; i++ {
+		m.Move()
+		visited[m.Head()] = true
+		metrics.MaxExcursion = max(metrics.MaxExcursion, m.Head(), -m.Head())
+	}
+	metrics.Moves = m.moves
+	metrics.Halted = m.halted
+	metrics.SquaresVisited = len(visited)
+	for _, square := range m.tape {
+		if square != m.noneSymbol {
+			metrics.Ones++
+		}
+	}
+	return metrics
+}
+
+// Scores halting machines by the amount of `1`'s (or other symbols) they leave on the tape (the classic
+// busy beaver objective)
+func OnesScore(metrics Metrics) int {
+	if !metrics.Halted {
+		return 0
+	}
+	return metrics.Ones
+}
+
+// Scores halting machines by the amount of moves they make (the maximum shifts objective)
+func MovesScore(metrics Metrics) int {
+	if !metrics.Halted {
+		return 0
+	}
+	return metrics.Moves
+}
@@ -0,0 +1,31 @@
+package turing
+
+import "testing"
+
+func TestMeasure(t *testing.T) {
+	// The 2-state busy beaver champion
+	input := getBusyBeaverMachineInput([]MConfiguration{
+		{"A", []string{"0"}, []string{"P1", "R"}, "B"},
+		{"A", []string{"1"}, []string{"P1", "L"}, "B"},
+		{"B", []string{"0"}, []string{"P1", "L"}, "A"},
+		{"B", []string{"1"}, []string{"P1", "R"}, "halt"},
+	})
+	expected := Metrics{Moves: 6, Halted: true, Ones: 4, SquaresVisited: 4, MaxExcursion: 2}
+	if actual := Measure(input, defaultBusyBeaverMaxMoves); actual != expected {
+		t.Errorf("got %+v, want %+v", actual, expected)
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		expected := Metrics{Moves: 2, Ones: 2, SquaresVisited: 2, MaxExcursion: 1}
+		if actual := Measure(input, 2); actual != expected {
+			t.Errorf("got %+v, want %+v", actual, expected)
+		}
+	})
+}
+
+func TestMovesScore(t *testing.T) {
+	result, err := BusyBeaver(2, WithScore(MovesScore))
+	if err != nil || result.Score != 6 {
+		t.Errorf("Incorrect S-2 number %d (%v), expected 6", result.Score, err)
+	}
+}
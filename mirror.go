@@ -0,0 +1,62 @@
+package turing
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Returns the left-right mirror of the machine: every `L` becomes `R` and vice versa, and the tape
+// (and BackgroundPattern) is reversed about the starting square. The mirror computes the reverse of
+// every tape the machine computes. Returns an error wrapping `ErrUnsupportedMachine` if the machine
+// has a LeftBound, as the mirror would need a rightmost square.
+func Mirror(input MachineInput) (MachineInput, error) {
+	if input.LeftBound != UnboundedTape {
+		return MachineInput{}, fmt.Errorf("%w: the mirror of a left-bounded tape is right-bounded", ErrUnsupportedMachine)
+	}
+	mirrored := input
+	mirrored.MConfigurations = cloneMConfigurations(input.MConfigurations)
+	for i := range mirrored.MConfigurations {
+		for j, operation := range mirrored.MConfigurations[i].Operations {
+			switch operation {
+			case string(leftOp):
+				mirrored.MConfigurations[i].Operations[j] = string(rightOp)
+			case string(rightOp):
+				mirrored.MConfigurations[i].Operations[j] = string(leftOp)
+			}
+		}
+	}
+
+	if len(input.Tape) != 0 {
+		// Pad the tape so that it includes the starting square
+		noneSymbol := input.NoneSymbol
+		if len(noneSymbol) == 0 {
+			noneSymbol = none
+		}
+		tape, start := slices.Clone(input.Tape), input.StartingSquare
+		for ; start < 0; start++ {
+			tape = append(Tape{noneSymbol}, tape...)
+		}
+		for len(tape) <= start {
+			tape = append(tape, noneSymbol)
+		}
+		slices.Reverse(tape)
+		mirrored.Tape = tape
+		mirrored.StartingSquare = len(tape) - 1 - start
+	}
+
+	if len(input.BackgroundPattern) != 0 {
+		mirrored.BackgroundPattern = []string{}
+		for i := range input.BackgroundPattern {
+			mirrored.BackgroundPattern = append(mirrored.BackgroundPattern, input.BackgroundPattern[(len(input.BackgroundPattern)-i)%len(input.BackgroundPattern)])
+		}
+	}
+	return mirrored, nil
+}
+
+// Returns true if the machine is its own mirror (up to the names and order of its m-configurations,
+// see `Canonicalize`). A machine with a LeftBound has no mirror (see `Mirror`).
+func IsOwnMirror(input MachineInput) bool {
+	mirrored, err := Mirror(input)
+	return err == nil && reflect.DeepEqual(Canonicalize(mirrored), Canonicalize(input))
+}
@@ -0,0 +1,85 @@
+package turing
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestMirror(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"P1", "R"}, "c"},
+			{"c", []string{"*", " "}, []string{"R", "P0", "L", "L"}, "d"},
+			{"d", []string{"*", " "}, []string{"Px"}, "halt"},
+		},
+		Tape:            Tape{"a", "b", "c"},
+		StartingSquare:  1,
+		PossibleSymbols: []string{"0", "1", "a", "b", "c", "x"},
+	}
+	mirrored, err := Mirror(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mirrored.StartingSquare != 1 || strings.Join(mirrored.Tape, "") != "cba" {
+		t.Errorf("got tape %q starting at %d, want cba starting at 1", mirrored.Tape, mirrored.StartingSquare)
+	}
+	if input.MConfigurations[0].Operations[1] != "R" {
+		t.Error("got the input changed, want it unchanged")
+	}
+
+	m, mm := NewMachine(input), NewMachine(mirrored)
+	m.MoveN(10)
+	mm.MoveN(10)
+	reversed := slices.Clone(mm.Tape())
+	slices.Reverse(reversed)
+	if strings.Join(reversed, "") != m.TapeString() || mm.Head() != -m.Head() {
+		t.Errorf("got %s and head %d, want the reverse of %s and head %d", mm.TapeString(), mm.Head(), m.TapeString(), -m.Head())
+	}
+
+	input.LeftBound = StrictLeftBound
+	if _, err := Mirror(input); !errors.Is(err, ErrUnsupportedMachine) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedMachine)
+	}
+}
+
+func TestMirrorBackgroundPattern(t *testing.T) {
+	mirrored, err := Mirror(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*"}, []string{"R"}, "b"},
+		},
+		BackgroundPattern: []string{"0", "1", "2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Moving left, the mirror sees the pattern the machine sees moving right
+	m := NewMachine(mirrored)
+	m.MoveN(3)
+	turingtest.TapePrefix(t, m.TapeString(), "210")
+}
+
+func TestIsOwnMirror(t *testing.T) {
+	notOwn := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P1", "R"}, "c"},
+			{"c", []string{" "}, []string{"P1", "L"}, "b"},
+		},
+	}
+	if IsOwnMirror(notOwn) {
+		t.Error("got own mirror, want not (the starting m-configuration moves right)")
+	}
+
+	symmetric := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P1"}, "c"},
+			{"c", []string{" ", "1"}, []string{"E"}, "b"},
+		},
+	}
+	if !IsOwnMirror(symmetric) {
+		t.Error("got not own mirror, want own mirror (the machine never moves)")
+	}
+}
@@ -0,0 +1,126 @@
+package turing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+type (
+	// Counts the moves and outcomes of machines run through it, so long-lived simulation services
+	// can be monitored. It is an http.Handler serving the counts in the Prometheus text format,
+	// so it can be mounted at `/metrics`. Safe for concurrent use.
+	RunMonitor struct {
+		mutex       sync.Mutex
+		moves       int
+		running     map[*Machine]int
+		maxSquares  int
+		stopReasons map[string]int
+	}
+)
+
+const (
+	// The amount of moves between updates of a running machine's counts
+	runMonitorSegment int = 1000
+
+	// The content type of the Prometheus text format
+	prometheusContentType string = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Returns a monitor that has not counted any runs
+func NewRunMonitor() *RunMonitor {
+	return &RunMonitor{
+		running:     map[*Machine]int{},
+		stopReasons: map[string]int{},
+	}
+}
+
+// Moves the machine n times and stops early if halted (see `MoveDetailed`), counting it as
+// running meanwhile. The counts are updated every thousand moves.
+func (r *RunMonitor) Run(m *Machine, n int) MoveReport {
+	report := MoveReport{}
+	for report.Moves < n {
+		moves := m.moves
+		segment := m.MoveDetailed(min(n-report.Moves, runMonitorSegment))
+		report.Moves += segment.Moves
+		r.update(m, m.moves-moves, true)
+		report.Halted, report.Reason, report.Err = segment.Halted, segment.Reason, segment.Err
+		report.MConfiguration, report.Head = segment.MConfiguration, segment.Head
+		if segment.Halted {
+			break
+		}
+	}
+	r.update(m, 0, false)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.stopReasons[stopReasonLabel(report)]++
+	return report
+}
+
+// Adds the moves of the machine, and records whether it is running and the size of its tape
+func (r *RunMonitor) update(m *Machine, moves int, running bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.moves += moves
+	r.maxSquares = max(r.maxSquares, len(m.tape))
+	if running {
+		r.running[m] = len(m.tape)
+	} else {
+		delete(r.running, m)
+	}
+}
+
+// Writes the counts in the Prometheus text format
+func (r *RunMonitor) WriteMetrics(w io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	squares := 0
+	for _, machineSquares := range r.running {
+		squares += machineSquares
+	}
+	reasons := []string{}
+	for reason := range r.stopReasons {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+
+	metrics := fmt.Sprintf("# HELP turing_moves_total Moves made by machines.\n"+
+		"# TYPE turing_moves_total counter\nturing_moves_total %d\n"+
+		"# HELP turing_machines_running Machines currently running.\n"+
+		"# TYPE turing_machines_running gauge\nturing_machines_running %d\n"+
+		"# HELP turing_tape_squares Squares of the tapes of the machines currently running.\n"+
+		"# TYPE turing_tape_squares gauge\nturing_tape_squares %d\n"+
+		"# HELP turing_tape_squares_max Squares of the largest tape of any machine run.\n"+
+		"# TYPE turing_tape_squares_max gauge\nturing_tape_squares_max %d\n"+
+		"# HELP turing_runs_stopped_total Runs stopped, by reason.\n"+
+		"# TYPE turing_runs_stopped_total counter\n",
+		r.moves, len(r.running), squares, r.maxSquares)
+	for _, reason := range reasons {
+		metrics += fmt.Sprintf("turing_runs_stopped_total{reason=%q} %d\n", reason, r.stopReasons[reason])
+	}
+	_, err := io.WriteString(w, metrics)
+	return err
+}
+
+func (r *RunMonitor) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	r.WriteMetrics(w)
+}
+
+// Returns why the run stopped, distinguishing the errors the machine may record
+func stopReasonLabel(report MoveReport) string {
+	if report.Reason != StoppedOnError {
+		return report.Reason.String()
+	}
+	for _, err := range []error{ErrNoMatchingConfiguration, ErrInvalidOperation, ErrTapeLimit} {
+		if errors.Is(report.Err, err) {
+			return err.Error()
+		}
+	}
+	return report.Reason.String()
+}
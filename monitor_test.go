@@ -0,0 +1,65 @@
+package turing
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunMonitor(t *testing.T) {
+	monitor := NewRunMonitor()
+
+	// Prints 0's forever
+	report := monitor.Run(NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "b"},
+		},
+	}), 2500)
+	if report.Moves != 2500 || report.Reason != StoppedAtMoveLimit {
+		t.Errorf("got %+v", report)
+	}
+
+	// Halts after a single move
+	monitor.Run(NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "halt"},
+		},
+	}), 2500)
+
+	// Runs out of tape
+	monitor.Run(NewMachine(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "b"},
+		},
+		MaxTapeSquares: 10,
+	}), 2500)
+
+	server := httptest.NewServer(monitor)
+	defer server.Close()
+	response, err := server.Client().Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != prometheusContentType {
+		t.Errorf("got content type %q", contentType)
+	}
+	for _, expected := range []string{
+		"\nturing_moves_total 2511\n",
+		"\nturing_machines_running 0\n",
+		"\nturing_tape_squares 0\n",
+		"\nturing_tape_squares_max 2500\n",
+		"\nturing_runs_stopped_total{reason=\"halted\"} 1\n",
+		"\nturing_runs_stopped_total{reason=\"move limit\"} 1\n",
+		"\nturing_runs_stopped_total{reason=\"tape limit reached\"} 1\n",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("got %s, want to contain %q", body, expected)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package turing
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// Runs machines with a simulator for the program format of Anthony Morphett's online Turing machine
+	// simulator (see `MorphettProgram`). The program is written to the simulator's standard input, and
+	// the initial tape (in Morphett's form, with `*` before the scanned square) and the most moves to
+	// make are given as its last two arguments. The simulator must write the outcome as lines of the
+	// form `state <state>`, `steps <moves>`, `head <square relative to the one originally scanned>`,
+	// `halted <true or false>` and `tape <tape>`.
+	//
+	// Morphett's own simulator runs in a web page, so it cannot be driven this way. The package's tests
+	// drive `testdata/morphett.js`, a reference reimplementation of the semantics his documentation
+	// describes, written for them: they check the translation to his format and this adapter, not that
+	// the package agrees with his implementation.
+	MorphettSimulator struct {
+		// The program to run
+		Path string
+
+		// The program's arguments (before the tape and the most moves)
+		Args []string
+	}
+
+	// A machine translated to Morphett's format, with the names of its m-configurations and symbols
+	morphettTranslation struct {
+		program string
+		tape    string
+
+		// The m-configuration named by each of Morphett's states
+		mConfigurations map[string]string
+
+		// The symbol named by each of Morphett's symbols
+		symbols map[string]string
+	}
+)
+
+const (
+	// Morphett's blank symbol
+	morphettBlank string = "_"
+
+	// Morphett's wildcard, which is also written before the scanned square of the initial tape
+	morphettWildcard string = "*"
+
+	// Morphett's machines halt upon moving to a state beginning with this
+	morphettHalt string = "halt"
+
+	// Used for symbols that are not single characters Morphett can read
+	morphettSymbols string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// Returns the machine as a program for Anthony Morphett's Turing machine simulator: a line of the form
+// `<state> <symbol> <new symbol> <l, r or *> <new state>` for each transition (see
+// `MachineInput.Transitions`), so rows making more than a write and a move are split. The starting
+// m-configuration becomes state `0`, the others `1`, `2`, and so on, and `halt` and the
+// HaltingMConfigurations become `halt`, `halt-1`, `halt-2`, and so on. Symbols other than single
+// characters are renamed. Returns an error wrapping `ErrUnsupportedMachine` if the machine cannot be
+// expressed in the format (it has a BackgroundPattern, a LeftBound or a MaxTapeSquares).
+func MorphettProgram(input MachineInput) (string, error) {
+	translation, err := translateToMorphett(input)
+	return translation.program, err
+}
+
+func (s MorphettSimulator) Simulate(input MachineInput, maxMoves int) (Simulation, error) {
+	translation, err := translateToMorphett(input)
+	if err != nil {
+		return Simulation{}, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.Path, append(slices.Clone(s.Args), translation.tape, strconv.Itoa(maxMoves))...)
+	cmd.Stdin = strings.NewReader(translation.program)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Simulation{}, fmt.Errorf("%s: %w: %s", s.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	simulation := Simulation{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		switch key {
+		case "state":
+			mConfiguration, ok := translation.mConfigurations[value]
+			if !ok {
+				return Simulation{}, fmt.Errorf("%s: unknown state %q", s.Path, value)
+			}
+			simulation.MConfiguration = mConfiguration
+		case "steps":
+			simulation.Moves, err = strconv.Atoi(value)
+		case "head":
+			simulation.Head, err = strconv.Atoi(value)
+		case "halted":
+			simulation.Halted, err = strconv.ParseBool(value)
+		case "tape":
+			var tape strings.Builder
+			for _, char := range value {
+				symbol, ok := translation.symbols[string(char)]
+				if !ok {
+					return Simulation{}, fmt.Errorf("%s: unknown symbol %q", s.Path, char)
+				}
+				tape.WriteString(symbol)
+			}
+			simulation.Tape = tape.String()
+		}
+		if err != nil {
+			return Simulation{}, fmt.Errorf("%s: %w", s.Path, err)
+		}
+	}
+	return simulation, nil
+}
+
+// Translates the machine to Morphett's format
+func translateToMorphett(input MachineInput) (morphettTranslation, error) {
+	switch {
+	case len(input.BackgroundPattern) != 0:
+		return morphettTranslation{}, fmt.Errorf("%w: Morphett's tapes are blank", ErrUnsupportedMachine)
+	case input.LeftBound != UnboundedTape:
+		return morphettTranslation{}, fmt.Errorf("%w: Morphett's tapes are unbounded", ErrUnsupportedMachine)
+	case input.MaxTapeSquares > 0:
+		return morphettTranslation{}, fmt.Errorf("%w: Morphett's tapes are unbounded", ErrUnsupportedMachine)
+	}
+	m := NewMachine(input)
+	translation := morphettTranslation{
+		mConfigurations: map[string]string{},
+		symbols:         map[string]string{morphettBlank: m.noneSymbol},
+	}
+
+	// Single characters Morphett reads as themselves keep their names
+	symbolNames := map[string]string{m.noneSymbol: morphettBlank}
+	symbols := machineSymbols(input)
+	for _, symbol := range symbols {
+		if utf8.RuneCountInString(symbol) == 1 && !strings.ContainsAny(symbol, morphettBlank+morphettWildcard+"; \t") {
+			symbolNames[symbol] = symbol
+			translation.symbols[symbol] = symbol
+		}
+	}
+	unused := strings.Split(morphettSymbols, "")
+	for _, symbol := range symbols {
+		if _, ok := symbolNames[symbol]; ok {
+			continue
+		}
+		unused = slices.DeleteFunc(unused, func(name string) bool {
+			_, taken := translation.symbols[name]
+			return taken
+		})
+		if len(unused) == 0 {
+			return morphettTranslation{}, fmt.Errorf("%w: too many symbols", ErrUnsupportedMachine)
+		}
+		symbolNames[symbol] = unused[0]
+		translation.symbols[unused[0]] = symbol
+	}
+
+	stateNames := map[string]string{}
+	states := 0
+	name := func(mConfiguration string) string {
+		if stateName, ok := stateNames[mConfiguration]; ok {
+			return stateName
+		}
+		stateName := strconv.Itoa(states)
+		if mConfiguration == haltMConfigurationName {
+			stateName = morphettHalt
+		} else if i := slices.Index(input.HaltingMConfigurations, mConfiguration); i >= 0 {
+			stateName = morphettHalt + "-" + strconv.Itoa(i+1)
+		} else {
+			states++
+		}
+		stateNames[mConfiguration] = stateName
+		translation.mConfigurations[stateName] = mConfiguration
+		return stateName
+	}
+	name(startingMConfigurationName(input))
+
+	var program strings.Builder
+	for _, transition := range input.Transitions() {
+		move := strings.ToLower(transition.Move)
+		if transition.Move == string(noOp) {
+			move = morphettWildcard
+		}
+		fmt.Fprintf(&program, "%s %s %s %s %s\n", name(transition.State), symbolNames[transition.Read],
+			symbolNames[transition.Write], move, name(transition.Next))
+	}
+	translation.program = program.String()
+
+	var tape strings.Builder
+	for i, square := range m.tape {
+		if i == m.origin {
+			tape.WriteString(morphettWildcard)
+		}
+		tape.WriteString(symbolNames[square])
+	}
+	if m.origin >= len(m.tape) {
+		tape.WriteString(morphettWildcard + morphettBlank)
+	}
+	translation.tape = tape.String()
+	return translation, nil
+}
@@ -0,0 +1,80 @@
+//go:build morphett
+
+// Runs the reference reimplementation of Morphett's simulator (see `MorphettSimulator`), which needs
+// Node.js: go test -tags morphett
+
+package turing
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestMorphettSimulator(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Fatal("node is needed to run the reference simulator")
+	}
+	morphett := MorphettSimulator{Path: node, Args: []string{"testdata/morphett.js"}}
+
+	example2 := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+			{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+			{"o", []string{"0"}, []string{}, "q"},
+			{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+			{"q", []string{" "}, []string{"P1", "L"}, "p"},
+			{"p", []string{"x"}, []string{"E", "R"}, "q"},
+			{"p", []string{"e"}, []string{"R"}, "f"},
+			{"p", []string{" "}, []string{"L", "L"}, "p"},
+			{"f", []string{"*"}, []string{"R", "R"}, "f"},
+			{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+		},
+		PossibleSymbols: []string{"0", "1", "e", "x"},
+	}
+	for name, machine := range map[string]struct {
+		input    MachineInput
+		maxMoves int
+	}{
+		"Example1": {simulatorExample, 10},
+		// Morphett's format has no rows of many operations, so the machine is compared in 5-tuple form
+		"Example2": {MachineInput{
+			MConfigurations: MConfigurationsFromTransitions(example2.Transitions()),
+			PossibleSymbols: example2.PossibleSymbols,
+		}, 500},
+		"Halts": {MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"S0"}, []string{"PS1", "R"}, "c"},
+				{"b", []string{"S1"}, []string{"PS1", "L"}, "c"},
+				{"c", []string{"S0"}, []string{"PS1", "L"}, "b"},
+				{"c", []string{"S1"}, []string{"PS1", "R"}, "done"},
+			},
+			Tape:                   Tape{"S1", "S0", "S0"},
+			StartingSquare:         1,
+			NoneSymbol:             "S0",
+			HaltingMConfigurations: []string{"done"},
+		}, 100},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := CompareSimulators(NativeSimulator{}, morphett, machine.input, machine.maxMoves); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// A simulator disagreeing with Morphett's semantics is caught
+	program := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P1", "R"}, "b"},
+		},
+	}
+	faulty := SimulatorFunc(func(input MachineInput, maxMoves int) (Simulation, error) {
+		simulation, err := NativeSimulator{}.Simulate(input, maxMoves)
+		simulation.Head--
+		return simulation, err
+	})
+	if err := CompareSimulators(faulty, morphett, program, 5); !errors.Is(err, ErrSimulatorMismatch) {
+		t.Errorf("got %v, want %v", err, ErrSimulatorMismatch)
+	}
+}
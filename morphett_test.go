@@ -0,0 +1,31 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestMorphettProgram(t *testing.T) {
+	program, err := MorphettProgram(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P::", "R"}, "c"},
+			{"c", []string{" "}, []string{"L"}, "d"},
+			{"d", []string{"::"}, []string{"N"}, "halt"},
+		},
+		HaltingMConfigurations: []string{"d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	turingtest.Equal(t, program, "0 _ a r 1\n1 _ _ l halt-1\nhalt-1 a a * halt\n")
+
+	_, err = MorphettProgram(MachineInput{
+		MConfigurations:   simulatorExample.MConfigurations,
+		BackgroundPattern: []string{"0", "1"},
+	})
+	if !errors.Is(err, ErrUnsupportedMachine) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedMachine)
+	}
+}
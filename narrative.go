@@ -0,0 +1,128 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Returns a description in markdown of what the machine does in each m-configuration, row by row, for
+// reviewing large (i.e. compiled) tables or for teaching. If a source map is provided (see
+// `CompileAbbreviatedTableWithSourceMap`), each m-configuration is also described by the m-function call
+// it was compiled from, and Turing's description of the m-function if it is one of `StandardSkeletons`.
+func DescribeTable(input MachineInput, sourceMap SourceMap) string {
+	var s strings.Builder
+	names := mConfigurationNames(input)
+	starting := startingMConfigurationName(input)
+
+	s.WriteString(fmt.Sprintf("The machine has %d m-configurations and starts in %s.\n", len(names), describeMConfiguration(starting, sourceMap)))
+	for _, name := range names {
+		s.WriteString(fmt.Sprintf("\n## %s\n\n", name))
+		if call, ok := sourceMap[name]; ok {
+			s.WriteString(fmt.Sprintf("Compiled from `%s`.", call))
+			if description, ok := skeletonDescription(call); ok {
+				s.WriteString(" " + description)
+			}
+			s.WriteString("\n\n")
+		}
+		if slices.Contains(input.HaltingMConfigurations, name) {
+			s.WriteString("The machine halts upon reaching it.\n\n")
+		}
+		for _, mConfiguration := range input.MConfigurations {
+			if mConfiguration.Name != name {
+				continue
+			}
+			s.WriteString(fmt.Sprintf("- %s, %s.\n", describeSymbols(mConfiguration.Symbols), describeRow(mConfiguration, sourceMap)))
+		}
+	}
+
+	// The m-configurations moved to without rows of their own halt the machine
+	undefined := []string{}
+	for _, mConfiguration := range input.MConfigurations {
+		final := mConfiguration.FinalMConfiguration
+		if !slices.Contains(names, final) && !slices.Contains(undefined, final) {
+			undefined = append(undefined, final)
+		}
+	}
+	for _, name := range undefined {
+		s.WriteString(fmt.Sprintf("\n## %s\n\n", name))
+		if call, ok := sourceMap[name]; ok {
+			s.WriteString(fmt.Sprintf("Compiled from `%s`. ", call))
+		}
+		s.WriteString("Has no rows, so the machine halts upon reaching it.\n")
+	}
+	return s.String()
+}
+
+// Returns Turing's description of the m-function called, if it is one of `StandardSkeletons`
+func skeletonDescription(call string) (string, bool) {
+	name, params := parseMFunction(call)
+	for _, function := range standardSkeletonFunctions {
+		if function.Name == name && function.Arity == len(params) {
+			return function.Description, true
+		}
+	}
+	return "", false
+}
+
+// Returns the m-configuration, followed by the m-function call it was compiled from if known
+func describeMConfiguration(name string, sourceMap SourceMap) string {
+	if call, ok := sourceMap[name]; ok {
+		return fmt.Sprintf("`%s` (`%s`)", name, call)
+	}
+	return fmt.Sprintf("`%s`", name)
+}
+
+// Returns when the row is taken, i.e. "On `0` or a blank square"
+func describeSymbols(symbols []string) string {
+	if len(symbols) == 0 {
+		return "On any square"
+	}
+	described := []string{}
+	for _, symbol := range symbols {
+		switch {
+		case symbol == none:
+			described = append(described, "a blank square")
+		case symbol == any:
+			described = append(described, "any symbol")
+		case strings.HasPrefix(symbol, not):
+			described = append(described, fmt.Sprintf("any symbol other than `%s`", symbol[len(not):]))
+		default:
+			described = append(described, fmt.Sprintf("`%s`", symbol))
+		}
+	}
+	return "On " + strings.Join(described, " or ")
+}
+
+// Returns what the row does, i.e. "prints `0`, moves right and goes to `c`"
+func describeRow(mConfiguration MConfiguration, sourceMap SourceMap) string {
+	actions := []string{}
+	for _, operation := range mConfiguration.Operations {
+		if len(operation) == 0 {
+			continue
+		}
+		switch operationCode(operation[0]) {
+		case rightOp:
+			actions = append(actions, "moves right")
+		case leftOp:
+			actions = append(actions, "moves left")
+		case eraseOp:
+			actions = append(actions, "erases the square")
+		case printOp:
+			actions = append(actions, fmt.Sprintf("prints `%s`", operation[1:]))
+		case noOp:
+			actions = append(actions, "does nothing")
+		case haltOp:
+			actions = append(actions, "halts")
+		}
+	}
+	if mConfiguration.FinalMConfiguration == mConfiguration.Name {
+		actions = append(actions, "stays in this m-configuration")
+	} else {
+		actions = append(actions, "goes to "+describeMConfiguration(mConfiguration.FinalMConfiguration, sourceMap))
+	}
+	if len(actions) == 1 {
+		return actions[0]
+	}
+	return strings.Join(actions[:len(actions)-1], ", ") + " and " + actions[len(actions)-1]
+}
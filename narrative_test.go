@@ -0,0 +1,49 @@
+package turing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestDescribeTable(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{"0", "!1"}, []string{"R"}, "c"},
+			{"c", []string{}, []string{}, "halt"},
+		},
+	}
+	turingtest.Equal(t, DescribeTable(input, nil), "The machine has 2 m-configurations and starts in `b`.\n"+
+		"\n## b\n\n"+
+		"- On a blank square, prints `0`, moves right and goes to `c`.\n"+
+		"\n## c\n\n"+
+		"- On `0` or any symbol other than `1`, moves right and stays in this m-configuration.\n"+
+		"- On any square, goes to `halt`.\n"+
+		"\n## halt\n\nHas no rows, so the machine halts upon reaching it.\n")
+
+	t.Run("SourceMap", func(t *testing.T) {
+		compiled, sourceMap, err := CompileAbbreviatedTableWithSourceMap(AbbreviatedTableInput{
+			MConfigurations: append([]MConfiguration{
+				{"b", []string{"*", " "}, []string{}, "pe(halt, 1)"},
+			}, StandardSkeletons()...),
+			PossibleSymbols: []string{"e", "1"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		turingtest.Equal(t, sourceMap[startingMConfigurationName(compiled)], "b")
+		description := DescribeTable(compiled, sourceMap)
+		for _, expected := range []string{
+			"Compiled from `pe(halt,1)`. Prints `b` at the end of the sequence of symbols -> `C`.",
+			"Compiled from `f(pe1(halt,1),halt,e)`. Finds the first (leftmost) `a` -> `C`.",
+			"goes to `q3` (`f1(pe1(halt,1),halt,e)`)",
+			"Compiled from `halt`. Has no rows, so the machine halts upon reaching it.",
+		} {
+			if !strings.Contains(description, expected) {
+				t.Errorf("got\n%s\nwant it to contain %q", description, expected)
+			}
+		}
+	})
+}
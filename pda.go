@@ -0,0 +1,194 @@
+package turing
+
+import "slices"
+
+type (
+	// A deterministic pushdown automaton. When more than one transition applies, the first
+	// listed is taken.
+	PDA struct {
+		// The states of the automaton
+		States []string
+
+		// The symbols the automaton reads
+		Alphabet []string
+
+		// The symbols the automaton keeps on its stack
+		StackAlphabet []string
+
+		// The starting state (the stack starts empty)
+		Start string
+
+		// The word is accepted if the automaton is in one of these states once it has read the whole word
+		Accept []string
+
+		// The moves of the automaton. If no transition applies the word is rejected.
+		Transitions []PDATransition
+	}
+
+	// A single move of a pushdown automaton
+	PDATransition struct {
+		// The state the move applies to
+		From string
+
+		// The input symbol that is read. If empty the move does not read input.
+		Input string
+
+		// The symbol that must be on top of the stack, and is popped. If empty the stack is not inspected.
+		Pop string
+
+		// The symbols pushed onto the stack, the last ending up on top
+		Push []string
+
+		// The state moved to
+		To string
+	}
+
+	// Struct to hold shared values when compiling a PDA
+	pdaCompiler struct {
+		pda             PDA
+		mConfigurations []MConfiguration
+		bottom          string
+		consumed        string
+		accept          string
+		reject          string
+		seeking         []string
+	}
+)
+
+const (
+	pdaBottomSymbol           string = "#"
+	pdaConsumedSymbol         string = "x"
+	pdaInitMConfigurationName string = "init"
+)
+
+// Compiles the automaton into a decider. The stack is kept on the tape to the left of the
+// input (separated by a bottom marker, with the top of the stack furthest left), and each
+// input square is marked once it has been read. For every move the machine scans the next
+// input square, walks left to the top of the stack, performs the move, and walks back.
+func (pda PDA) Compile() DeciderInput {
+	symbols := append(slices.Clone(pda.Alphabet), pda.StackAlphabet...)
+	names := append(slices.Clone(pda.States), pdaInitMConfigurationName)
+	c := &pdaCompiler{
+		pda:             pda,
+		mConfigurations: []MConfiguration{},
+		bottom:          uniqueName(pdaBottomSymbol, symbols),
+		consumed:        uniqueName(pdaConsumedSymbol, symbols),
+		accept:          uniqueName(dfaAcceptMConfigurationName, names),
+		reject:          uniqueName(dfaRejectMConfigurationName, names),
+		seeking:         []string{},
+	}
+	init := uniqueName(pdaInitMConfigurationName, pda.States)
+
+	// Place the bottom marker just left of the input
+	c.add(init, append(slices.Clone(pda.Alphabet), none), []string{string(leftOp), string(printOp) + c.bottom, string(rightOp)}, pda.Start)
+
+	for _, state := range pda.States {
+		c.compileState(state)
+	}
+
+	return DeciderInput{
+		MachineInput: MachineInput{
+			MConfigurations:        c.mConfigurations,
+			StartingMConfiguration: init,
+			PossibleSymbols:        append(symbols, c.bottom, c.consumed),
+		},
+		AcceptMConfigurations: []string{c.accept},
+		RejectMConfigurations: []string{c.reject},
+	}
+}
+
+// Adds the m-configurations for a state. The machine is scanning the next input square.
+func (c *pdaCompiler) compileState(state string) {
+	inputs := append(slices.Clone(c.pda.Alphabet), none)
+	for _, input := range inputs {
+		if input == none && slices.Contains(c.pda.Accept, state) {
+			c.add(state, []string{none}, []string{}, c.accept)
+			continue
+		}
+
+		// Walk left to the top of the stack (or the bottom marker if it is empty)
+		left := state + "/" + input
+		c.add(state, []string{input}, []string{string(leftOp)}, left)
+		c.add(left, []string{none}, []string{string(rightOp)}, left+":")
+		c.add(left, c.nonBlank(), []string{string(leftOp)}, left)
+
+		// Perform the move depending on the top of the stack
+		for _, top := range append(slices.Clone(c.pda.StackAlphabet), c.bottom) {
+			transition, ok := c.transition(state, input, top)
+			if !ok {
+				c.add(left+":", []string{top}, []string{}, c.reject)
+				continue
+			}
+			c.add(left+":", []string{top}, c.stackOperations(transition), c.seek(transition.To, len(transition.Input) != 0))
+		}
+	}
+}
+
+// Returns the first transition that applies
+func (c *pdaCompiler) transition(state string, input string, top string) (PDATransition, bool) {
+	for _, transition := range c.pda.Transitions {
+		if transition.From != state {
+			continue
+		}
+		if len(transition.Input) != 0 && transition.Input != input {
+			continue
+		}
+		if len(transition.Pop) != 0 && transition.Pop != top {
+			continue
+		}
+		return transition, true
+	}
+	return PDATransition{}, false
+}
+
+// Returns the operations that pop and push, with the machine scanning the top of the stack
+func (c *pdaCompiler) stackOperations(transition PDATransition) []string {
+	operations := []string{}
+	if len(transition.Pop) != 0 {
+		operations = append(operations, string(eraseOp))
+	}
+	for i, symbol := range transition.Push {
+		if i > 0 || len(transition.Pop) == 0 {
+			operations = append(operations, string(leftOp))
+		}
+		operations = append(operations, string(printOp)+symbol)
+	}
+	return operations
+}
+
+// Returns the m-configuration that walks right to the next input square (marking it if
+// `consume`) and then moves to the state, adding it if needed.
+func (c *pdaCompiler) seek(state string, consume bool) string {
+	name := state + "<"
+	if consume {
+		name += c.consumed
+	}
+	if slices.Contains(c.seeking, name) {
+		return name
+	}
+	c.seeking = append(c.seeking, name)
+
+	// Walk right past the stack and the bottom marker
+	c.add(name, []string{c.bottom}, []string{string(rightOp)}, name+c.bottom)
+	c.add(name, append(slices.Clone(c.pda.StackAlphabet), none), []string{string(rightOp)}, name)
+
+	// Walk right past the input already read
+	c.add(name+c.bottom, []string{c.consumed}, []string{string(rightOp)}, name+c.bottom)
+	if consume {
+		c.add(name+c.bottom, c.pda.Alphabet, []string{string(printOp) + c.consumed, string(rightOp)}, state)
+	} else {
+		c.add(name+c.bottom, append(slices.Clone(c.pda.Alphabet), none), []string{}, state)
+	}
+	return name
+}
+
+// Returns every symbol apart from ` ` (None)
+func (c *pdaCompiler) nonBlank() []string {
+	symbols := append(slices.Clone(c.pda.Alphabet), c.pda.StackAlphabet...)
+	return append(symbols, c.bottom, c.consumed)
+}
+
+// Adds an m-configuration
+func (c *pdaCompiler) add(name string, symbols []string, operations []string, finalMConfiguration string) {
+	c.mConfigurations = append(c.mConfigurations, MConfiguration{name, symbols, operations, finalMConfiguration})
+}
@@ -0,0 +1,72 @@
+package turing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+// Recognizes {0^n 1^n}, using Z to mark the bottom of the stack
+var zerosThenOnesPDA = PDA{
+	States:        []string{"s", "p", "q", "f"},
+	Alphabet:      []string{"0", "1"},
+	StackAlphabet: []string{"A", "Z"},
+	Start:         "s",
+	Accept:        []string{"f"},
+	Transitions: []PDATransition{
+		{From: "s", Push: []string{"Z"}, To: "p"},
+		{From: "p", Input: "0", Push: []string{"A"}, To: "p"},
+		{From: "p", Input: "1", Pop: "A", To: "q"},
+		{From: "p", Pop: "Z", To: "f"},
+		{From: "q", Input: "1", Pop: "A", To: "q"},
+		{From: "q", Pop: "Z", To: "f"},
+	},
+}
+
+func TestPDACompile(t *testing.T) {
+	decider := zerosThenOnesPDA.Compile()
+	for word, expected := range map[string]bool{
+		"":       true,
+		"01":     true,
+		"0011":   true,
+		"000111": true,
+		"0":      false,
+		"1":      false,
+		"10":     false,
+		"001":    false,
+		"011":    false,
+		"0101":   false,
+	} {
+		t.Run(word, func(t *testing.T) {
+			result, err := decider.RunWord(word, WordOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Accepted != expected {
+				t.Errorf("got %s, want accepted to be %t", result.Decision, expected)
+			}
+		})
+	}
+}
+
+func TestPDAPushMultiple(t *testing.T) {
+	// Pushes two symbols for every 0, and pops one for every 1
+	decider := PDA{
+		States:        []string{"p", "q"},
+		Alphabet:      []string{"0", "1"},
+		StackAlphabet: []string{"A", "B"},
+		Start:         "p",
+		Accept:        []string{"q"},
+		Transitions: []PDATransition{
+			{From: "p", Input: "0", Push: []string{"A", "B"}, To: "p"},
+			{From: "p", Input: "1", Pop: "B", To: "q"},
+			{From: "q", Input: "1", Pop: "A", Push: []string{"A"}, To: "q"},
+		},
+	}.Compile()
+	result, _ := decider.RunWord("0011", WordOptions{})
+	if !result.Accepted {
+		t.Errorf("got %s, want accept", result.Decision)
+	}
+	turingtest.TapePrefix(t, strings.TrimSpace(strings.Join(result.Tape, "")), "ABA#xxxx")
+}
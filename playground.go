@@ -0,0 +1,505 @@
+package turing
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type (
+	// Serves a page for driving and watching a machine from a browser. The page connects back over a
+	// websocket (at the same path), on which it sends commands (see `PlaygroundCommand`) and is sent an
+	// event after every move (see `PlaygroundEvent`), so any other websocket client may drive it too.
+	// Each connection runs its own machine.
+	Playground struct {
+		// The machine each connection runs
+		Input MachineInput
+
+		// If positive, the most moves made each second while running (see `Runner`)
+		MovesPerSecond float64
+
+		// The amount of moves a `run` command makes before pausing. Defaults to 1000000.
+		MaxMoves int
+
+		// If provided, a connection runs the machine of the registry named by the `machine` query
+		// parameter (i.e. `?machine=bb2-champion`) rather than `Input`
+		Registry *MachineRegistry
+
+		// The origins (i.e. `https://example.com`) of other sites whose pages may connect. Browsers send
+		// the origin of the page connecting, and pages served from another host are refused unless listed,
+		// so other sites cannot drive the playground with the visitor's credentials.
+		AllowedOrigins []string
+	}
+
+	// A command sent to a Playground
+	PlaygroundCommand struct {
+		// One of `step`, `run`, `pause`, `reset` or `breakpoints`
+		Command string `json:"command"`
+
+		// The amount of moves a `step` makes (defaults to one, and at most 1000)
+		Count int `json:"count,omitempty"`
+
+		// The breakpoints `breakpoints` sets, replacing those set before
+		Breakpoints []PlaygroundBreakpoint `json:"breakpoints,omitempty"`
+	}
+
+	// Pauses a running machine when it moves to the m-configuration, if the condition holds
+	// (see `BreakpointExpression`)
+	PlaygroundBreakpoint struct {
+		MConfiguration string `json:"mConfiguration"`
+		Condition      string `json:"condition,omitempty"`
+	}
+
+	// An event sent by a Playground, with the state of the machine
+	PlaygroundEvent struct {
+		// One of `state` (on connecting or a `reset`), `move`, `stopped`, `halted`, `breakpoints` or `error`
+		Event string `json:"event"`
+
+		MachineSnapshot
+
+		// Why the machine stopped: `step`, `pause` or `breakpoint`
+		Reason string `json:"reason,omitempty"`
+
+		// The error the machine halted with, or the command failed with
+		Message string `json:"message,omitempty"`
+
+		// Whether each of the breakpoints was set: its m-configuration is defined and condition compiles
+		Verified []bool `json:"verified,omitempty"`
+	}
+
+	// A connection to the Playground, running its own machine
+	playgroundSession struct {
+		playground  *Playground
+		input       MachineInput
+		conn        *websocketConn
+		machine     *Machine
+		breakpoints []dapBreakpoint
+		cancel      context.CancelFunc
+		done        chan struct{}
+	}
+
+	// The server's end of a websocket connection (RFC 6455). Writes are safe for concurrent use.
+	websocketConn struct {
+		conn   net.Conn
+		reader *bufio.Reader
+		mutex  sync.Mutex
+
+		// Whether frames read must be masked, as every frame a client sends must be
+		masked bool
+	}
+)
+
+const (
+	defaultPlaygroundMaxMoves   int = 1000000
+	playgroundMaxStepCount      int = 1000
+	playgroundStoppedStep           = "step"
+	playgroundStoppedPause          = "pause"
+	playgroundStoppedBreakpoint     = "breakpoint"
+
+	websocketGUID                     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	websocketMaxMessage        uint64 = 1 << 20
+	websocketText              byte   = 0x1
+	websocketClose             byte   = 0x8
+	websocketPing              byte   = 0x9
+	websocketPong              byte   = 0xA
+	websocketFinal             byte   = 0x80
+	websocketMasked            byte   = 0x80
+	websocketLength16          byte   = 126
+	websocketLength64          byte   = 127
+	websocketMaxUnextendedSize int    = 125
+)
+
+// Returns a playground running the machine at most `movesPerSecond` moves each second (see `MovesPerSecond`)
+func NewPlayground(input MachineInput, movesPerSecond float64) *Playground {
+	return &Playground{
+		Input:          input,
+		MovesPerSecond: movesPerSecond,
+	}
+}
+
+// Serves the page, or the websocket if the request asks to upgrade to one
+func (p *Playground) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	input := p.Input
+	if name := req.URL.Query().Get("machine"); len(name) != 0 && p.Registry != nil {
+		machine, err := p.Registry.Lookup(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		input = machine.Input
+	}
+	if !strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, playgroundPage)
+		return
+	}
+	if !p.allowsOrigin(req) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	conn, err := acceptWebsocket(w, req)
+	if err != nil {
+		return
+	}
+	defer conn.conn.Close()
+
+	session := &playgroundSession{
+		playground:  p,
+		input:       input,
+		conn:        conn,
+		machine:     NewMachine(input),
+		breakpoints: []dapBreakpoint{},
+	}
+	session.send(PlaygroundEvent{Event: "state"})
+	session.serve()
+}
+
+// Returns true if the request has no origin (it is not from a browser), or comes from a page served
+// by the playground's own host or one of the AllowedOrigins
+func (p *Playground) allowsOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if len(origin) == 0 || slices.Contains(p.AllowedOrigins, origin) {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, req.Host)
+}
+
+// Handles commands until the connection is closed
+func (s *playgroundSession) serve() {
+	defer s.pause()
+	for {
+		message, err := s.conn.read()
+		if err != nil {
+			return
+		}
+		var command PlaygroundCommand
+		if err := json.Unmarshal(message, &command); err != nil {
+			s.pause()
+			s.send(PlaygroundEvent{Event: "error", Message: err.Error()})
+			continue
+		}
+		s.handle(command)
+	}
+}
+
+// Handles a command. Only `run` moves the machine in the background, and every other command pauses it first.
+func (s *playgroundSession) handle(command PlaygroundCommand) {
+	running := s.running()
+	if command.Command != "run" || !running {
+		s.pause()
+	}
+
+	switch command.Command {
+	case "step":
+		if command.Count > playgroundMaxStepCount {
+			s.send(PlaygroundEvent{Event: "error", Message: fmt.Sprintf("step count %d is more than %d", command.Count, playgroundMaxStepCount)})
+			return
+		}
+		for range max(command.Count, 1) {
+			if s.machine.halted {
+				break
+			}
+			s.machine.Move()
+			s.send(PlaygroundEvent{Event: "move"})
+		}
+		s.stopped(playgroundStoppedStep)
+	case "run":
+		if !running {
+			s.run()
+		}
+	case "pause":
+		// A running machine reports that it stopped itself
+		if !running {
+			s.stopped(playgroundStoppedPause)
+		}
+	case "reset":
+		s.machine = NewMachine(s.input)
+		s.send(PlaygroundEvent{Event: "state"})
+	case "breakpoints":
+		s.setBreakpoints(command.Breakpoints)
+	default:
+		s.send(PlaygroundEvent{Event: "error", Message: fmt.Sprintf("unsupported command %s", command.Command)})
+	}
+}
+
+// Moves the machine in the background until it halts, reaches a breakpoint or is paused
+func (s *playgroundSession) run() {
+	maxMoves := s.playground.MaxMoves
+	if maxMoves <= 0 {
+		maxMoves = defaultPlaygroundMaxMoves
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+
+	reason := playgroundStoppedPause
+	runner := NewRunner(s.machine, s.playground.MovesPerSecond)
+	runner.OnMove = func(m *Machine) {
+		s.send(PlaygroundEvent{Event: "move"})
+		if atBreakpoint(m, s.breakpoints) {
+			reason = playgroundStoppedBreakpoint
+			cancel()
+		}
+	}
+	go func() {
+		defer close(s.done)
+		runner.Run(ctx, maxMoves)
+		s.stopped(reason)
+	}()
+}
+
+// Returns true if the machine is moving in the background
+func (s *playgroundSession) running() bool {
+	if s.done == nil {
+		return false
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Stops the machine running in the background (if it is), waiting until it has stopped
+func (s *playgroundSession) pause() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.cancel, s.done = nil, nil
+}
+
+// Sends a `halted` event if the machine halted, or a `stopped` event
+func (s *playgroundSession) stopped(reason string) {
+	if s.machine.halted {
+		event := PlaygroundEvent{Event: "halted"}
+		if s.machine.err != nil {
+			event.Message = s.machine.err.Error()
+		}
+		s.send(event)
+		return
+	}
+	s.send(PlaygroundEvent{Event: "stopped", Reason: reason})
+}
+
+// Replaces the breakpoints with those whose m-configuration is defined and condition compiles
+func (s *playgroundSession) setBreakpoints(breakpoints []PlaygroundBreakpoint) {
+	set := []dapBreakpoint{}
+	verified := []bool{}
+	for _, breakpoint := range breakpoints {
+		defined := slices.ContainsFunc(s.input.MConfigurations, func(mConfiguration MConfiguration) bool {
+			return mConfiguration.Name == breakpoint.MConfiguration
+		})
+		var condition *BreakpointExpression
+		var err error
+		if len(breakpoint.Condition) != 0 {
+			condition, err = CompileBreakpoint(breakpoint.Condition)
+		}
+		if defined && err == nil {
+			set = append(set, dapBreakpoint{name: breakpoint.MConfiguration, condition: condition})
+		}
+		verified = append(verified, defined && err == nil)
+	}
+	s.breakpoints = set
+	s.send(PlaygroundEvent{Event: "breakpoints", Verified: verified})
+}
+
+// Sends the event with the state of the machine. Write errors are noticed when the next command is read.
+func (s *playgroundSession) send(event PlaygroundEvent) {
+	event.MachineSnapshot = newMachineSnapshot(s.machine)
+	message, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	s.conn.write(websocketText, message)
+}
+
+// Completes the websocket handshake, taking over the request's connection. Responds with an error
+// (and returns it) if the request is not a websocket handshake.
+func acceptWebsocket(w http.ResponseWriter, req *http.Request) (*websocketConn, error) {
+	key := req.Header.Get("Sec-WebSocket-Key")
+	if len(key) == 0 || !strings.Contains(strings.ToLower(req.Header.Get("Connection")), "upgrade") {
+		http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+		return nil, errors.New("not a websocket handshake")
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websockets are not supported", http.StatusInternalServerError)
+		return nil, errors.New("connection cannot be hijacked")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", websocketAccept(key)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &websocketConn{conn: conn, reader: rw.Reader, masked: true}, nil
+}
+
+// Returns the `Sec-WebSocket-Accept` header proving the handshake with the key was understood
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Reads a message, joining its fragments and answering pings along the way. Returns `io.EOF` once the
+// client closes the connection, and an error if a frame is not masked when it must be.
+func (c *websocketConn) read() ([]byte, error) {
+	message := []byte{}
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+			return nil, err
+		}
+		final, opcode := header[0]&websocketFinal != 0, header[0]&0x0F
+		masked, length := header[1]&websocketMasked != 0, uint64(header[1]&^websocketMasked)
+		switch byte(length) {
+		case websocketLength16:
+			var extended [2]byte
+			if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+				return nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(extended[:]))
+		case websocketLength64:
+			var extended [8]byte
+			if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+				return nil, err
+			}
+			length = binary.BigEndian.Uint64(extended[:])
+		}
+		if length > websocketMaxMessage-uint64(len(message)) {
+			return nil, fmt.Errorf("websocket message longer than %d bytes", websocketMaxMessage)
+		}
+		if c.masked && !masked {
+			return nil, errors.New("websocket frame from the client is not masked")
+		}
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+				return nil, err
+			}
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.reader, payload); err != nil {
+			return nil, err
+		}
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%len(mask)]
+			}
+		}
+
+		switch opcode {
+		case websocketClose:
+			c.write(websocketClose, nil)
+			return nil, io.EOF
+		case websocketPing:
+			if err := c.write(websocketPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case websocketPong:
+			continue
+		}
+		message = append(message, payload...)
+		if final {
+			return message, nil
+		}
+	}
+}
+
+// Writes a single (unfragmented, unmasked) frame
+func (c *websocketConn) write(opcode byte, payload []byte) error {
+	frame := []byte{websocketFinal | opcode}
+	switch {
+	case len(payload) <= websocketMaxUnextendedSize:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		frame = binary.BigEndian.AppendUint16(append(frame, websocketLength16), uint16(len(payload)))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, websocketLength64), uint64(len(payload)))
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, err := c.conn.Write(append(frame, payload...))
+	return err
+}
+
+// The page served by a Playground
+const playgroundPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Turing Machine Playground</title>
+<style>
+body { font-family: monospace; margin: 2em; }
+#tape { font-size: 1.5em; white-space: pre; }
+#tape .head { background: #fd0; }
+</style>
+</head>
+<body>
+<h1>Turing Machine Playground</h1>
+<p>
+<button id="step">Step</button>
+<button id="run">Run</button>
+<button id="pause">Pause</button>
+<button id="reset">Reset</button>
+</p>
+<p>
+Breakpoint <input id="mConfiguration" placeholder="m-configuration">
+<input id="condition" placeholder="condition (optional)">
+<button id="breakpoints">Set</button>
+</p>
+<p>m-configuration <b id="state"></b>, moves <b id="moves"></b> <span id="status"></span></p>
+<div id="tape"></div>
+<script>
+const socket = new WebSocket(location.href.replace(/^http/, "ws"));
+const send = (command) => socket.send(JSON.stringify(command));
+for (const command of ["step", "run", "pause", "reset"]) {
+	document.getElementById(command).onclick = () => send({command});
+}
+document.getElementById("breakpoints").onclick = () => {
+	const mConfiguration = document.getElementById("mConfiguration").value;
+	const condition = document.getElementById("condition").value;
+	send({command: "breakpoints", breakpoints: mConfiguration ? [{mConfiguration, condition}] : []});
+};
+socket.onmessage = (message) => {
+	const event = JSON.parse(message.data);
+	document.getElementById("state").textContent = event.mConfiguration;
+	document.getElementById("moves").textContent = event.moves;
+	let status = event.event === "move" ? "" : event.event;
+	if (event.reason) status += " (" + event.reason + ")";
+	if (event.message) status += ": " + event.message;
+	if (event.verified) status += " " + event.verified.map((verified) => verified ? "set" : "not set").join(", ");
+	document.getElementById("status").textContent = status;
+	document.getElementById("tape").replaceChildren(...(event.tape || []).map((symbol, square) => {
+		const span = document.createElement("span");
+		span.textContent = symbol;
+		if (square === event.origin + event.head) span.className = "head";
+		return span;
+	}));
+};
+</script>
+</body>
+</html>
+`
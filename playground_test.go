@@ -0,0 +1,231 @@
+package turing
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestPlayground(t *testing.T) {
+	playground := NewPlayground(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}, 0)
+	playground.Registry = StandardMachineRegistry()
+	playground.AllowedOrigins = []string{"https://allowed.example"}
+	server := httptest.NewServer(playground)
+	defer server.Close()
+
+	t.Run("Page", func(t *testing.T) {
+		response, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		page, _ := io.ReadAll(response.Body)
+		if !strings.Contains(string(page), "new WebSocket") {
+			t.Errorf("got %q, want the playground page", page)
+		}
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		client := dialPlayground(t, server.URL+"/?machine=bb2-champion")
+		defer client.conn.Close()
+		turingtest.Equal(t, client.next(t).MConfiguration, "0")
+
+		// Breakpoints are set on the registry's machine, not the playground's
+		client.send(t, PlaygroundCommand{Command: "breakpoints", Breakpoints: []PlaygroundBreakpoint{
+			{MConfiguration: "1"},
+			{MConfiguration: "k"},
+		}})
+		if event := client.next(t); len(event.Verified) != 2 || !event.Verified[0] || event.Verified[1] {
+			t.Errorf("got %v, want only the first breakpoint set", event.Verified)
+		}
+
+		response, err := http.Get(server.URL + "/?machine=bb1-champion")
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", response.StatusCode, http.StatusNotFound)
+		}
+	})
+
+	t.Run("Commands", func(t *testing.T) {
+		client := dialPlayground(t, server.URL)
+		defer client.conn.Close()
+		turingtest.Equal(t, client.next(t).Event, "state")
+
+		client.send(t, PlaygroundCommand{Command: "breakpoints", Breakpoints: []PlaygroundBreakpoint{
+			{MConfiguration: "k", Condition: "head > 4"},
+			{MConfiguration: "z"},
+		}})
+		if event := client.next(t); len(event.Verified) != 2 || !event.Verified[0] || event.Verified[1] {
+			t.Errorf("got %v, want only the first breakpoint set", event.Verified)
+		}
+
+		client.send(t, PlaygroundCommand{Command: "step", Count: 2})
+		for range 2 {
+			turingtest.Equal(t, client.next(t).Event, "move")
+		}
+		event := client.next(t)
+		turingtest.Equal(t, event.Event+" "+event.Reason+" "+event.MConfiguration, "stopped step e")
+
+		// `k` is first reached with the head at 3, and next at 7
+		client.send(t, PlaygroundCommand{Command: "run"})
+		for event = client.next(t); event.Event == "move"; event = client.next(t) {
+		}
+		turingtest.Equal(t, event.Event+" "+event.Reason+" "+event.MConfiguration, "stopped breakpoint k")
+		if event.Moves != 7 || event.Head != 7 {
+			t.Errorf("got %d moves with the head at %d, want 7 and 7", event.Moves, event.Head)
+		}
+		turingtest.Equal(t, strings.TrimSpace(Tape(event.Tape).String("")), "0 1 0 1")
+
+		client.send(t, PlaygroundCommand{Command: "reset"})
+		event = client.next(t)
+		if event.Event != "state" || event.Moves != 0 {
+			t.Errorf("got %s after %d moves, want state after 0", event.Event, event.Moves)
+		}
+
+		client.send(t, PlaygroundCommand{Command: "jump"})
+		turingtest.Equal(t, client.next(t).Event, "error")
+
+		client.send(t, PlaygroundCommand{Command: "step", Count: playgroundMaxStepCount + 1})
+		event = client.next(t)
+		if event.Event != "error" || event.Moves != 0 {
+			t.Errorf("got %s after %d moves, want error after 0", event.Event, event.Moves)
+		}
+	})
+
+	t.Run("Origin", func(t *testing.T) {
+		for origin, expected := range map[string]int{
+			server.URL:                  http.StatusSwitchingProtocols,
+			"https://allowed.example":   http.StatusSwitchingProtocols,
+			"https://attacker.example":  http.StatusForbidden,
+			"http://playground.example": http.StatusForbidden,
+		} {
+			request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			request.Header.Set("Origin", origin)
+			request.Header.Set("Upgrade", "websocket")
+			request.Header.Set("Connection", "Upgrade")
+			request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			request.Header.Set("Sec-WebSocket-Version", "13")
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+			if response.StatusCode != expected {
+				t.Errorf("got status %d for origin %s, want %d", response.StatusCode, origin, expected)
+			}
+		}
+	})
+
+	t.Run("Unmasked", func(t *testing.T) {
+		client := dialPlayground(t, server.URL)
+		defer client.conn.Close()
+		client.next(t)
+
+		payload, _ := json.Marshal(PlaygroundCommand{Command: "step"})
+		client.conn.Write(append([]byte{websocketFinal | websocketText, byte(len(payload))}, payload...))
+		// The connection is closed rather than the command handled
+		conn := &websocketConn{conn: client.conn, reader: client.reader}
+		if message, err := conn.read(); err == nil {
+			t.Errorf("got %s, want the connection closed", message)
+		}
+	})
+
+	t.Run("Pause", func(t *testing.T) {
+		client := dialPlayground(t, server.URL)
+		defer client.conn.Close()
+		client.next(t)
+
+		client.send(t, PlaygroundCommand{Command: "run"})
+		client.next(t)
+		client.send(t, PlaygroundCommand{Command: "pause"})
+		event := client.next(t)
+		for ; event.Event == "move"; event = client.next(t) {
+		}
+		turingtest.Equal(t, event.Event+" "+event.Reason, "stopped pause")
+	})
+
+	t.Run("Accept", func(t *testing.T) {
+		// The example from RFC 6455
+		turingtest.Equal(t, websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=")
+	})
+}
+
+// A websocket client of a Playground
+type playgroundClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Connects to the Playground, completing the websocket handshake
+func dialPlayground(t *testing.T, url string) playgroundClient {
+	t.Helper()
+	host, path, _ := strings.Cut(strings.TrimPrefix(url, "http://"), "/")
+	conn, err := net.Dial("tcp", host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(conn, "GET /"+path+" HTTP/1.1\r\nHost: playground\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("got status %d, want %d", response.StatusCode, http.StatusSwitchingProtocols)
+	}
+	return playgroundClient{conn: conn, reader: reader}
+}
+
+// Sends the command in a masked text frame, as browsers do
+func (c playgroundClient) send(t *testing.T, command PlaygroundCommand) {
+	t.Helper()
+	payload, _ := json.Marshal(command)
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{websocketFinal | websocketText}
+	if len(payload) <= websocketMaxUnextendedSize {
+		frame = append(frame, websocketMasked|byte(len(payload)))
+	} else {
+		frame = binary.BigEndian.AppendUint16(append(frame, websocketMasked|websocketLength16), uint16(len(payload)))
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%len(mask)])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Reads the next event
+func (c playgroundClient) next(t *testing.T) PlaygroundEvent {
+	t.Helper()
+	conn := &websocketConn{conn: c.conn, reader: c.reader}
+	message, err := conn.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event PlaygroundEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
@@ -0,0 +1,195 @@
+package turing
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+type (
+	// Input for a ProbabilisticMachine. Unlike Turing's machines, several m-configurations
+	// may match the same m-configuration name and scanned symbol, in which case one of them
+	// is chosen at random.
+	ProbabilisticMachineInput struct {
+		MachineInput
+
+		// The relative weight of each m-configuration (by index). Missing weights default to 1. Weights
+		// must not be negative, and m-configurations weighing zero are never chosen.
+		Weights []float64
+
+		// The seed for the random number generator, so runs can be reproduced
+		Seed int64
+	}
+
+	// A Machine whose moves are sampled from the weighted m-configurations that match. Only the
+	// methods below sample, so the Machine itself is not exposed.
+	ProbabilisticMachine struct {
+		machine *Machine
+		weights []float64
+		random  *rand.Rand
+	}
+
+	// The result of running a ProbabilisticMachine many times (see `MonteCarlo`)
+	MonteCarloResult struct {
+		// The amount of trials run
+		Trials int
+
+		// The amount of trials in which the machine halted
+		Halted int
+
+		// The fraction of trials in which the machine halted
+		HaltingProbability float64
+
+		// The average amount of moves made in the trials that halted
+		MeanMovesToHalt float64
+
+		// The amount of trials that ended with each tape (with blanks trimmed from the ends)
+		Outputs map[string]int
+	}
+)
+
+// Returns a new ProbabilisticMachine, or an error wrapping `ErrInvalidWeight` if a weight is negative
+// (or not a number), or every m-configuration weighs zero
+func NewProbabilisticMachine(input ProbabilisticMachineInput) (*ProbabilisticMachine, error) {
+	pm := &ProbabilisticMachine{
+		machine: NewMachine(input.MachineInput),
+		weights: input.Weights,
+		random:  rand.New(rand.NewSource(input.Seed)),
+	}
+	var total float64
+	for i, weight := range input.Weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("%w: %v for m-configuration %d", ErrInvalidWeight, weight, i)
+		}
+	}
+	for i := range input.MConfigurations {
+		total += pm.weight(i)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: every m-configuration weighs zero", ErrInvalidWeight)
+	}
+	return pm, nil
+}
+
+// Moves the machine once, choosing among the matching m-configurations at random
+func (pm *ProbabilisticMachine) Move() {
+	m := pm.machine
+	if m.halted {
+		return
+	}
+
+	symbol, ok := m.scan()
+	if !ok {
+		return
+	}
+
+	// Find every matching m-configuration that may be chosen, and the total of their weights
+	candidates := []int{}
+	var total float64
+	for i, mConfiguration := range m.mConfigurations {
+		if mConfiguration.Name == m.currentMConfigurationName && m.matches(mConfiguration, symbol) && pm.weight(i) > 0 {
+			candidates = append(candidates, i)
+			total += pm.weight(i)
+		}
+	}
+
+	if len(candidates) == 0 {
+		m.halt(symbol)
+		return
+	}
+
+	// Sample a candidate in proportion to its weight
+	chosen := candidates[len(candidates)-1]
+	sample := pm.random.Float64() * total
+	for _, candidate := range candidates {
+		sample -= pm.weight(candidate)
+		if sample < 0 {
+			chosen = candidate
+			break
+		}
+	}
+
+	m.apply(m.mConfigurations[chosen])
+}
+
+// Moves the machine n times and stops early if halted. Returns the amount of moves the machine took.
+func (pm *ProbabilisticMachine) MoveN(n int) int {
+	for i := 1; i <= n; i++ {
+		pm.Move()
+		if pm.machine.halted {
+			return i
+		}
+	}
+	return n
+}
+
+// Returns true if the machine has halted
+func (pm *ProbabilisticMachine) Halted() bool {
+	return pm.machine.Halted()
+}
+
+// Returns the error the machine halted with, if it halted unintentionally (see `Machine.Err`)
+func (pm *ProbabilisticMachine) Err() error {
+	return pm.machine.Err()
+}
+
+// Returns the m-configuration the machine is in
+func (pm *ProbabilisticMachine) CurrentMConfiguration() string {
+	return pm.machine.CurrentMConfiguration()
+}
+
+// Returns the scanned square, relative to the square originally scanned (see `Machine.Head`)
+func (pm *ProbabilisticMachine) Head() int {
+	return pm.machine.Head()
+}
+
+// Returns the machine's tape
+func (pm *ProbabilisticMachine) Tape() Tape {
+	return pm.machine.Tape()
+}
+
+// Returns the machine's tape as a string
+func (pm *ProbabilisticMachine) TapeString() string {
+	return pm.machine.TapeString()
+}
+
+// Returns the weight of the m-configuration at the index
+func (pm *ProbabilisticMachine) weight(i int) float64 {
+	if i < len(pm.weights) {
+		return pm.weights[i]
+	}
+	return 1
+}
+
+// Runs the machine `trials` times (each for at most `maxMoves` moves, and each with its own seed
+// derived from the input's seed), and reports how often it halts and what it outputs. Returns an error
+// wrapping `ErrInvalidWeight` if the weights are not valid (see `NewProbabilisticMachine`).
+func MonteCarlo(input ProbabilisticMachineInput, trials int, maxMoves int) (MonteCarloResult, error) {
+	result := MonteCarloResult{
+		Trials:  trials,
+		Outputs: map[string]int{},
+	}
+	var totalMovesToHalt int
+	for trial := 0; trial < trials; trial++ {
+		trialInput := input
+		trialInput.Seed = input.Seed + int64(trial)
+		pm, err := NewProbabilisticMachine(trialInput)
+		if err != nil {
+			return MonteCarloResult{}, err
+		}
+		pm.MoveN(maxMoves)
+		if pm.machine.halted {
+			result.Halted++
+			totalMovesToHalt += pm.machine.moves
+		}
+		result.Outputs[strings.Trim(pm.TapeString(), pm.machine.noneSymbol)]++
+	}
+	if trials > 0 {
+		result.HaltingProbability = float64(result.Halted) / float64(trials)
+	}
+	if result.Halted > 0 {
+		result.MeanMovesToHalt = float64(totalMovesToHalt) / float64(result.Halted)
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package turing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProbabilisticMachine(t *testing.T) {
+	input := ProbabilisticMachineInput{
+		MachineInput: MachineInput{
+			MConfigurations: []MConfiguration{
+				// Flip a coin: print `0` or `1` and halt
+				{"b", []string{" "}, []string{"P0"}, "halt"},
+				{"b", []string{" "}, []string{"P1"}, "halt"},
+			},
+		},
+		Weights: []float64{1, 3},
+		Seed:    42,
+	}
+
+	pm, err := NewProbabilisticMachine(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm.MoveN(10)
+	first := pm.TapeString()
+	pm, _ = NewProbabilisticMachine(input)
+	pm.MoveN(10)
+	if pm.TapeString() != first {
+		t.Errorf("got %s, want %s for the same seed", pm.TapeString(), first)
+	}
+
+	result, err := MonteCarlo(input, 2000, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HaltingProbability != 1 || result.MeanMovesToHalt != 1 {
+		t.Errorf("got %+v, want to always halt after one move", result)
+	}
+	if ratio := float64(result.Outputs["1"]) / float64(result.Trials); math.Abs(ratio-0.75) > 0.05 {
+		t.Errorf("got %f of trials printing 1, want roughly 0.75", ratio)
+	}
+
+	// An m-configuration weighing zero is never chosen
+	input.Weights = []float64{0, 1}
+	result, err = MonteCarlo(input, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Outputs["1"] != 100 {
+		t.Errorf("got %v, want every trial printing 1", result.Outputs)
+	}
+}
+
+func TestMonteCarloHaltingProbability(t *testing.T) {
+	result, err := MonteCarlo(ProbabilisticMachineInput{
+		MachineInput: MachineInput{
+			MConfigurations: []MConfiguration{
+				// Either halt or move right forever
+				{"b", []string{" "}, []string{}, "halt"},
+				{"b", []string{" "}, []string{"R"}, "c"},
+				{"c", []string{" "}, []string{"R"}, "c"},
+			},
+		},
+	}, 1000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.HaltingProbability-0.5) > 0.05 {
+		t.Errorf("got %f, want roughly 0.5", result.HaltingProbability)
+	}
+}
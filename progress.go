@@ -0,0 +1,108 @@
+package turing
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// The progress of a long-running search, reported every so often so it can be displayed or monitored
+	SearchProgress struct {
+		// The amount of machines examined so far
+		Examined int `json:"examined"`
+
+		// The amount of machines the search examines in total (0 if unknown)
+		Total int `json:"total"`
+
+		// The best score so far
+		Best int `json:"best"`
+
+		// The time since the search started
+		Elapsed time.Duration `json:"elapsed"`
+
+		// The amount of machines examined per second
+		Rate float64 `json:"rate"`
+	}
+
+	// Receives the progress of a search
+	ProgressFunc func(progress SearchProgress)
+
+	// Struct to hold the state of progress reporting. Safe for concurrent use.
+	progressReporter struct {
+		mutex    sync.Mutex
+		report   ProgressFunc
+		every    int
+		start    time.Time
+		progress SearchProgress
+		reported int
+	}
+
+	// A ResultSink reporting the progress of the results pushed to it
+	progressSink struct {
+		sink     ResultSink
+		reporter *progressReporter
+	}
+)
+
+// Returns a reporter calling `report` (if not nil) once at least `every` more machines have been examined
+func newProgressReporter(report ProgressFunc, every int, total int) *progressReporter {
+	return &progressReporter{
+		report:   report,
+		every:    max(every, 1),
+		start:    time.Now(),
+		progress: SearchProgress{Total: total},
+	}
+}
+
+// Records that `count` more machines were examined, with the best score so far
+func (p *progressReporter) examined(count int, best int) {
+	if p.report == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.progress.Examined += count
+	p.progress.Best = max(p.progress.Best, best)
+	if p.progress.Examined-p.reported >= p.every {
+		p.reportLocked()
+	}
+}
+
+// Reports the progress at the end of the search, unless it was just reported
+func (p *progressReporter) done() {
+	if p.report == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.reported != p.progress.Examined || p.reported == 0 {
+		p.reportLocked()
+	}
+}
+
+func (p *progressReporter) reportLocked() {
+	p.progress.Elapsed = time.Since(p.start)
+	if seconds := p.progress.Elapsed.Seconds(); seconds > 0 {
+		p.progress.Rate = float64(p.progress.Examined) / seconds
+	}
+	p.reported = p.progress.Examined
+	p.report(p.progress)
+}
+
+// Returns a ResultSink pushing results to `sink`, and calling `report` once at least `every` more
+// machines have been searched. `total` is the amount of machines searched in total, if known (see
+// `EnumerationSourceSize`).
+func ProgressSink(sink ResultSink, every int, total int, report ProgressFunc) ResultSink {
+	return &progressSink{
+		sink:     sink,
+		reporter: newProgressReporter(report, every, total),
+	}
+}
+
+func (s *progressSink) Push(result SearchResult) error {
+	if err := s.sink.Push(result); err != nil {
+		return err
+	}
+	s.reporter.examined(result.Searched, result.Best)
+	return nil
+}
@@ -0,0 +1,46 @@
+package turing
+
+import "testing"
+
+func TestBusyBeaverProgress(t *testing.T) {
+	reports := []SearchProgress{}
+	result, err := BusyBeaver(1, WithProgress(10, func(progress SearchProgress) {
+		reports = append(reports, progress)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every 10 machines, and once done
+	if len(reports) != 7 {
+		t.Fatalf("got %d reports, want 7", len(reports))
+	}
+	last := reports[len(reports)-1]
+	if last.Examined != 64 || last.Total != 64 || last.Best != result.Score || last.Rate <= 0 {
+		t.Errorf("got %+v, want all 64 machines examined", last)
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i].Examined <= reports[i-1].Examined || reports[i].Elapsed < reports[i-1].Elapsed {
+			t.Errorf("got %+v after %+v, want progress", reports[i], reports[i-1])
+		}
+	}
+}
+
+func TestProgressSink(t *testing.T) {
+	reports := []SearchProgress{}
+	best := &BestResultSink{}
+	sink := ProgressSink(best, 8, EnumerationSourceSize(1), func(progress SearchProgress) {
+		reports = append(reports, progress)
+	})
+	if err := SearchWorker(EnumerationSource(1, 10), sink, OnesScore, defaultBusyBeaverMaxMoves, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// One report for each batch of 10, the last of which has the remaining 8 machines
+	if len(reports) != 5 {
+		t.Fatalf("got %d reports, want 5", len(reports))
+	}
+	if last := reports[len(reports)-1]; last.Examined != 48 || last.Total != 48 || last.Best != 1 {
+		t.Errorf("got %+v, want all 48 candidates examined", last)
+	}
+}
@@ -0,0 +1,269 @@
+package turing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+type (
+	// A run of a machine, captured once so it can be scrubbed backward and forward without
+	// re-simulating. Consists of the initial MachineInput and the changes made by each move.
+	RunRecording struct {
+		// The version of the format (see `RunRecordingVersion`)
+		Version int `json:"version"`
+
+		// The machine that was run
+		Input MachineInput `json:"input"`
+
+		// The most moves the machine was allowed to make
+		MaxMoves int `json:"maxMoves,omitempty"`
+
+		// The changes made by each move, in order
+		Steps []RecordedStep `json:"steps"`
+	}
+
+	// The changes made by a single move
+	RecordedStep struct {
+		// The m-configuration after the move
+		MConfiguration string `json:"mConfiguration"`
+
+		// The scanned square after the move, relative to the square originally scanned
+		Head int `json:"head"`
+
+		// The squares written during the move
+		Writes []TapeWrite `json:"writes,omitempty"`
+	}
+
+	// Scrubs through a RunRecording, reconstructing the complete configuration at any move
+	RunCursor struct {
+		recording      RunRecording
+		noneSymbol     string
+		step           int
+		squares        map[int]string
+		head           int
+		mConfiguration string
+	}
+)
+
+const (
+	// The current version of the RunRecording format
+	RunRecordingVersion int = 1
+)
+
+// Runs the machine for at most `maxMoves` moves (stopping early if it halts), and records every move
+func Record(input MachineInput, maxMoves int) RunRecording {
+	recordedInput := input
+	recordedInput.RecordTapeWrites = true
+	m := NewMachine(recordedInput)
+
+	recording := RunRecording{
+		Version:  RunRecordingVersion,
+		Input:    input,
+		MaxMoves: maxMoves,
+		Steps:    []RecordedStep{},
+	}
+	for i := 0; i < maxMoves; i++ {
+		step, ok := recordStep(m)
+		if !ok {
+			break
+		}
+		recording.Steps = append(recording.Steps, step)
+	}
+	return recording
+}
+
+// Moves the machine once, returning the changes it made. Returns false if it made no move.
+func recordStep(m *Machine) (RecordedStep, bool) {
+	moves, writes := m.moves, len(m.tapeWrites)
+	m.Move()
+	if m.moves == moves {
+		return RecordedStep{}, false
+	}
+	return RecordedStep{
+		MConfiguration: m.currentMConfigurationName,
+		Head:           m.Head(),
+		Writes:         slices.Clone(m.tapeWrites[writes:]),
+	}, true
+}
+
+// Returns the m-configuration the machine starts in and the one after each move, so the run can be
+// checked with `turingtest.Trace`
+func (recording RunRecording) MConfigurations() []string {
+	mConfigurations := []string{startingMConfigurationName(recording.Input)}
+	for _, step := range recording.Steps {
+		mConfigurations = append(mConfigurations, step.MConfiguration)
+	}
+	return mConfigurations
+}
+
+// Records the run (see `Record`) and writes it (see `WriteRunRecording`), so it can later be
+// replayed with `ReplayRun`
+func RecordRun(w io.Writer, input MachineInput, maxMoves int) error {
+	return WriteRunRecording(w, Record(input, maxMoves))
+}
+
+// Reads a recording written by `RecordRun` and runs its machine again, checking that every move
+// matches the recording (see `Verify`)
+func ReplayRun(r io.Reader) (RunRecording, error) {
+	recording, err := ReadRunRecording(r)
+	if err != nil {
+		return recording, err
+	}
+	return recording, recording.Verify()
+}
+
+// Runs the recorded machine again, checking that every move makes the recorded changes and that
+// the machine stops moving where the recording ends. An error wrapping `ErrReplayMismatch` is
+// returned for the first move that differs.
+func (recording RunRecording) Verify() error {
+	input := recording.Input
+	input.RecordTapeWrites = true
+	m := NewMachine(input)
+	for i, expected := range recording.Steps {
+		actual, ok := recordStep(m)
+		if !ok {
+			return fmt.Errorf("%w: move %d was not made", ErrReplayMismatch, i)
+		}
+		if actual.MConfiguration != expected.MConfiguration || actual.Head != expected.Head {
+			return fmt.Errorf("%w: move %d went to %s at %d, not %s at %d", ErrReplayMismatch, i,
+				actual.MConfiguration, actual.Head, expected.MConfiguration, expected.Head)
+		}
+		if !slices.Equal(actual.Writes, expected.Writes) {
+			return fmt.Errorf("%w: move %d wrote %v, not %v", ErrReplayMismatch, i, actual.Writes, expected.Writes)
+		}
+	}
+	if len(recording.Steps) < recording.MaxMoves {
+		if _, ok := recordStep(m); ok {
+			return fmt.Errorf("%w: move %d was made after the recording ended", ErrReplayMismatch, len(recording.Steps))
+		}
+	}
+	return nil
+}
+
+// Writes the recording as JSON
+func WriteRunRecording(w io.Writer, recording RunRecording) error {
+	return json.NewEncoder(w).Encode(recording)
+}
+
+// Reads a recording written by `WriteRunRecording`, migrating it if it was written in an older
+// format (see `RegisterMigration`). An error wrapping `ErrUnsupportedVersion` is returned if the
+// recording was written in a newer format.
+func ReadRunRecording(r io.Reader) (RunRecording, error) {
+	var data json.RawMessage
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return RunRecording{}, err
+	}
+	var versioned struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &versioned); err != nil {
+		return RunRecording{}, err
+	}
+	data, err := migrate(RunRecordingKind, versioned.Version, RunRecordingVersion, data)
+	if err != nil {
+		return RunRecording{}, err
+	}
+	var recording RunRecording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return RunRecording{}, err
+	}
+	recording.Version = RunRecordingVersion
+	return recording, nil
+}
+
+// Returns a cursor positioned before the first move of the recording
+func (recording RunRecording) Cursor() *RunCursor {
+	c := &RunCursor{
+		recording:      recording,
+		noneSymbol:     recording.Input.NoneSymbol,
+		squares:        map[int]string{},
+		mConfiguration: startingMConfigurationName(recording.Input),
+	}
+	if len(c.noneSymbol) == 0 {
+		c.noneSymbol = none
+	}
+	for i, square := range recording.Input.Tape {
+		c.squares[i-recording.Input.StartingSquare] = square
+	}
+	return c
+}
+
+// Returns the amount of moves made so far
+func (c *RunCursor) Step() int {
+	return c.step
+}
+
+// Applies the next move. Returns false if the recording has ended.
+func (c *RunCursor) Forward() bool {
+	if c.step >= len(c.recording.Steps) {
+		return false
+	}
+	step := c.recording.Steps[c.step]
+	for _, write := range step.Writes {
+		c.squares[write.Square] = write.New
+	}
+	c.head = step.Head
+	c.mConfiguration = step.MConfiguration
+	c.step++
+	return true
+}
+
+// Undoes the previous move. Returns false if the cursor is at the start of the recording.
+func (c *RunCursor) Backward() bool {
+	if c.step == 0 {
+		return false
+	}
+	c.step--
+	step := c.recording.Steps[c.step]
+	for i := len(step.Writes) - 1; i >= 0; i-- {
+		c.squares[step.Writes[i].Square] = step.Writes[i].Old
+	}
+	if c.step == 0 {
+		c.head = 0
+		c.mConfiguration = startingMConfigurationName(c.recording.Input)
+	} else {
+		c.head = c.recording.Steps[c.step-1].Head
+		c.mConfiguration = c.recording.Steps[c.step-1].MConfiguration
+	}
+	return true
+}
+
+// Moves the cursor so that `step` moves have been made (clamped to the recording)
+func (c *RunCursor) Seek(step int) {
+	for c.step < step && c.Forward() {
+	}
+	for c.step > step && c.Backward() {
+	}
+}
+
+// Returns the current m-configuration
+func (c *RunCursor) MConfiguration() string {
+	return c.mConfiguration
+}
+
+// Returns the scanned square, relative to the square originally scanned
+func (c *RunCursor) Head() int {
+	return c.head
+}
+
+// Returns the squares from the leftmost to the rightmost written or scanned, and the
+// index of the square originally scanned within them. Squares never written bear the None
+// symbol (or the BackgroundPattern).
+func (c *RunCursor) Tape() (Tape, int) {
+	start, end := min(0, c.head), max(0, c.head)
+	for square := range c.squares {
+		start = min(start, square)
+		end = max(end, square)
+	}
+	tape := Tape{}
+	for square := start; square <= end; square++ {
+		symbol, ok := c.squares[square]
+		if !ok {
+			symbol = backgroundSymbol(c.recording.Input.BackgroundPattern, c.noneSymbol, square)
+		}
+		tape = append(tape, symbol)
+	}
+	return tape, -start
+}
@@ -0,0 +1,176 @@
+package turing
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestRunRecording(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+			{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+			{"o", []string{"0"}, []string{}, "q"},
+			{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+			{"q", []string{" "}, []string{"P1", "L"}, "p"},
+			{"p", []string{"x"}, []string{"E", "R"}, "q"},
+			{"p", []string{"e"}, []string{"R"}, "f"},
+			{"p", []string{" "}, []string{"L", "L"}, "p"},
+			{"f", []string{"*"}, []string{"R", "R"}, "f"},
+			{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteRunRecording(&buffer, Record(input, 200)); err != nil {
+		t.Fatal(err)
+	}
+	recording, err := ReadRunRecording(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recording.Steps) != 200 {
+		t.Fatalf("got %d steps, want 200", len(recording.Steps))
+	}
+
+	cursor := recording.Cursor()
+	for _, step := range []int{200, 5, 100, 0} {
+		t.Run(strconv.Itoa(step), func(t *testing.T) {
+			m := NewMachine(input)
+			m.MoveN(step)
+			cursor.Seek(step)
+			tape, _ := cursor.Tape()
+			turingtest.TapePrefix(t, strings.Join(tape, ""), m.TapeString())
+			if cursor.MConfiguration() != m.currentMConfigurationName {
+				t.Errorf("got %s, want %s", cursor.MConfiguration(), m.currentMConfigurationName)
+			}
+			if cursor.Head() != m.scannedSquare-m.origin {
+				t.Errorf("got %d, want %d", cursor.Head(), m.scannedSquare-m.origin)
+			}
+		})
+	}
+}
+
+func TestRunRecordingMConfigurations(t *testing.T) {
+	recording := Record(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+			{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+			{"o", []string{"0"}, []string{}, "q"},
+			{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+			{"q", []string{" "}, []string{"P1", "L"}, "p"},
+			{"p", []string{"x"}, []string{"E", "R"}, "q"},
+			{"p", []string{"e"}, []string{"R"}, "f"},
+			{"p", []string{" "}, []string{"L", "L"}, "p"},
+			{"f", []string{"*"}, []string{"R", "R"}, "f"},
+			{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+		},
+	}, 50)
+	turingtest.Trace(t, recording.MConfigurations(), "b", "o", "q", "q", "q", "p", "...", "f", "...", "o", "...", "p", "...", "f")
+}
+
+func TestRunRecordingHalts(t *testing.T) {
+	recording := Record(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "halt"},
+		},
+	}, 10)
+	if len(recording.Steps) != 1 {
+		t.Errorf("got %d steps, want 1", len(recording.Steps))
+	}
+}
+
+func TestRunRecordingStartingSquare(t *testing.T) {
+	recording := Record(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Px", "R"}, "b"},
+		},
+		Tape:           Tape{"0", "1", "0"},
+		StartingSquare: 1,
+	}, 1)
+	cursor := recording.Cursor()
+	cursor.Seek(1)
+	tape, origin := cursor.Tape()
+	if strings.Join(tape, "") != "0x0" || origin != 1 {
+		t.Errorf("got %q and origin %d, want 0x0 and 1", strings.Join(tape, ""), origin)
+	}
+}
+
+func TestRunRecordingBackgroundPattern(t *testing.T) {
+	recording := Record(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{}, []string{"R"}, "c"},
+			{"c", []string{}, []string{"R"}, "d"},
+			{"d", []string{}, []string{"Px"}, "e"},
+		},
+		BackgroundPattern: []string{"a", "b"},
+	}, 3)
+	cursor := recording.Cursor()
+	cursor.Seek(3)
+	tape, origin := cursor.Tape()
+	if strings.Join(tape, "") != "abx" || origin != 0 {
+		t.Errorf("got %q and origin %d, want abx and 0", strings.Join(tape, ""), origin)
+	}
+}
+
+func TestReadRunRecordingVersion(t *testing.T) {
+	_, err := ReadRunRecording(strings.NewReader(`{"version": 99}`))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("got %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestReplayRun(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"P0", "R"}, "c"},
+			{"c", []string{"*", " "}, []string{"R"}, "e"},
+			{"e", []string{"*", " "}, []string{"P1", "R"}, "f"},
+			{"f", []string{"*", " "}, []string{"R"}, "halt"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	if err := RecordRun(&buffer, input, 10); err != nil {
+		t.Fatal(err)
+	}
+	recorded := buffer.String()
+	recording, err := ReplayRun(strings.NewReader(recorded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recording.Steps) != 4 || recording.MaxMoves != 10 {
+		t.Errorf("got %d steps of at most %d", len(recording.Steps), recording.MaxMoves)
+	}
+
+	for name, tamper := range map[string]func(recording *RunRecording){
+		"Head": func(recording *RunRecording) {
+			recording.Steps[1].Head = 5
+		},
+		"MConfiguration": func(recording *RunRecording) {
+			recording.Steps[2].MConfiguration = "b"
+		},
+		"Writes": func(recording *RunRecording) {
+			recording.Steps[0].Writes[0].New = "1"
+		},
+		"Truncated": func(recording *RunRecording) {
+			recording.Steps = recording.Steps[:3]
+		},
+		"Extended": func(recording *RunRecording) {
+			recording.Steps = append(recording.Steps, RecordedStep{MConfiguration: "halt", Head: 5})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recording, _ := ReadRunRecording(strings.NewReader(recorded))
+			tamper(&recording)
+			if err := recording.Verify(); !errors.Is(err, ErrReplayMismatch) {
+				t.Errorf("got %v, want ErrReplayMismatch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// A named region of the tape (i.e. input, scratch or output), delimited by symbols on F-squares
+	TapeRegion struct {
+		// The region's name, used to name its m-functions (i.e. `f_scratch(C, B, a)`)
+		Name string
+
+		// The symbol on the F-square before the region
+		Start string
+
+		// The symbol on the F-square after the region
+		End string
+
+		// The amount of F-squares in the region
+		Size int
+
+		// The m-configuration taken up when printing at the end of the region with no room left.
+		// Defaults to `full_` followed by the region's name.
+		Full string
+	}
+
+	// Regions laid out one after the other on a tape beginning with Turing's `e e` (see `NewTapeRegions`)
+	TapeRegions struct {
+		regions []TapeRegion
+	}
+)
+
+// Returns the regions, in the order they are laid out on the tape. Returns `ErrInvalidOption` if a
+// region is unnamed (or named twice) or has a negative size, and `ErrSymbolCollision` if a delimiter is
+// used twice, is `e` or cannot be told apart from other symbols in a table (i.e. begins with `!`).
+func NewTapeRegions(regions ...TapeRegion) (TapeRegions, error) {
+	names := []string{}
+	delimiters := []string{}
+	for _, region := range regions {
+		if len(region.Name) == 0 || strings.ContainsAny(region.Name, "(), ") || slices.Contains(names, region.Name) {
+			return TapeRegions{}, fmt.Errorf("%w: region name %q", ErrInvalidOption, region.Name)
+		}
+		if region.Size < 0 {
+			return TapeRegions{}, fmt.Errorf("%w: region %s has size %d", ErrInvalidOption, region.Name, region.Size)
+		}
+		names = append(names, region.Name)
+		for _, delimiter := range []string{region.Start, region.End} {
+			if len(delimiter) == 0 || delimiter == none || delimiter == any || delimiter == "e" ||
+				strings.HasPrefix(delimiter, not) || slices.Contains(delimiters, delimiter) {
+				return TapeRegions{}, fmt.Errorf("%w: region %s delimiter %q", ErrSymbolCollision, region.Name, delimiter)
+			}
+			delimiters = append(delimiters, delimiter)
+		}
+	}
+	return TapeRegions{regions: slices.Clone(regions)}, nil
+}
+
+// Returns the regions, in the order they are laid out on the tape
+func (r TapeRegions) Regions() []TapeRegion {
+	return slices.Clone(r.regions)
+}
+
+// Returns a tape with the regions laid out (empty) after `e e`, to be used as a machine's `Tape`
+func (r TapeRegions) Tape() Tape {
+	tape := Tape{"e", "e"}
+	for _, region := range r.regions {
+		tape = append(tape, region.Start, none)
+		for range region.Size {
+			tape = append(tape, none, none)
+		}
+		tape = append(tape, region.End, none)
+	}
+	return tape
+}
+
+// Returns the delimiters (and `e`), which must be among the PossibleSymbols of a table using the regions
+func (r TapeRegions) Symbols() []string {
+	symbols := []string{"e"}
+	for _, region := range r.regions {
+		symbols = append(symbols, region.Start, region.End)
+	}
+	return symbols
+}
+
+// Returns region-scoped variants of Turing's skeleton tables (m-functions) for every region, to be added
+// to an abbreviated table's m-configurations. For a region `x`:
+//
+//   - `f_x(C, B, a)` finds the first `a` in the region -> `C`. If there is no `a` -> `B`.
+//   - `e_x(C, B, a)` erases the first `a` in the region -> `C`. If there is no `a` -> `B`.
+//   - `e_x(B, a)` erases all letters `a` in the region -> `B`.
+//   - `pe_x(C, b)` prints `b` on the first blank F-square in the region -> `C`.
+//   - `c_x(C, B, a)` and `ce_x(C, B, a)` write the first symbol in the region marked `a` at the end
+//     of the region (and erase the `a`) -> `C`. `ce_x(B, a)` does so for every symbol marked `a`.
+//   - `c_x_y(C, B, a)`, `ce_x_y(C, B, a)` and `ce_x_y(B, a)` do the same, but write at the end of
+//     region `y`.
+//
+// Unlike `f` and the others, these never look past the region's delimiters, so letters used
+// in one region cannot be mistaken for those of another.
+func (r TapeRegions) Skeletons() []MConfiguration {
+	mConfigurations := []MConfiguration{}
+	for _, region := range r.regions {
+		mConfigurations = append(mConfigurations, region.skeletons()...)
+	}
+	for _, from := range r.regions {
+		for _, to := range r.regions {
+			if from.Name != to.Name {
+				mConfigurations = append(mConfigurations, copySkeletons(from.Name+"_"+to.Name, from.Name, to.Name)...)
+			}
+		}
+	}
+	return mConfigurations
+}
+
+// Returns the region's find, erase, print and copy m-functions
+func (region TapeRegion) skeletons() []MConfiguration {
+	x := "_" + region.Name
+	full := region.Full
+	if len(full) == 0 {
+		full = "full" + x
+	}
+	mConfigurations := []MConfiguration{
+		// From `s_x(C)` the machine finds the region's start delimiter -> `C`
+		{"s" + x + "(C)", []string{"e"}, []string{"L"}, "s" + x + "1(C)"},
+		{"s" + x + "(C)", []string{not + "e", none}, []string{"L"}, "s" + x + "(C)"},
+		{"s" + x + "1(C)", []string{region.Start}, []string{}, "C"},
+		{"s" + x + "1(C)", []string{not + region.Start, none}, []string{"R"}, "s" + x + "1(C)"},
+
+		{"f" + x + "(C, B, a)", []string{any, none}, []string{}, "s" + x + "(f" + x + "1(C, B, a))"},
+		{"f" + x + "1(C, B, a)", []string{"a"}, []string{}, "C"},
+		{"f" + x + "1(C, B, a)", []string{region.End}, []string{}, "B"},
+		{"f" + x + "1(C, B, a)", []string{not + "a", none}, []string{"R"}, "f" + x + "1(C, B, a)"},
+
+		{"e" + x + "(C, B, a)", []string{any, none}, []string{}, "f" + x + "(e" + x + "1(C), B, a)"},
+		{"e" + x + "1(C)", []string{any, none}, []string{"E"}, "C"},
+		{"e" + x + "(B, a)", []string{any, none}, []string{}, "e" + x + "(e" + x + "(B, a), B, a)"},
+
+		{"pe" + x + "(C, b)", []string{any, none}, []string{}, "s" + x + "(pe" + x + "1(C, b))"},
+		{"pe" + x + "1(C, b)", []string{any}, []string{"R", "R"}, "pe" + x + "2(C, b)"},
+		{"pe" + x + "2(C, b)", []string{region.End}, []string{}, full},
+		{"pe" + x + "2(C, b)", []string{any}, []string{"R", "R"}, "pe" + x + "2(C, b)"},
+		{"pe" + x + "2(C, b)", []string{none}, []string{"Pb"}, "C"},
+	}
+	return append(mConfigurations, copySkeletons(region.Name, region.Name, region.Name)...)
+}
+
+// Returns the m-functions `c_x` and `ce_x` (named by `suffix`) copying symbols marked in region `from`
+// to the end of region `to`
+func copySkeletons(suffix string, from string, to string) []MConfiguration {
+	x := "_" + suffix
+	return []MConfiguration{
+		{"c" + x + "(C, B, a)", []string{any, none}, []string{}, "f_" + from + "(c" + x + "1(C), B, a)"},
+		{"c" + x + "1(C)", []string{any, none}, []string{"L"}, "c" + x + "2(C)"},
+		{"c" + x + "2(C)", []string{"_b"}, []string{}, "pe_" + to + "(C, _b)"},
+		{"ce" + x + "(C, B, a)", []string{any, none}, []string{}, "c" + x + "(e_" + from + "(C, B, a), B, a)"},
+		{"ce" + x + "(B, a)", []string{any, none}, []string{}, "ce" + x + "(ce" + x + "(B, a), B, a)"},
+	}
+}
@@ -0,0 +1,64 @@
+package turing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestTapeRegions(t *testing.T) {
+	regions, err := NewTapeRegions(
+		TapeRegion{Name: "scratch", Start: "(", End: ")", Size: 2},
+		TapeRegion{Name: "input", Start: "[", End: "]", Size: 2},
+		TapeRegion{Name: "output", Start: "<", End: ">", Size: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tape := regions.Tape()
+	turingtest.Equal(t, tape.String(""), "ee(     ) [     ] <     > ")
+
+	// The scratch region's `x` is found first by `f`, but is out of reach of the input region's m-functions
+	tape[4], tape[5] = "1", "x"
+	tape[12], tape[13] = "0", "x"
+	tape[14], tape[15] = "1", "x"
+	mConfigurations := append([]MConfiguration{
+		{"b", []string{"*", " "}, []string{}, "ce_input_output(pe_output(b1, 1), x)"},
+	}, regions.Skeletons()...)
+	input, err := CompileAbbreviatedTable(AbbreviatedTableInput{
+		MConfigurations:        mConfigurations,
+		Tape:                   tape,
+		PossibleSymbols:        append(regions.Symbols(), "0", "1", "x"),
+		HaltingMConfigurations: []string{"b1", "full_output"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(input)
+	if _, err := m.RunUntilHalt(10000); err != nil {
+		t.Fatal(err)
+	}
+	// The output region was full, so `1` was not printed
+	turingtest.Equal(t, m.TapeString()[m.Origin():], "ee( 1x  ) [ 0 1 ] < 0 1 > ")
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, test := range []struct {
+			name    string
+			regions []TapeRegion
+			err     error
+		}{
+			{"Unnamed", []TapeRegion{{Start: "[", End: "]"}}, ErrInvalidOption},
+			{"Named Twice", []TapeRegion{{Name: "a", Start: "[", End: "]"}, {Name: "a", Start: "<", End: ">"}}, ErrInvalidOption},
+			{"Negative Size", []TapeRegion{{Name: "a", Start: "[", End: "]", Size: -1}}, ErrInvalidOption},
+			{"Shared Delimiter", []TapeRegion{{Name: "a", Start: "[", End: "]"}, {Name: "b", Start: "]", End: ">"}}, ErrSymbolCollision},
+			{"Schwa Delimiter", []TapeRegion{{Name: "a", Start: "e", End: "]"}}, ErrSymbolCollision},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				if _, err := NewTapeRegions(test.regions...); !errors.Is(err, test.err) {
+					t.Errorf("got %v, want %v", err, test.err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package turing
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+)
+
+type (
+	// Machines registered under names (i.e. `bb2-champion`), so that they can be referred to by name
+	// rather than passed around as tables (see `Playground.Registry`). Safe for concurrent use.
+	MachineRegistry struct {
+		machines map[string]RegisteredMachine
+		mutex    sync.RWMutex
+	}
+
+	// A machine of a MachineRegistry, with its metadata
+	RegisteredMachine struct {
+		// The name the machine is registered under
+		Name string `json:"name"`
+
+		// What the machine does
+		Description string `json:"description,omitempty"`
+
+		// Where the machine comes from (i.e. a paper, or the file it was read from)
+		Source string `json:"source,omitempty"`
+
+		// Any other metadata
+		Metadata map[string]string `json:"metadata,omitempty"`
+
+		// The machine
+		Input MachineInput `json:"input"`
+	}
+)
+
+// Returns an empty MachineRegistry
+func NewMachineRegistry() *MachineRegistry {
+	return &MachineRegistry{
+		machines: map[string]RegisteredMachine{},
+	}
+}
+
+// Returns a MachineRegistry of the machines this package defines: Turing's examples, the busy beaver
+// champions and the unary arithmetic machines
+func StandardMachineRegistry() *MachineRegistry {
+	r := NewMachineRegistry()
+	for _, machine := range standardMachines() {
+		r.Register(machine)
+	}
+	return r
+}
+
+// Registers the machine under its name, replacing any machine registered under it before.
+// An error wrapping `ErrInvalidOption` is returned if the machine has no name.
+func (r *MachineRegistry) Register(machine RegisteredMachine) error {
+	if len(machine.Name) == 0 {
+		return fmt.Errorf("%w: a registered machine needs a name", ErrInvalidOption)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.machines[machine.Name] = cloneRegisteredMachine(machine)
+	return nil
+}
+
+// Reads the machine from the file (written by `WriteMachineInput`) and registers it under the name
+func (r *MachineRegistry) RegisterFile(name string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	input, err := ReadMachineInput(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return r.Register(RegisteredMachine{Name: name, Source: path, Input: input})
+}
+
+// Returns the machine registered under the name. An error wrapping `ErrUnknownMachine` is returned if
+// there is none.
+func (r *MachineRegistry) Lookup(name string) (RegisteredMachine, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	machine, ok := r.machines[name]
+	if !ok {
+		return RegisteredMachine{}, fmt.Errorf("%w: %s", ErrUnknownMachine, name)
+	}
+	return cloneRegisteredMachine(machine), nil
+}
+
+// Returns the registered machines, by name
+func (r *MachineRegistry) Machines() []RegisteredMachine {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	machines := []RegisteredMachine{}
+	for _, machine := range r.machines {
+		machines = append(machines, cloneRegisteredMachine(machine))
+	}
+	slices.SortFunc(machines, func(a, b RegisteredMachine) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return machines
+}
+
+// Returns a copy of the machine, so the registry shares nothing with those registering or looking it up
+func cloneRegisteredMachine(machine RegisteredMachine) RegisteredMachine {
+	machine.Metadata = maps.Clone(machine.Metadata)
+	machine.Input.MConfigurations = cloneMConfigurations(machine.Input.MConfigurations)
+	machine.Input.Tape = slices.Clone(machine.Input.Tape)
+	machine.Input.PossibleSymbols = slices.Clone(machine.Input.PossibleSymbols)
+	machine.Input.HaltingMConfigurations = slices.Clone(machine.Input.HaltingMConfigurations)
+	machine.Input.BackgroundPattern = slices.Clone(machine.Input.BackgroundPattern)
+	machine.Input.BeepMConfigurations = slices.Clone(machine.Input.BeepMConfigurations)
+	machine.Input.Exports = slices.Clone(machine.Input.Exports)
+	return machine
+}
+
+// Returns the machines of `StandardMachineRegistry`
+func standardMachines() []RegisteredMachine {
+	return []RegisteredMachine{
+		{
+			Name:        "turing-example-1",
+			Description: "Prints 0 1 0 1 ... on alternate squares.",
+			Source:      "Turing (1936), section 3, example I",
+			Input: MachineInput{
+				MConfigurations: []MConfiguration{
+					{"b", []string{" "}, []string{"P0", "R"}, "c"},
+					{"c", []string{" "}, []string{"R"}, "e"},
+					{"e", []string{" "}, []string{"P1", "R"}, "k"},
+					{"k", []string{" "}, []string{"R"}, "b"},
+				},
+				PossibleSymbols: []string{"0", "1"},
+			},
+		},
+		{
+			Name:        "turing-example-2",
+			Description: "Prints 0 0 1 0 1 1 0 1 1 1 ..., with one more 1 each time.",
+			Source:      "Turing (1936), section 3, example II",
+			Input: MachineInput{
+				MConfigurations: []MConfiguration{
+					{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+					{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+					{"o", []string{"0"}, []string{}, "q"},
+					{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+					{"q", []string{" "}, []string{"P1", "L"}, "p"},
+					{"p", []string{"x"}, []string{"E", "R"}, "q"},
+					{"p", []string{"e"}, []string{"R"}, "f"},
+					{"p", []string{" "}, []string{"L", "L"}, "p"},
+					{"f", []string{"*"}, []string{"R", "R"}, "f"},
+					{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+				},
+				PossibleSymbols: []string{"e", "x", "0", "1"},
+			},
+		},
+		{
+			Name:        "bb2-champion",
+			Description: "The 2 m-configuration busy beaver champion, printing four 1s in six moves before halting.",
+			Source:      "Radó (1962)",
+			Input: getBusyBeaverMachineInput([]MConfiguration{
+				{"0", []string{"0"}, []string{"P1", "R"}, "1"},
+				{"0", []string{"1"}, []string{"P1", "L"}, "1"},
+				{"1", []string{"0"}, []string{"P1", "L"}, "0"},
+				{"1", []string{"1"}, []string{"P1", "R"}, haltMConfigurationName},
+			}),
+		},
+		{
+			Name:        "unary-increment",
+			Description: "Adds one to a unary number (see `UnaryTape`), then halts.",
+			Input:       UnaryIncrementMachine(),
+		},
+		{
+			Name:        "unary-addition",
+			Description: "Adds two unary numbers, then halts.",
+			Input:       UnaryAdditionMachine(),
+		},
+		{
+			Name:        "unary-multiplication",
+			Description: "Multiplies two unary numbers, writing the product after them, then halts.",
+			Input:       UnaryMultiplicationMachine(),
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package turing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestMachineRegistry(t *testing.T) {
+	t.Run("Standard", func(t *testing.T) {
+		r := StandardMachineRegistry()
+		champion, err := r.Lookup("bb2-champion")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewMachine(champion.Input)
+		moves, err := m.RunUntilHalt(100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if moves != 6 {
+			t.Errorf("got %d moves, want 6", moves)
+		}
+		turingtest.Equal(t, m.TapeString(), "1111")
+
+		example, err := r.Lookup("turing-example-2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m = NewMachine(example.Input)
+		m.MoveN(200)
+		turingtest.TapePrefix(t, m.TapeString(), "ee0 0 1 0 1 1 0 1 1 1 0 1 1 1 1")
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		r := NewMachineRegistry()
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "b"},
+			},
+		}
+		if err := r.Register(RegisteredMachine{Name: "zeros", Metadata: map[string]string{"author": "me"}, Input: input}); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Register(RegisteredMachine{Input: input}); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("got %v, want %v", err, ErrInvalidOption)
+		}
+
+		// The registry keeps its own copy
+		input.MConfigurations[0].Operations[0] = "P1"
+		zeros, err := r.Lookup("zeros")
+		if err != nil {
+			t.Fatal(err)
+		}
+		turingtest.Equal(t, zeros.Input.MConfigurations[0].Operations[0], "P0")
+		turingtest.Equal(t, zeros.Metadata["author"], "me")
+
+		if _, err := r.Lookup("ones"); !errors.Is(err, ErrUnknownMachine) {
+			t.Errorf("got %v, want %v", err, ErrUnknownMachine)
+		}
+	})
+
+	t.Run("RegisterFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "example.json")
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		example, _ := StandardMachineRegistry().Lookup("turing-example-1")
+		if err := WriteMachineInput(file, example.Input); err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+
+		r := NewMachineRegistry()
+		if err := r.RegisterFile("example", path); err != nil {
+			t.Fatal(err)
+		}
+		machines := r.Machines()
+		if len(machines) != 1 {
+			t.Fatalf("got %d machines, want 1", len(machines))
+		}
+		turingtest.Equal(t, machines[0].Source, path)
+		turingtest.Equal(t, Fingerprint(machines[0].Input), Fingerprint(example.Input))
+	})
+}
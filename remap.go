@@ -0,0 +1,136 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Returns the machine with its symbols renamed by the mapping (symbols not in the mapping are kept),
+// in its m-configurations, Tape, PossibleSymbols, NoneSymbol and BackgroundPattern. Abbreviated tables
+// should be compiled first, as the symbols passed to m-functions are not renamed. An error wrapping
+// `ErrSymbolCollision` is returned if two symbols would be given the same name, or a symbol would be
+// given a name reserved for `*` (Any) or `!` (Not).
+func RemapSymbols(input MachineInput, mapping map[string]string) (MachineInput, error) {
+	noneSymbol := input.NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+	rename := func(symbol string) string {
+		if renamed, ok := mapping[symbol]; ok {
+			return renamed
+		}
+		return symbol
+	}
+
+	// Every symbol the machine uses, so that no two are given the same name
+	alphabet := []string{noneSymbol}
+	alphabet = append(alphabet, input.PossibleSymbols...)
+	alphabet = append(alphabet, input.Tape...)
+	alphabet = append(alphabet, input.BackgroundPattern...)
+	for _, mConfiguration := range input.MConfigurations {
+		for _, symbol := range mConfiguration.Symbols {
+			if symbol != any {
+				alphabet = append(alphabet, strings.TrimPrefix(symbol, not))
+			}
+		}
+		for _, operation := range mConfiguration.Operations {
+			if len(operation) > 1 && operationCode(operation[0]) == printOp {
+				alphabet = append(alphabet, operation[1:])
+			}
+		}
+	}
+	for symbol := range mapping {
+		if !slices.Contains(alphabet, symbol) {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+
+	renamedFrom := map[string]string{}
+	for _, symbol := range alphabet {
+		renamed := rename(symbol)
+		if len(renamed) == 0 || renamed == any || strings.HasPrefix(renamed, not) {
+			return MachineInput{}, fmt.Errorf("%w: %q cannot be renamed %q", ErrSymbolCollision, symbol, renamed)
+		}
+		if from, ok := renamedFrom[renamed]; ok && from != symbol {
+			return MachineInput{}, fmt.Errorf("%w: %q and %q would both be %q", ErrSymbolCollision, from, symbol, renamed)
+		}
+		renamedFrom[renamed] = symbol
+	}
+
+	return renameSymbols(input, rename), nil
+}
+
+// Returns the machine with every symbol given a normalized name by `normalize`, such as `norm.NFC.String`
+// from golang.org/x/text, so that symbols written differently but meaning the same (a precomposed `é` and
+// an `e` followed by a combining accent, say) are matched as one. Unlike `RemapSymbols` symbols may be
+// given the same name, but an error wrapping `ErrSymbolCollision` is returned if a symbol would be given
+// an empty name or one reserved for `*` (Any) or `!` (Not).
+func NormalizeSymbols(input MachineInput, normalize func(string) string) (MachineInput, error) {
+	var err error
+	normalized := renameSymbols(input, func(symbol string) string {
+		normalizedSymbol := normalize(symbol)
+		if err == nil && (len(normalizedSymbol) == 0 || normalizedSymbol == any || strings.HasPrefix(normalizedSymbol, not)) {
+			err = fmt.Errorf("%w: %q cannot be normalized to %q", ErrSymbolCollision, symbol, normalizedSymbol)
+		}
+		return normalizedSymbol
+	})
+	if err != nil {
+		return MachineInput{}, err
+	}
+	return normalized, nil
+}
+
+// Returns the machine with its symbols renamed by `rename`, in its m-configurations, Tape,
+// PossibleSymbols, NoneSymbol and BackgroundPattern
+func renameSymbols(input MachineInput, rename func(string) string) MachineInput {
+	noneSymbol := input.NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+	renameAll := func(symbols []string) []string {
+		if symbols == nil {
+			return nil
+		}
+		renamed := []string{}
+		for _, symbol := range symbols {
+			renamed = append(renamed, rename(symbol))
+		}
+		return renamed
+	}
+
+	remapped := input
+	remapped.MConfigurations = []MConfiguration{}
+	for _, mConfiguration := range input.MConfigurations {
+		symbols := []string{}
+		for _, symbol := range mConfiguration.Symbols {
+			if strings.HasPrefix(symbol, not) {
+				symbols = append(symbols, not+rename(symbol[1:]))
+			} else if symbol == any {
+				symbols = append(symbols, symbol)
+			} else {
+				symbols = append(symbols, rename(symbol))
+			}
+		}
+		operations := []string{}
+		for _, operation := range mConfiguration.Operations {
+			if len(operation) > 1 && operationCode(operation[0]) == printOp {
+				operation = string(printOp) + rename(operation[1:])
+			}
+			operations = append(operations, operation)
+		}
+		remapped.MConfigurations = append(remapped.MConfigurations, MConfiguration{
+			Name:                mConfiguration.Name,
+			Symbols:             symbols,
+			Operations:          operations,
+			FinalMConfiguration: mConfiguration.FinalMConfiguration,
+		})
+	}
+	remapped.Tape = renameAll(input.Tape)
+	remapped.PossibleSymbols = renameAll(input.PossibleSymbols)
+	remapped.BackgroundPattern = renameAll(input.BackgroundPattern)
+	if renamedNone := rename(noneSymbol); len(input.NoneSymbol) != 0 || renamedNone != none {
+		remapped.NoneSymbol = renamedNone
+	}
+	return remapped
+}
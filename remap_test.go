@@ -0,0 +1,100 @@
+package turing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestRemapSymbols(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{"!1", " "}, []string{"R", "P1", "R"}, "d"},
+			{"d", []string{"*"}, []string{"R"}, "b"},
+		},
+		Tape:            Tape{"1"},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	remapped, err := RemapSymbols(input, map[string]string{"0": "::", "1": "S12", " ": "_"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remapped.NoneSymbol != "_" || remapped.MConfigurations[1].Symbols[0] != "!S12" || remapped.MConfigurations[2].Symbols[0] != "*" {
+		t.Errorf("got %+v", remapped)
+	}
+
+	if remapped.Tape[0] != "S12" {
+		t.Errorf("got tape %q, want S12", remapped.Tape)
+	}
+
+	remapped.Tape = nil
+	m := NewMachine(remapped)
+	m.MoveN(2)
+	turingtest.TapePrefix(t, m.TapeString(), "::_S12")
+
+	// The original is unchanged
+	if input.MConfigurations[0].Operations[0] != "P0" || input.Tape[0] != "1" {
+		t.Errorf("got %+v, want the input unchanged", input)
+	}
+}
+
+func TestRemapSymbolsCollisions(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R", "P1"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	}
+	for _, mapping := range []map[string]string{
+		{"0": "1"},
+		{"0": "x", "1": "x"},
+		{" ": "0"},
+		{"0": "*"},
+		{"0": "!x"},
+		{"0": ""},
+	} {
+		if _, err := RemapSymbols(input, mapping); !errors.Is(err, ErrSymbolCollision) {
+			t.Errorf("got %v for %v, want ErrSymbolCollision", err, mapping)
+		}
+	}
+
+	// Swapping symbols is not a collision
+	if _, err := RemapSymbols(input, map[string]string{"0": "1", "1": "0"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNormalizeSymbols(t *testing.T) {
+	// A stand-in for NFC normalization, composing `e` followed by a combining acute accent into `é`
+	compose := func(symbol string) string {
+		return strings.ReplaceAll(symbol, "e\u0301", "é")
+	}
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"é"}, []string{"Pə", "R"}, "b"},
+			{"b", []string{"!é"}, []string{"R"}, "b"},
+		},
+		Tape:            Tape{"é", "e\u0301", "x"},
+		PossibleSymbols: []string{"é", "ə", "x"},
+	}
+
+	// Only the precomposed `é` is matched before normalizing
+	m := NewMachine(input)
+	m.MoveN(3)
+	turingtest.TapePrefix(t, m.TapeString(), "əe\u0301x")
+
+	normalized, err := NormalizeSymbols(input, compose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = NewMachine(normalized)
+	m.MoveN(3)
+	turingtest.TapePrefix(t, m.TapeString(), "əəx")
+
+	if _, err := NormalizeSymbols(input, func(string) string { return "*" }); !errors.Is(err, ErrSymbolCollision) {
+		t.Errorf("got %v, want ErrSymbolCollision", err)
+	}
+}
@@ -0,0 +1,95 @@
+package turing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+type (
+	// A summary of a busy beaver search, for sharing its results (see `WriteSearchReport`
+	// and `Markdown`)
+	SearchReport struct {
+		// The amount of m-configurations of the machines searched
+		N int `json:"n"`
+
+		// The amount of machines enumerated
+		Enumerated int `json:"enumerated"`
+
+		// The amount of machines proven to never halt without simulating them, by decider
+		// (i.e. `PrunedCycler`)
+		Pruned map[string]int `json:"pruned"`
+
+		// The amount of machines simulated
+		Simulated int `json:"simulated"`
+
+		// The amount of machines simulated that halted
+		Halted int `json:"halted"`
+
+		// The machines that neither halted nor were proven to never halt
+		Holdouts []MachineInput `json:"holdouts"`
+
+		// The best score
+		Best int `json:"best"`
+
+		// The machines with the best score
+		Champions []SearchChampion `json:"champions"`
+	}
+
+	// A machine with the best score of a search
+	SearchChampion struct {
+		// The machine's table, abbreviated
+		Table string `json:"table"`
+
+		// The machine
+		Machine MachineInput `json:"machine"`
+
+		// The measurements of its run
+		Metrics Metrics `json:"metrics"`
+	}
+)
+
+const (
+	// Machines without a transition to `halt`
+	PrunedNoHaltTransition string = "no-halt-transition"
+
+	// Machines repeating a configuration, up to translation (see `DecideHalting`)
+	PrunedCycler string = "cycler"
+
+	// Machines whose left-right mirror (see `Mirror`) is searched instead, since it scores the same
+	PrunedMirror string = "mirror"
+)
+
+// Writes the report as JSON
+func WriteSearchReport(w io.Writer, report SearchReport) error {
+	return json.NewEncoder(w).Encode(report)
+}
+
+// Returns the report as a Markdown document
+func (r SearchReport) Markdown() string {
+	var markdown strings.Builder
+	markdown.WriteString(fmt.Sprintf("# Busy beaver search (%d m-configurations)\n\n", r.N))
+	markdown.WriteString("| | Machines |\n|---|---|\n")
+	markdown.WriteString(fmt.Sprintf("| Enumerated | %d |\n", r.Enumerated))
+	pruned := []string{}
+	for decider := range r.Pruned {
+		pruned = append(pruned, decider)
+	}
+	slices.Sort(pruned)
+	for _, decider := range pruned {
+		markdown.WriteString(fmt.Sprintf("| Pruned (%s) | %d |\n", decider, r.Pruned[decider]))
+	}
+	markdown.WriteString(fmt.Sprintf("| Simulated | %d |\n", r.Simulated))
+	markdown.WriteString(fmt.Sprintf("| Halted | %d |\n", r.Halted))
+	markdown.WriteString(fmt.Sprintf("| Holdouts | %d |\n", len(r.Holdouts)))
+
+	markdown.WriteString(fmt.Sprintf("\n## Champions (score %d)\n\n", r.Best))
+	markdown.WriteString("| Table | Moves | Ones | Squares visited | Max excursion |\n|---|---|---|---|---|\n")
+	for _, champion := range r.Champions {
+		markdown.WriteString(fmt.Sprintf("| `%s` | %d | %d | %d | %d |\n", strings.TrimSpace(champion.Table),
+			champion.Metrics.Moves, champion.Metrics.Ones, champion.Metrics.SquaresVisited, champion.Metrics.MaxExcursion))
+	}
+	return markdown.String()
+}
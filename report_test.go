@@ -0,0 +1,48 @@
+package turing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSearchReport(t *testing.T) {
+	report := busyBeaverSearch(1, busyBeaverOptions{
+		maxMoves: defaultBusyBeaverMaxMoves,
+		score:    OnesScore,
+		symbols:  2,
+	})
+	if report.Enumerated != 64 || report.Pruned[PrunedNoHaltTransition] != 16 || report.Pruned[PrunedMirror] != 24 || report.Pruned[PrunedCycler] != 4 {
+		t.Errorf("got %d enumerated and %v pruned", report.Enumerated, report.Pruned)
+	}
+	// Half the machines are mirrors of the others, and those printing `1`'s forever are neither halting nor cyclers
+	if report.Simulated != 20 || report.Halted != 16 || len(report.Holdouts) != 4 {
+		t.Errorf("got %d simulated, %d halted and %d holdouts", report.Simulated, report.Halted, len(report.Holdouts))
+	}
+	if report.Best != 1 || len(report.Champions) != 8 {
+		t.Errorf("got best %d with %d champions", report.Best, len(report.Champions))
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteSearchReport(&b, report); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(b.String(), `"pruned":{"cycler":4,"mirror":24,"no-halt-transition":16}`) {
+			t.Errorf("got %s", b.String())
+		}
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		markdown := report.Markdown()
+		for _, expected := range []string{
+			"| Pruned (cycler) | 4 |\n| Pruned (mirror) | 24 |\n| Pruned (no-halt-transition) | 16 |\n",
+			"## Champions (score 1)",
+			"| `0[ 0:P1;L;halt, 1:P0;R;0 ]` | 1 | 1 | 2 | 1 |",
+		} {
+			if !strings.Contains(markdown, expected) {
+				t.Errorf("got %s, want to contain %q", markdown, expected)
+			}
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package turing
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Landauer showed that computation is only irreversible because information is discarded, and
+// Bennett showed that a machine which keeps a history of its moves can be made reversible. The
+// transformation below keeps the history on the tape itself, so that the reversible machine is an
+// ordinary table the simulator runs, and can be run backwards by inverting its table.
+//
+// Every square of the reversible machine's tape bears three tracks: the symbol of the original
+// machine (the work track), a square of the history (the history track) and a mark used while making
+// a move. A square bearing only a symbol of the original machine bears that symbol, so the reversible
+// machine's tape reads as the original's, with the other squares written `w|h|p`. The history is a
+// stack beginning at the scanned square, holding the number of the transition (see
+// `MachineInput.Transitions`) behind each move made, most recent first, and ending with `$`. Before
+// each move the history is shifted right to make room, and the transition's number is written on
+// the square scanned after the move.
+//
+// The reversible machine is reversible in the sense of Bennett's quadruple machines: each row either
+// scans and writes one symbol, or moves without scanning, and no two rows lead to the same
+// m-configuration unless both write, and write different symbols (see `IsReversible`).
+
+const (
+	// Separates the tracks of a square of a reversible machine's tape
+	reversibleTrackSeparator string = "|"
+
+	// The bottom of the history of a reversible machine
+	reversibleHistoryBottom string = "$"
+)
+
+// Returns a reversible machine computing what the machine computes, with the same m-configurations
+// (and others of its own, named `rev.…`). Each move of the machine takes the reversible machine a
+// number of moves that grows with the length of the history. Every other field (such as the
+// BackgroundPattern) is carried over. The table grows with the square of the amount of transitions,
+// so this is meant for small machines.
+func Reversible(input MachineInput) MachineInput {
+	m := NewMachine(input)
+	transitions := input.Transitions()
+	symbols := append([]string{m.noneSymbol}, machineSymbols(input)...)
+
+	prefix := reversiblePrefix(input)
+	records := []string{}
+	for i := range transitions {
+		records = append(records, strconv.Itoa(i+1))
+	}
+	stack := append(slices.Clone(records), reversibleHistoryBottom)
+
+	mConfigurations := []MConfiguration{}
+	row := func(name string, symbol string, write string, final string) {
+		mConfigurations = append(mConfigurations, MConfiguration{name, []string{symbol}, []string{string(printOp) + write}, final})
+	}
+	shift := func(name string, move operationCode, final string) {
+		mConfigurations = append(mConfigurations, MConfiguration{name, []string{}, []string{string(move)}, final})
+	}
+	carryShift := func(pass int, carried string) string {
+		return fmt.Sprintf("%s.carry%d(%s)", prefix, pass, carried)
+	}
+	carryRead := func(pass int, carried string) string {
+		return fmt.Sprintf("%s.carried%d(%s)", prefix, pass, carried)
+	}
+	returnShift := func(pass int) string {
+		return fmt.Sprintf("%s.return%d", prefix, pass)
+	}
+	returnRead := func(pass int) string {
+		return fmt.Sprintf("%s.returned%d", prefix, pass)
+	}
+
+	// Each transition either moves left onto the square before the history, and writes its number
+	// there, or marks the scanned square with its number and shifts the history right (twice if it
+	// moves right) before doing so
+	for i, transition := range transitions {
+		record, mark := records[i], i+1
+		switch transition.Move {
+		case string(leftOp):
+			moved := fmt.Sprintf("%s.left(%s)", prefix, record)
+			put := fmt.Sprintf("%s.put(%s)", prefix, record)
+			for _, top := range stack {
+				row(transition.State, reversibleSymbol(transition.Read, top, 0), reversibleSymbol(transition.Write, top, 0), moved)
+			}
+			shift(moved, leftOp, put)
+			for _, symbol := range symbols {
+				row(put, symbol, reversibleSymbol(symbol, record, 0), transition.Next)
+			}
+		case string(rightOp):
+			moved := fmt.Sprintf("%s.right(%s)", prefix, record)
+			marked := fmt.Sprintf("%s.mark(%s)", prefix, record)
+			for _, top := range stack {
+				row(transition.State, reversibleSymbol(transition.Read, top, 0), reversibleSymbol(transition.Write, "", mark), carryShift(1, top))
+			}
+			row(returnRead(1), reversibleSymbol(transition.Write, "", mark), transition.Write, moved)
+			shift(moved, rightOp, marked)
+			for _, top := range stack {
+				for _, symbol := range symbols {
+					row(marked, reversibleSymbol(symbol, top, 0), reversibleSymbol(symbol, "", mark), carryShift(2, top))
+				}
+			}
+			for _, symbol := range symbols {
+				row(returnRead(2), reversibleSymbol(symbol, "", mark), reversibleSymbol(symbol, record, 0), transition.Next)
+			}
+		default:
+			for _, top := range stack {
+				row(transition.State, reversibleSymbol(transition.Read, top, 0), reversibleSymbol(transition.Write, "", mark), carryShift(1, top))
+			}
+			row(returnRead(1), reversibleSymbol(transition.Write, "", mark), reversibleSymbol(transition.Write, record, 0), transition.Next)
+		}
+	}
+
+	// Shifts the history right of the marked square one square right, then returns to the mark
+	for _, pass := range []int{1, 2} {
+		for _, carried := range stack {
+			shift(carryShift(pass, carried), rightOp, carryRead(pass, carried))
+		}
+		for _, carried := range records {
+			for _, top := range stack {
+				for _, symbol := range symbols {
+					row(carryRead(pass, carried), reversibleSymbol(symbol, top, 0), reversibleSymbol(symbol, carried, 0), carryShift(pass, top))
+				}
+			}
+		}
+		for _, symbol := range symbols {
+			row(carryRead(pass, reversibleHistoryBottom), symbol, reversibleSymbol(symbol, reversibleHistoryBottom, 0), returnShift(pass))
+		}
+		shift(returnShift(pass), leftOp, returnRead(pass))
+		for _, record := range records {
+			for _, symbol := range symbols {
+				row(returnRead(pass), reversibleSymbol(symbol, record, 0), reversibleSymbol(symbol, record, 0), returnShift(pass))
+			}
+		}
+	}
+
+	// The history begins empty, on the square originally scanned
+	tape := slices.Clone(m.tape)
+	for len(tape) <= m.origin {
+		tape = append(tape, m.backgroundSymbol(len(tape)-m.origin))
+	}
+	tape[m.origin] = reversibleSymbol(tape[m.origin], reversibleHistoryBottom, 0)
+
+	reversible := input
+	reversible.MConfigurations = mConfigurations
+	reversible.Tape = tape
+	reversible.StartingSquare = m.origin
+	reversible.StartingMConfiguration = startingMConfigurationName(input)
+	reversible.PossibleSymbols = nil
+	return reversible
+}
+
+// Returns the symbols of the original machine on a reversible machine's tape (see `Reversible`)
+func ReversibleWorkTape(tape Tape) Tape {
+	work := Tape{}
+	for _, square := range tape {
+		symbol, _, _ := strings.Cut(square, reversibleTrackSeparator)
+		work = append(work, symbol)
+	}
+	return work
+}
+
+// Returns true if the machine is reversible: every row either scans one symbol and prints or erases
+// at most once, or matches every symbol (has no symbols) and moves once to the left or right, an
+// m-configuration with a row of the second kind has no other rows, and no two rows lead to the same
+// m-configuration unless both are of the first kind and write different symbols. Every complete
+// configuration of a reversible machine then follows from at most one other, so the machine can be
+// run backwards (see `Inverse`).
+func IsReversible(input MachineInput) bool {
+	noneSymbol := input.NoneSymbol
+	if len(noneSymbol) == 0 {
+		noneSymbol = none
+	}
+	rows := map[string]int{}
+	shifts := map[string]bool{}
+	// The symbols written by the rows leading to each m-configuration (empty for a move)
+	written := map[string][]string{}
+	for _, mConfiguration := range input.MConfigurations {
+		write, move, ok := quadruple(mConfiguration, noneSymbol)
+		if !ok {
+			return false
+		}
+		rows[mConfiguration.Name]++
+		if len(move) != 0 {
+			shifts[mConfiguration.Name] = true
+		}
+		final := mConfiguration.FinalMConfiguration
+		if slices.Contains(written[final], write) || (len(written[final]) != 0 && (len(write) == 0 || slices.Contains(written[final], ""))) {
+			return false
+		}
+		written[final] = append(written[final], write)
+	}
+	for name := range shifts {
+		if rows[name] > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a machine running the (reversible) machine backwards from its complete configuration: making
+// as many moves as the machine has made returns the tape, the scanned square and the m-configuration
+// to those the machine started with. Returns `ErrNotReversible` if the machine is not reversible
+// (see `IsReversible`).
+func Inverse(m *Machine) (MachineInput, error) {
+	if !IsReversible(MachineInput{MConfigurations: m.mConfigurations, NoneSymbol: m.noneSymbol}) {
+		return MachineInput{}, ErrNotReversible
+	}
+	mConfigurations := []MConfiguration{}
+	for _, mConfiguration := range m.mConfigurations {
+		write, move, _ := quadruple(mConfiguration, m.noneSymbol)
+		inverse := MConfiguration{
+			Name:                mConfiguration.FinalMConfiguration,
+			FinalMConfiguration: mConfiguration.Name,
+		}
+		switch move {
+		case string(leftOp):
+			inverse.Symbols, inverse.Operations = []string{}, []string{string(rightOp)}
+		case string(rightOp):
+			inverse.Symbols, inverse.Operations = []string{}, []string{string(leftOp)}
+		default:
+			inverse.Symbols, inverse.Operations = []string{write}, []string{string(printOp) + mConfiguration.Symbols[0]}
+		}
+		mConfigurations = append(mConfigurations, inverse)
+	}
+	return MachineInput{
+		MConfigurations:        mConfigurations,
+		Tape:                   slices.Clone(m.tape),
+		StartingSquare:         m.scannedSquare,
+		StartingMConfiguration: m.currentMConfigurationName,
+		NoneSymbol:             m.noneSymbol,
+	}, nil
+}
+
+// Performs Bennett's compute-copy-uncompute construction: the reversible form of the machine (see
+// `Reversible`) computes for `n` moves, the symbols of the original machine on its tape are copied,
+// and then the computation is undone by running it backwards (see `Inverse`), leaving the tape as it
+// began. Returns the copy, or an error wrapping `ErrNotReversible` if undoing the computation does not
+// restore the tape, the scanned square and the m-configuration the reversible machine began with.
+func Bennett(input MachineInput, n int) (Tape, error) {
+	reversible := Reversible(input)
+	m := NewMachine(reversible)
+	moves := m.MoveN(n)
+	output := ReversibleWorkTape(m.tape)
+	inverse, err := Inverse(m)
+	if err != nil {
+		return nil, err
+	}
+	backwards := NewMachine(inverse)
+	if undone := backwards.MoveN(moves); undone != moves || backwards.err != nil {
+		return nil, fmt.Errorf("%w: uncomputing stopped after %d of %d moves", ErrNotReversible, undone, moves)
+	}
+	if err := checkUncomputed(NewMachine(reversible), backwards); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Returns an error wrapping `ErrNotReversible` unless the machine run backwards is in the complete
+// configuration the machine started in: the same m-configuration, and the same symbols on every square
+// relative to the scanned square
+func checkUncomputed(start *Machine, backwards *Machine) error {
+	if backwards.currentMConfigurationName != start.currentMConfigurationName {
+		return fmt.Errorf("%w: uncomputing ended in m-configuration %s, not %s", ErrNotReversible,
+			backwards.currentMConfigurationName, start.currentMConfigurationName)
+	}
+	for i, symbol := range backwards.tape {
+		square := i - backwards.scannedSquare
+		if expected := start.Square(square); symbol != expected {
+			return fmt.Errorf("%w: uncomputing left %q on square %d, not %q", ErrNotReversible, symbol, square, expected)
+		}
+	}
+	return nil
+}
+
+// Returns the symbol written (or an empty string) and the move made (or an empty string) by a row of
+// a reversible machine, and whether the row has the form of one (see `IsReversible`)
+func quadruple(mConfiguration MConfiguration, noneSymbol string) (string, string, bool) {
+	operations := mConfiguration.Operations
+	if len(mConfiguration.Symbols) == 0 {
+		if len(operations) != 1 || (operations[0] != string(leftOp) && operations[0] != string(rightOp)) {
+			return "", "", false
+		}
+		return "", operations[0], true
+	}
+	if len(mConfiguration.Symbols) != 1 || len(operations) > 1 {
+		return "", "", false
+	}
+	symbol := mConfiguration.Symbols[0]
+	if symbol == any || strings.Contains(symbol, not) {
+		return "", "", false
+	}
+	if len(operations) == 0 {
+		return symbol, "", true
+	}
+	switch operationCode(operations[0][0]) {
+	case printOp:
+		return operations[0][1:], "", true
+	case eraseOp:
+		return noneSymbol, "", true
+	}
+	return "", "", false
+}
+
+// Returns a square of a reversible machine's tape bearing the symbol, the square of the history (if
+// any) and the mark (if any)
+func reversibleSymbol(symbol string, history string, mark int) string {
+	if len(history) == 0 && mark == 0 {
+		return symbol
+	}
+	marked := ""
+	if mark != 0 {
+		marked = strconv.Itoa(mark)
+	}
+	return strings.Join([]string{symbol, history, marked}, reversibleTrackSeparator)
+}
+
+// Returns a prefix for the names of the reversible machine's own m-configurations that no
+// m-configuration of the machine begins with
+func reversiblePrefix(input MachineInput) string {
+	prefix := "rev"
+	for slices.ContainsFunc(input.MConfigurations, func(mConfiguration MConfiguration) bool {
+		return strings.HasPrefix(mConfiguration.Name, prefix+".") || strings.HasPrefix(mConfiguration.FinalMConfiguration, prefix+".")
+	}) {
+		prefix += "'"
+	}
+	return prefix
+}
@@ -0,0 +1,139 @@
+package turing
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestReversible(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"*", " "}, []string{"Pe", "R", "Pe", "R", "P0", "R", "R", "P0", "L", "L"}, "o"},
+			{"o", []string{"1"}, []string{"R", "Px", "L", "L", "L"}, "o"},
+			{"o", []string{"0"}, []string{}, "q"},
+			{"q", []string{"0", "1"}, []string{"R", "R"}, "q"},
+			{"q", []string{" "}, []string{"P1", "L"}, "p"},
+			{"p", []string{"x"}, []string{"E", "R"}, "q"},
+			{"p", []string{"e"}, []string{"R"}, "f"},
+			{"p", []string{" "}, []string{"L", "L"}, "p"},
+			{"f", []string{"*"}, []string{"R", "R"}, "f"},
+			{"f", []string{" "}, []string{"P0", "L", "L"}, "o"},
+		},
+		PossibleSymbols: []string{"0", "1", "e", "x"},
+	}
+	if IsReversible(input) {
+		t.Errorf("got reversible, want the machine not to be")
+	}
+	reversible := Reversible(input)
+	if !IsReversible(reversible) {
+		t.Fatalf("got a machine that is not reversible")
+	}
+
+	// Each transition of the original machine leaves a square of history
+	m := NewMachine(reversible)
+	m.MoveN(20000)
+	var history int
+	for _, square := range m.Tape() {
+		if track := strings.Split(square, "|"); len(track) == 3 && track[1] != "$" {
+			history++
+		}
+	}
+	original := NewMachine(MachineInput{
+		MConfigurations: MConfigurationsFromTransitions(input.Transitions()),
+		PossibleSymbols: input.PossibleSymbols,
+	})
+	original.MoveN(history)
+	turingtest.Equal(t, strings.TrimRight(strings.Join(ReversibleWorkTape(m.Tape()), ""), " "), strings.TrimRight(original.TapeString(), " "))
+	turingtest.TapePrefix(t, strings.Join(ReversibleWorkTape(m.Tape()), ""), "ee0 0 1 0 1")
+}
+
+func TestInverse(t *testing.T) {
+	reversible := Reversible(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"0"}, []string{"P1", "L"}, "c"},
+			{"b", []string{"1", " "}, []string{"P0", "R"}, "b"},
+			{"c", []string{"*", " "}, []string{"R", "R"}, "b"},
+		},
+		Tape:           Tape{"1", "1", "0", "1"},
+		StartingSquare: 1,
+	})
+	m := NewMachine(reversible)
+	moves := m.MoveN(500)
+	inverse, err := Inverse(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsReversible(inverse) {
+		t.Errorf("got an inverse that is not reversible")
+	}
+
+	backwards := NewMachine(inverse)
+	backwards.MoveN(moves)
+	if backwards.CurrentMConfiguration() != "b" {
+		t.Errorf("got %s, want b", backwards.CurrentMConfiguration())
+	}
+	tape := slices.Clone(backwards.Tape()[backwards.scannedSquare-1:])
+	for len(tape) > 0 && tape[len(tape)-1] == " " {
+		tape = tape[:len(tape)-1]
+	}
+	if !slices.Equal(tape, reversible.Tape) {
+		t.Errorf("got %q, want %q", tape, reversible.Tape)
+	}
+
+}
+
+func TestBennett(t *testing.T) {
+	output, err := Bennett(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	turingtest.TapePrefix(t, strings.Join(output, ""), "0 1 0 1")
+
+	t.Run("Uncomputed", func(t *testing.T) {
+		reversible := Reversible(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"P1", "R"}, "b"},
+			},
+		})
+		m := NewMachine(reversible)
+		moves := m.MoveN(300)
+		inverse, err := Inverse(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		backwards := NewMachine(inverse)
+		backwards.MoveN(moves)
+		// The history is gone, and the tape is as it began
+		if err := checkUncomputed(NewMachine(reversible), backwards); err != nil {
+			t.Error(err)
+		}
+		for i, square := range backwards.Tape() {
+			expected := none
+			if i == backwards.scannedSquare {
+				expected = reversibleSymbol(none, reversibleHistoryBottom, 0)
+			}
+			if square != expected {
+				t.Errorf("got %q on square %d, want %q", square, i-backwards.scannedSquare, expected)
+			}
+		}
+
+		// A run not fully undone is caught
+		backwards = NewMachine(inverse)
+		backwards.MoveN(moves - 1)
+		if err := checkUncomputed(NewMachine(reversible), backwards); !errors.Is(err, ErrNotReversible) {
+			t.Errorf("got %v, want %v", err, ErrNotReversible)
+		}
+	})
+}
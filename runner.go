@@ -0,0 +1,60 @@
+package turing
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Drives a machine move by move, optionally at a limited pace, so front-ends can animate it at
+	// human speeds (see also `Machine.Run`)
+	Runner struct {
+		// The machine being run
+		Machine *Machine
+
+		// If positive, the most moves made each second. Otherwise moves are made as fast as possible.
+		MovesPerSecond float64
+
+		// If provided, called after every move
+		OnMove func(m *Machine)
+	}
+)
+
+// Returns a runner making at most `movesPerSecond` moves each second (see `MovesPerSecond`)
+func NewRunner(m *Machine, movesPerSecond float64) *Runner {
+	return &Runner{
+		Machine:        m,
+		MovesPerSecond: movesPerSecond,
+	}
+}
+
+// Moves the machine until it halts, it has made `maxMoves` moves, or the context is done. Returns
+// the amount of moves made, and the context's error if it was done first.
+func (r *Runner) Run(ctx context.Context, maxMoves int) (int, error) {
+	var tick <-chan time.Time
+	if r.MovesPerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / r.MovesPerSecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	moves := 0
+	for moves < maxMoves && !r.Machine.halted {
+		if err := ctx.Err(); err != nil {
+			return moves, err
+		}
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return moves, ctx.Err()
+			case <-tick:
+			}
+		}
+		r.Machine.Move()
+		moves++
+		if r.OnMove != nil {
+			r.OnMove(r.Machine)
+		}
+	}
+	return moves, nil
+}
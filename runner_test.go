@@ -0,0 +1,55 @@
+package turing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+
+	t.Run("MovesPerSecond", func(t *testing.T) {
+		runner := NewRunner(NewMachine(input), 200)
+		tapes := []string{}
+		runner.OnMove = func(m *Machine) {
+			tapes = append(tapes, m.TapeString())
+		}
+		start := time.Now()
+		moves, err := runner.Run(context.Background(), 20)
+		if err != nil || moves != 20 {
+			t.Errorf("got %d moves and %v, want 20 moves", moves, err)
+		}
+		// 20 moves at 200 moves a second take at least 100 milliseconds
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("took %v, want at least 100ms", elapsed)
+		}
+		if len(tapes) != 20 || tapes[3] != "0 1 " {
+			t.Errorf("got tapes %q", tapes)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		moves, err := NewRunner(NewMachine(input), 100).Run(ctx, 1000)
+		if !errors.Is(err, context.DeadlineExceeded) || moves >= 1000 {
+			t.Errorf("got %d moves and %v, want the deadline to be exceeded", moves, err)
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		moves, err := NewRunner(NewMachine(input), 0).Run(context.Background(), 100000)
+		if err != nil || moves != 100000 {
+			t.Errorf("got %d moves and %v, want 100000 moves", moves, err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package turing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+type (
+	// Wraps serialized data with the kind of data and the version of its format, so that data
+	// written by older versions of this package can be migrated when read
+	Envelope struct {
+		// The kind of data (i.e. `MachineInputKind`)
+		Kind string `json:"kind"`
+
+		// The version of the format the data was written in
+		Version int `json:"version"`
+
+		// The serialized data
+		Data json.RawMessage `json:"data"`
+	}
+
+	// Rewrites data of one format version as the next version
+	Migration func(data json.RawMessage) (json.RawMessage, error)
+)
+
+const (
+	// The kind of a serialized MachineInput
+	MachineInputKind string = "machine"
+
+	// The current version of the MachineInput format
+	MachineInputVersion int = 1
+
+	// The kind of a serialized RunRecording
+	RunRecordingKind string = "recording"
+)
+
+var (
+	migrations      = map[string]map[int]Migration{}
+	migrationsMutex sync.RWMutex
+)
+
+// Registers a migration of the kind of data from the version `from` to the version after it.
+// Versions without a registered migration are assumed to be compatible with the next.
+func RegisterMigration(kind string, from int, migration Migration) {
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+	if migrations[kind] == nil {
+		migrations[kind] = map[int]Migration{}
+	}
+	migrations[kind][from] = migration
+}
+
+// Migrates the data from its version to the current version. An error wrapping
+// `ErrUnsupportedVersion` is returned if the data is from a newer (or invalid) version.
+func migrate(kind string, version int, current int, data json.RawMessage) (json.RawMessage, error) {
+	if version < 1 || version > current {
+		return nil, fmt.Errorf("%w: %s version %d", ErrUnsupportedVersion, kind, version)
+	}
+	migrationsMutex.RLock()
+	defer migrationsMutex.RUnlock()
+	for ; version < current; version++ {
+		migration, ok := migrations[kind][version]
+		if !ok {
+			continue
+		}
+		var err error
+		if data, err = migration(data); err != nil {
+			return nil, fmt.Errorf("migrating %s from version %d: %w", kind, version, err)
+		}
+	}
+	return data, nil
+}
+
+// Writes the MachineInput as JSON, in an Envelope
+func WriteMachineInput(w io.Writer, input MachineInput) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(Envelope{
+		Kind:    MachineInputKind,
+		Version: MachineInputVersion,
+		Data:    data,
+	})
+}
+
+// Reads a MachineInput written by `WriteMachineInput`, migrating it if it was written in an
+// older format. An error wrapping `ErrUnsupportedVersion` is returned if it was written in a newer format.
+func ReadMachineInput(r io.Reader) (MachineInput, error) {
+	var envelope Envelope
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return MachineInput{}, err
+	}
+	if envelope.Kind != MachineInputKind {
+		return MachineInput{}, fmt.Errorf("expected %s, got %q", MachineInputKind, envelope.Kind)
+	}
+	data, err := migrate(envelope.Kind, envelope.Version, MachineInputVersion, envelope.Data)
+	if err != nil {
+		return MachineInput{}, err
+	}
+	var input MachineInput
+	err = json.Unmarshal(data, &input)
+	return input, err
+}
@@ -0,0 +1,70 @@
+package turing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMachineInputSerialization(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "b"},
+		},
+		Tape:           Tape{"0"},
+		StartingSquare: 1,
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteMachineInput(&buffer, input); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadMachineInput(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, input) {
+		t.Errorf("got %v, want %v", read, input)
+	}
+
+	_, err = ReadMachineInput(strings.NewReader(`{"kind": "machine", "version": 99, "data": {}}`))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("got %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := ReadMachineInput(strings.NewReader(`{"kind": "recording", "version": 1, "data": {}}`)); err == nil {
+		t.Error("got no error for a recording, want one")
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	// Version 1 called the field `a`, version 2 `b` and version 3 `c`
+	rename := func(from string, to string) Migration {
+		return func(data json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(strings.Replace(string(data), `"`+from+`"`, `"`+to+`"`, 1)), nil
+		}
+	}
+	RegisterMigration("test", 1, rename("a", "b"))
+	RegisterMigration("test", 2, rename("b", "c"))
+
+	for version, data := range map[int]string{1: `{"a":1}`, 2: `{"b":1}`, 3: `{"c":1}`} {
+		migrated, err := migrate("test", version, 3, json.RawMessage(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(migrated) != `{"c":1}` {
+			t.Errorf("got %s from version %d, want {\"c\":1}", migrated, version)
+		}
+	}
+
+	failing := errors.New("failing")
+	RegisterMigration("failing", 1, func(json.RawMessage) (json.RawMessage, error) {
+		return nil, failing
+	})
+	if _, err := migrate("failing", 1, 2, nil); !errors.Is(err, failing) {
+		t.Errorf("got %v, want %v", err, failing)
+	}
+}
@@ -0,0 +1,194 @@
+package turing
+
+import (
+	"iter"
+	"sync"
+)
+
+type (
+	// A batch of candidate machines of a busy beaver search
+	SearchBatch struct {
+		// Identifies the batch, so its result can be matched to it
+		ID int `json:"id"`
+
+		// The candidates
+		Machines []MachineInput `json:"machines"`
+	}
+
+	// The result of searching a batch
+	SearchResult struct {
+		// The ID of the batch searched
+		BatchID int `json:"batchId"`
+
+		// The best score of the batch's candidates
+		Best int `json:"best"`
+
+		// The candidate with the best score (the first, if several have it)
+		Machine MachineInput `json:"machine"`
+
+		// The amount of candidates searched
+		Searched int `json:"searched"`
+	}
+
+	// Provides batches of candidates to search. Implementations may read them from any queue
+	// (a file, an HTTP endpoint, a channel) so a search can be split across processes and hosts.
+	WorkSource interface {
+		// Returns the next batch, or false once there are none left
+		Next() (SearchBatch, bool, error)
+	}
+
+	// Accepts the results of searched batches. Like a WorkSource, implementations may push them to
+	// any queue.
+	ResultSink interface {
+		Push(result SearchResult) error
+	}
+
+	// Adapts a function to a WorkSource
+	WorkSourceFunc func() (SearchBatch, bool, error)
+
+	// Adapts a function to a ResultSink
+	ResultSinkFunc func(result SearchResult) error
+
+	// A ResultSink keeping the best result pushed to it. Safe for concurrent use.
+	BestResultSink struct {
+		mutex  sync.Mutex
+		best   SearchResult
+		pushed int
+	}
+
+	// A WorkSource of enumerated machines (see `EnumerationSource`). Safe for concurrent use.
+	EnumeratedSource struct {
+		mutex     sync.Mutex
+		next      func() (MachineInput, bool)
+		stop      func()
+		batchSize int
+		id        int
+	}
+)
+
+func (f WorkSourceFunc) Next() (SearchBatch, bool, error) {
+	return f()
+}
+
+func (f ResultSinkFunc) Push(result SearchResult) error {
+	return f(result)
+}
+
+// Returns a WorkSource of every busy beaver candidate with `n` m-configurations (machines over
+// `0` and `1` with at least one transition to `halt`), in batches of at most `batchSize`. The
+// enumeration runs in a goroutine of its own until the source is exhausted or closed, so a source that
+// is abandoned early must be closed.
+func EnumerationSource(n int, batchSize int) *EnumeratedSource {
+	next, stop := iter.Pull(func(yield func(MachineInput) bool) {
+		enumerateMachines(n, []string{"0", "1"}, true, func(mConfigurations []MConfiguration) bool {
+			if !atLeastOneHaltState(mConfigurations) {
+				return true
+			}
+			return yield(getBusyBeaverMachineInput(cloneMConfigurations(mConfigurations)))
+		})
+	})
+	return &EnumeratedSource{
+		next:      next,
+		stop:      stop,
+		batchSize: max(batchSize, 1),
+	}
+}
+
+// Returns the amount of candidates `EnumerationSource` provides for `n` m-configurations: every machine,
+// except those without a transition to `halt`
+func EnumerationSourceSize(n int) int {
+	symbols := []string{"0", "1"}
+	return enumerationSize(n, len(symbols), len(quintupleChoices(enumerationFinals(n, true), symbols))) -
+		enumerationSize(n, len(symbols), len(quintupleChoices(enumerationFinals(n, false), symbols)))
+}
+
+func (s *EnumeratedSource) Next() (SearchBatch, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	batch := SearchBatch{
+		ID:       s.id,
+		Machines: []MachineInput{},
+	}
+	for len(batch.Machines) < s.batchSize {
+		machine, ok := s.next()
+		if !ok {
+			s.stop()
+			break
+		}
+		batch.Machines = append(batch.Machines, machine)
+	}
+	if len(batch.Machines) == 0 {
+		return SearchBatch{}, false, nil
+	}
+	s.id++
+	return batch, true, nil
+}
+
+// Stops the enumeration. Later calls to `Next` return no batches.
+func (s *EnumeratedSource) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stop()
+	return nil
+}
+
+// Returns a WorkSource reading batches from the channel until it is closed
+func ChannelSource(batches <-chan SearchBatch) WorkSource {
+	return WorkSourceFunc(func() (SearchBatch, bool, error) {
+		batch, ok := <-batches
+		return batch, ok, nil
+	})
+}
+
+// Returns a ResultSink writing results to the channel
+func ChannelSink(results chan<- SearchResult) ResultSink {
+	return ResultSinkFunc(func(result SearchResult) error {
+		results <- result
+		return nil
+	})
+}
+
+// Searches batches from the source until it is exhausted, scoring each candidate after at most
+// `limit` moves, and pushes the result of each batch to the sink. Measurements are looked up in
+// the cache, if not nil. Any number of workers may share a source and sink.
+func SearchWorker(source WorkSource, sink ResultSink, score Score, limit int, cache *MeasureCache) error {
+	for {
+		batch, ok, err := source.Next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		result := SearchResult{
+			BatchID:  batch.ID,
+			Searched: len(batch.Machines),
+		}
+		for i, machine := range batch.Machines {
+			if s := score(cache.Measure(machine, limit)); i == 0 || s > result.Best {
+				result.Best = s
+				result.Machine = machine
+			}
+		}
+		if err := sink.Push(result); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *BestResultSink) Push(result SearchResult) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.pushed == 0 || result.Best > s.best.Best {
+		s.best = result
+	}
+	s.pushed++
+	return nil
+}
+
+// Returns the best result pushed so far, and the amount of results pushed
+func (s *BestResultSink) Best() (SearchResult, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.best, s.pushed
+}
@@ -0,0 +1,68 @@
+package turing
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSearchWorker(t *testing.T) {
+	// Several workers sharing a single source
+	source := EnumerationSource(1, 10)
+	sink := &BestResultSink{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := SearchWorker(source, sink, OnesScore, defaultBusyBeaverMaxMoves, nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	best, pushed := sink.Best()
+	if best.Best != 1 {
+		t.Errorf("Incorrect BB-1 number %d, expected 1", best.Best)
+	}
+	// The 48 machines with a transition to `halt`, in batches of 10
+	if pushed != 5 {
+		t.Errorf("got %d results, want 5", pushed)
+	}
+	if actual := OnesScore(Measure(best.Machine, defaultBusyBeaverMaxMoves)); actual != 1 {
+		t.Errorf("got best machine printing %d, want 1", actual)
+	}
+}
+
+func TestEnumerationSourceClose(t *testing.T) {
+	source := EnumerationSource(2, 10)
+	if _, ok, _ := source.Next(); !ok {
+		t.Fatal("got no batch, want one")
+	}
+	if err := source.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if batch, ok, _ := source.Next(); ok {
+		t.Errorf("got batch %d after closing, want none", batch.ID)
+	}
+}
+
+func TestChannelSourceAndSink(t *testing.T) {
+	batches := make(chan SearchBatch, 2)
+	results := make(chan SearchResult, 2)
+	batches <- SearchBatch{ID: 7, Machines: []MachineInput{
+		getBusyBeaverMachineInput([]MConfiguration{
+			{"A", []string{"0"}, []string{"P1", "R"}, "halt"},
+			{"A", []string{"1"}, []string{"P1", "R"}, "halt"},
+		}),
+	}}
+	close(batches)
+
+	if err := SearchWorker(ChannelSource(batches), ChannelSink(results), MovesScore, defaultBusyBeaverMaxMoves, nil); err != nil {
+		t.Fatal(err)
+	}
+	result := <-results
+	if result.BatchID != 7 || result.Best != 1 || result.Searched != 1 {
+		t.Errorf("got %+v", result)
+	}
+}
@@ -0,0 +1,177 @@
+package turing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+type (
+	// Runs machines. Implemented natively by this package (see `NativeSimulator`), and by other
+	// simulators through adapters (see `CommandSimulator` and `MorphettSimulator`), so their results
+	// can be compared (see `CompareSimulators`).
+	Simulator interface {
+		// Runs the machine for at most `maxMoves` moves (stopping early if it halts)
+		Simulate(input MachineInput, maxMoves int) (Simulation, error)
+	}
+
+	// Adapts a function to a Simulator
+	SimulatorFunc func(input MachineInput, maxMoves int) (Simulation, error)
+
+	// The outcome of running a machine
+	Simulation struct {
+		// The amount of moves the machine made
+		Moves int `json:"moves"`
+
+		// Whether the machine halted
+		Halted bool `json:"halted"`
+
+		// The m-configuration the machine stopped in
+		MConfiguration string `json:"mConfiguration"`
+
+		// The scanned square, relative to the square originally scanned
+		Head int `json:"head"`
+
+		// The tape, as a string
+		Tape string `json:"tape"`
+
+		// If provided, the complete configuration after each move (see `CompleteConfiguration`)
+		Trace []string `json:"trace,omitempty"`
+	}
+
+	// Runs machines with this package's Machine
+	NativeSimulator struct {
+		// If `true`, the Simulation includes a trace
+		Trace bool
+	}
+
+	// Runs machines with an external program. The program is given a SimulationRequest as JSON
+	// on its standard input, and must write a Simulation as JSON to its standard output
+	// (`ServeSimulation` is an example).
+	CommandSimulator struct {
+		// The program to run
+		Path string
+
+		// The program's arguments
+		Args []string
+	}
+
+	// The input of an external simulator (see `CommandSimulator`)
+	SimulationRequest struct {
+		Machine  MachineInput `json:"machine"`
+		MaxMoves int          `json:"maxMoves"`
+	}
+)
+
+func (f SimulatorFunc) Simulate(input MachineInput, maxMoves int) (Simulation, error) {
+	return f(input, maxMoves)
+}
+
+func (s NativeSimulator) Simulate(input MachineInput, maxMoves int) (Simulation, error) {
+	m := NewMachine(input)
+	simulation := Simulation{}
+	for i := 0; i < maxMoves && !m.halted; i++ {
+		moves := m.moves
+		m.Move()
+		if s.Trace && m.moves != moves {
+			simulation.Trace = append(simulation.Trace, m.CompleteConfiguration())
+		}
+	}
+	simulation.Moves = m.moves
+	simulation.Halted = m.halted
+	simulation.MConfiguration = m.currentMConfigurationName
+	simulation.Head = m.Head()
+	simulation.Tape = m.TapeString()
+	return simulation, nil
+}
+
+func (s CommandSimulator) Simulate(input MachineInput, maxMoves int) (Simulation, error) {
+	request, err := json.Marshal(SimulationRequest{Machine: input, MaxMoves: maxMoves})
+	if err != nil {
+		return Simulation{}, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(s.Path, s.Args...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Simulation{}, fmt.Errorf("%s: %w: %s", s.Path, err, strings.TrimSpace(stderr.String()))
+	}
+	var simulation Simulation
+	if err := json.Unmarshal(stdout.Bytes(), &simulation); err != nil {
+		return Simulation{}, fmt.Errorf("%s: %w", s.Path, err)
+	}
+	return simulation, nil
+}
+
+// Reads a SimulationRequest, runs it with the simulator, and writes the Simulation (the protocol
+// of `CommandSimulator`)
+func ServeSimulation(r io.Reader, w io.Writer, simulator Simulator) error {
+	var request SimulationRequest
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return err
+	}
+	simulation, err := simulator.Simulate(request.Machine, request.MaxMoves)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(simulation)
+}
+
+// Runs the machine with both simulators and returns an error wrapping `ErrSimulatorMismatch` if
+// they disagree. Tapes are compared without blanks at either end, and traces only if both
+// simulators provide them.
+func CompareSimulators(a, b Simulator, input MachineInput, maxMoves int) error {
+	simulationA, err := a.Simulate(input, maxMoves)
+	if err != nil {
+		return err
+	}
+	simulationB, err := b.Simulate(input, maxMoves)
+	if err != nil {
+		return err
+	}
+
+	blank := input.NoneSymbol
+	if len(blank) == 0 {
+		blank = none
+	}
+	mismatch := func(what string, valueA, valueB interface{}) error {
+		return fmt.Errorf("%w: %s %v != %v", ErrSimulatorMismatch, what, valueA, valueB)
+	}
+	switch {
+	case simulationA.Moves != simulationB.Moves:
+		return mismatch("moves", simulationA.Moves, simulationB.Moves)
+	case simulationA.Halted != simulationB.Halted:
+		return mismatch("halted", simulationA.Halted, simulationB.Halted)
+	case simulationA.MConfiguration != simulationB.MConfiguration:
+		return mismatch("m-configuration", simulationA.MConfiguration, simulationB.MConfiguration)
+	case simulationA.Head != simulationB.Head:
+		return mismatch("head", simulationA.Head, simulationB.Head)
+	case trimBlank(simulationA.Tape, blank) != trimBlank(simulationB.Tape, blank):
+		return mismatch("tape", trimBlank(simulationA.Tape, blank), trimBlank(simulationB.Tape, blank))
+	}
+	if len(simulationA.Trace) != 0 && len(simulationB.Trace) != 0 {
+		for i := 0; i < min(len(simulationA.Trace), len(simulationB.Trace)); i++ {
+			if simulationA.Trace[i] != simulationB.Trace[i] {
+				return mismatch(fmt.Sprintf("move %d", i+1), simulationA.Trace[i], simulationB.Trace[i])
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the tape without blank squares at either end. The blank may be longer than a character, so
+// it is removed whole rather than as a set of characters.
+func trimBlank(tape string, blank string) string {
+	for strings.HasPrefix(tape, blank) {
+		tape = strings.TrimPrefix(tape, blank)
+	}
+	for strings.HasSuffix(tape, blank) {
+		tape = strings.TrimSuffix(tape, blank)
+	}
+	return tape
+}
@@ -0,0 +1,93 @@
+package turing
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+var simulatorExample = MachineInput{
+	MConfigurations: []MConfiguration{
+		{"b", []string{" "}, []string{"P0", "R"}, "c"},
+		{"c", []string{" "}, []string{"R"}, "e"},
+		{"e", []string{" "}, []string{"P1", "R"}, "k"},
+		{"k", []string{" "}, []string{"R"}, "b"},
+	},
+}
+
+// Not a real test. Serves simulations when run by `TestCommandSimulator`.
+func TestHelperSimulator(t *testing.T) {
+	if os.Getenv("TURING_HELPER_SIMULATOR") != "1" {
+		t.Skip("only run as a helper process")
+	}
+	if err := ServeSimulation(os.Stdin, os.Stdout, NativeSimulator{Trace: true}); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestCommandSimulator(t *testing.T) {
+	t.Setenv("TURING_HELPER_SIMULATOR", "1")
+	external := CommandSimulator{Path: os.Args[0], Args: []string{"-test.run=^TestHelperSimulator$"}}
+
+	simulation, err := external.Simulate(simulatorExample, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulation.Moves != 10 || len(simulation.Trace) != 10 {
+		t.Errorf("got %d moves and %d traced, want 10", simulation.Moves, len(simulation.Trace))
+	}
+	if err := CompareSimulators(NativeSimulator{Trace: true}, external, simulatorExample, 10); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompareSimulators(t *testing.T) {
+	// Disagrees with the native simulator about the last figure
+	faulty := SimulatorFunc(func(input MachineInput, maxMoves int) (Simulation, error) {
+		simulation, err := NativeSimulator{}.Simulate(input, maxMoves)
+		simulation.Tape = simulation.Tape[:len(simulation.Tape)-1] + "x"
+		return simulation, err
+	})
+	if err := CompareSimulators(NativeSimulator{}, faulty, simulatorExample, 3); !errors.Is(err, ErrSimulatorMismatch) {
+		t.Errorf("got %v, want ErrSimulatorMismatch", err)
+	}
+
+	failing := errors.New("failing")
+	broken := SimulatorFunc(func(MachineInput, int) (Simulation, error) {
+		return Simulation{}, failing
+	})
+	if err := CompareSimulators(NativeSimulator{}, broken, simulatorExample, 3); !errors.Is(err, failing) {
+		t.Errorf("got %v, want %v", err, failing)
+	}
+
+	if _, err := (CommandSimulator{Path: "/nonexistent/simulator"}).Simulate(simulatorExample, 3); err == nil {
+		t.Error("got no error for a missing program, want one")
+	}
+}
+
+func TestCompareSimulatorsLongBlank(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"S0"}, []string{"PS00", "R"}, "b"},
+		},
+		NoneSymbol: "S0",
+	}
+	// Only whole blanks are trimmed, so `S00` is not mistaken for blanks
+	padded := SimulatorFunc(func(input MachineInput, maxMoves int) (Simulation, error) {
+		simulation, err := NativeSimulator{}.Simulate(input, maxMoves)
+		simulation.Tape = "S0" + simulation.Tape + "S0S0"
+		return simulation, err
+	})
+	if err := CompareSimulators(NativeSimulator{}, padded, input, 3); err != nil {
+		t.Error(err)
+	}
+	shortened := SimulatorFunc(func(input MachineInput, maxMoves int) (Simulation, error) {
+		simulation, err := NativeSimulator{}.Simulate(input, maxMoves)
+		simulation.Tape = simulation.Tape[:len(simulation.Tape)-1]
+		return simulation, err
+	})
+	if err := CompareSimulators(NativeSimulator{}, shortened, input, 3); !errors.Is(err, ErrSimulatorMismatch) {
+		t.Errorf("got %v, want %v", err, ErrSimulatorMismatch)
+	}
+}
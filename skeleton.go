@@ -0,0 +1,131 @@
+package turing
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+)
+
+type (
+	// A collection of skeleton tables (m-functions), which may be loaded at runtime from textual
+	// tables (see `CompileAssembly`) and added to an abbreviated table's m-configurations
+	SkeletonLibrary struct {
+		// The m-configurations of each m-function, by its name and amount of parameters
+		mFunctions map[string][]MConfiguration
+
+		// The keys of `mFunctions`, in the order they were first registered
+		order []string
+	}
+)
+
+// Returns an empty SkeletonLibrary
+func NewSkeletonLibrary() *SkeletonLibrary {
+	return &SkeletonLibrary{
+		mFunctions: map[string][]MConfiguration{},
+		order:      []string{},
+	}
+}
+
+// Registers the m-configurations. An m-function already in the library (with the same name and
+// amount of parameters) is replaced by the m-configurations defining it here.
+func (l *SkeletonLibrary) Register(mConfigurations ...MConfiguration) {
+	replaced := []string{}
+	for _, mConfiguration := range mConfigurations {
+		key := skeletonKey(mConfiguration.Name)
+		if !slices.Contains(replaced, key) {
+			replaced = append(replaced, key)
+			if _, ok := l.mFunctions[key]; !ok {
+				l.order = append(l.order, key)
+			}
+			l.mFunctions[key] = []MConfiguration{}
+		}
+		l.mFunctions[key] = append(l.mFunctions[key], mConfiguration)
+	}
+}
+
+// Compiles the source (see `CompileAssembly`) and registers its m-configurations. Other sources
+// may be included with `resolve`. Only the rules of the source are used.
+func (l *SkeletonLibrary) Load(source string, resolve AssemblyResolver) error {
+	input, err := CompileAssembly(source, resolve)
+	if err != nil {
+		return err
+	}
+	l.Register(input.MConfigurations...)
+	return nil
+}
+
+// Reads the file and registers its m-configurations (see `Load`). Files it includes are
+// resolved relative to the working directory.
+func (l *SkeletonLibrary) LoadFile(path string) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := l.Load(string(source), readAssemblyFile); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Returns the m-configurations of every m-function in the library, in the order they were registered
+func (l *SkeletonLibrary) MConfigurations() []MConfiguration {
+	mConfigurations := []MConfiguration{}
+	for _, key := range l.order {
+		mConfigurations = append(mConfigurations, cloneMConfigurations(l.mFunctions[key])...)
+	}
+	return mConfigurations
+}
+
+// Returns the m-functions in the library, in the form `name/n` (where `n` is the amount of parameters)
+func (l *SkeletonLibrary) Names() []string {
+	return slices.Clone(l.order)
+}
+
+// Returns `name/n` for the m-function, where `n` is its amount of parameters
+func skeletonKey(mFunction string) string {
+	name, params := parseMFunction(mFunction)
+	return name + "/" + strconv.Itoa(len(params))
+}
+
+// Resolves included assembly from the file system
+func readAssemblyFile(name string) (string, error) {
+	source, err := os.ReadFile(name)
+	return string(source), err
+}
+
+// Returns the rows of a new m-function, `head` (i.e. `fx(C, B)`), defined as calling an m-function of
+// `mConfigurations` with some of its parameters fixed, `body` (i.e. `f(C, B, x)`). The new m-function's
+// rows are those of the called m-function with its parameters substituted, so it takes no extra move,
+// and calls to the called m-function with the same arguments are made to the new m-function instead.
+// An error wrapping `ErrUnknownMFunction` is returned if the called m-function is not defined.
+func PartiallyApply(mConfigurations []MConfiguration, head string, body string) ([]MConfiguration, error) {
+	name, args := parseMFunction(body)
+	at := &abbreviatedTable{input: AbbreviatedTableInput{MConfigurations: mConfigurations}}
+	mFunctions := at.findMFunctions(name, len(args))
+	if len(mFunctions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMFunction, body)
+	}
+	applied := composeMFunction(name, at.substituteFinalMConfigurationParams(args, nil))
+
+	rows := []MConfiguration{}
+	for _, mFunction := range mFunctions {
+		_, params := parseMFunction(mFunction.Name)
+		substitutions := createSubstitutionMap(params, args)
+		finalName, finalParams := parseMFunction(mFunction.FinalMConfiguration)
+		final := at.substituteFinalMConfigurationName(finalName, substitutions)
+		if len(finalParams) != 0 {
+			final = composeMFunction(final, at.substituteFinalMConfigurationParams(finalParams, substitutions))
+		}
+		if final == applied {
+			final = head
+		}
+		rows = append(rows, MConfiguration{
+			Name:                head,
+			Symbols:             at.substituteSymbols(mFunction.Symbols, substitutions),
+			Operations:          at.substituteOperations(mFunction.Operations, substitutions),
+			FinalMConfiguration: final,
+		})
+	}
+	return rows, at.err
+}
@@ -0,0 +1,112 @@
+package turing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+const printTwiceSkeleton = `
+; Prints the symbol on this square and the next F-square, then -> C
+pr2(C, a):
+    on * " " do Pa, R, R, Pa goto C
+`
+
+func TestSkeletonLibrary(t *testing.T) {
+	library := NewSkeletonLibrary()
+	if err := library.Load(printTwiceSkeleton, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	mConfigurations := append([]MConfiguration{
+		{"b", []string{"*", " "}, []string{}, "pr2(c, 0)"},
+		{"c", []string{"*", " "}, []string{"R", "R"}, "pr2(halt, 1)"},
+	}, library.MConfigurations()...)
+	m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
+		MConfigurations: mConfigurations,
+		PossibleSymbols: []string{"0", "1"},
+	}))
+	m.MoveN(10)
+	turingtest.TapePrefix(t, m.TapeString(), "0 0 1 1")
+
+	if names := library.Names(); !reflect.DeepEqual(names, []string{"pr2/2"}) {
+		t.Errorf("got %v, want [pr2/2]", names)
+	}
+}
+
+func TestSkeletonLibraryReplaces(t *testing.T) {
+	library := NewSkeletonLibrary()
+	library.Register(
+		MConfiguration{"p(C)", []string{"*"}, []string{"P0"}, "C"},
+		MConfiguration{"q(C)", []string{"*"}, []string{"P1"}, "C"},
+	)
+	library.Register(MConfiguration{"p(C)", []string{"*"}, []string{"Px"}, "C"})
+
+	expected := []MConfiguration{
+		{"p(C)", []string{"*"}, []string{"Px"}, "C"},
+		{"q(C)", []string{"*"}, []string{"P1"}, "C"},
+	}
+	if mConfigurations := library.MConfigurations(); !reflect.DeepEqual(mConfigurations, expected) {
+		t.Errorf("got %v, want %v", mConfigurations, expected)
+	}
+}
+
+func TestSkeletonLibraryLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "library.tm")
+	if err := os.WriteFile(path, []byte(printTwiceSkeleton), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	library := NewSkeletonLibrary()
+	if err := library.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if len(library.MConfigurations()) != 1 {
+		t.Errorf("got %d m-configurations, want 1", len(library.MConfigurations()))
+	}
+	if err := library.LoadFile(filepath.Join(t.TempDir(), "missing.tm")); err == nil {
+		t.Error("got no error for a missing file, want one")
+	}
+}
+
+func TestPartiallyApply(t *testing.T) {
+	// `fx(C, B)` finds the first `x`, calling itself rather than `f(C, B, x)`
+	rows, err := PartiallyApply(StandardSkeletons(), "fx(C, B)", "f(C, B, x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []MConfiguration{
+		{"fx(C, B)", []string{"e"}, []string{"L"}, "f1(C,B,x)"},
+		{"fx(C, B)", []string{"!e", " "}, []string{"L"}, "fx(C, B)"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("got %v, want %v", rows, expected)
+	}
+
+	// `pr0(C)` prints `0` twice, and may be called with an m-function
+	library := NewSkeletonLibrary()
+	if err := library.Load(printTwiceSkeleton, nil); err != nil {
+		t.Fatal(err)
+	}
+	rows, err = PartiallyApply(library.MConfigurations(), "pr0(C)", "pr2(C, 0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mConfigurations := append([]MConfiguration{
+		{"b", []string{"*", " "}, []string{}, "pr0(r(r(b)))"},
+	}, append(rows, StandardSkeletons()...)...)
+	m := NewMachine(NewAbbreviatedTable(AbbreviatedTableInput{
+		MConfigurations: mConfigurations,
+		PossibleSymbols: []string{"0"},
+	}))
+	m.MoveN(12)
+	turingtest.TapePrefix(t, m.TapeString(), "0 0 0 0 0 0")
+
+	if _, err := PartiallyApply(library.MConfigurations(), "pr(C)", "pr3(C, 0)"); !errors.Is(err, ErrUnknownMFunction) {
+		t.Errorf("got %v, want ErrUnknownMFunction", err)
+	}
+}
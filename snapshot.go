@@ -0,0 +1,141 @@
+package turing
+
+import "slices"
+
+type (
+	// The state of a machine after a move, captured by a SnapshotTimeline
+	MachineSnapshot struct {
+		// The amount of moves the machine had made
+		Moves int `json:"moves"`
+
+		// The m-configuration the machine was in
+		MConfiguration string `json:"mConfiguration"`
+
+		// The scanned square, relative to the square originally scanned
+		Head int `json:"head"`
+
+		// A copy of the tape
+		Tape Tape `json:"tape"`
+
+		// The index within `Tape` of the square originally scanned
+		Origin int `json:"origin"`
+
+		// Whether the machine had halted
+		Halted bool `json:"halted"`
+	}
+
+	// Captures a snapshot of a machine every `Interval` moves while running it, keeping the most recent
+	// `Capacity` of them, so that long runs (such as the Universal Machine's) can be scrubbed through or
+	// inspected after the fact without recording every move (as `Record` does).
+	SnapshotTimeline struct {
+		// The amount of moves between snapshots (at least one)
+		Interval int
+
+		// The most snapshots kept, older ones being dropped. If zero every snapshot is kept.
+		Capacity int
+
+		// If provided, called with every snapshot as it is captured (i.e. to write it elsewhere)
+		Export func(MachineSnapshot)
+
+		// The kept snapshots, oldest first once the buffer has wrapped around at `next`
+		snapshots []MachineSnapshot
+		next      int
+	}
+)
+
+// Returns a timeline capturing a snapshot every `interval` moves, keeping the most recent `capacity`
+func NewSnapshotTimeline(interval int, capacity int) *SnapshotTimeline {
+	return &SnapshotTimeline{
+		Interval:  interval,
+		Capacity:  capacity,
+		snapshots: []MachineSnapshot{},
+	}
+}
+
+// Moves the machine n times and stops early if halted (see `MoveDetailed`), capturing a snapshot
+// whenever the amount of moves it has made reaches a multiple of `Interval`, and once it halts
+func (s *SnapshotTimeline) Run(m *Machine, n int) MoveReport {
+	interval := max(s.Interval, 1)
+	report := MoveReport{}
+	for report.Moves < n {
+		segment := m.MoveDetailed(min(n-report.Moves, interval-m.moves%interval))
+		report.Moves += segment.Moves
+		report.Halted, report.Reason, report.Err = segment.Halted, segment.Reason, segment.Err
+		report.MConfiguration, report.Head = segment.MConfiguration, segment.Head
+		if segment.Halted || m.moves%interval == 0 {
+			s.capture(m)
+		}
+		if segment.Halted {
+			break
+		}
+	}
+	return report
+}
+
+// Returns a snapshot of the machine's current state
+func newMachineSnapshot(m *Machine) MachineSnapshot {
+	return MachineSnapshot{
+		Moves:          m.moves,
+		MConfiguration: m.currentMConfigurationName,
+		Head:           m.Head(),
+		Tape:           slices.Clone(m.tape),
+		Origin:         m.origin,
+		Halted:         m.halted,
+	}
+}
+
+// Captures a snapshot of the machine, dropping the oldest if the timeline is full
+func (s *SnapshotTimeline) capture(m *Machine) {
+	snapshot := newMachineSnapshot(m)
+	if len(s.snapshots) > 0 && s.latest().Moves == snapshot.Moves {
+		return
+	}
+	if s.Export != nil {
+		s.Export(snapshot)
+	}
+	if s.Capacity <= 0 || len(s.snapshots) < s.Capacity {
+		s.snapshots = append(s.snapshots, snapshot)
+		return
+	}
+	s.snapshots[s.next] = snapshot
+	s.next = (s.next + 1) % s.Capacity
+}
+
+// Returns the most recently captured snapshot
+func (s *SnapshotTimeline) latest() MachineSnapshot {
+	return s.snapshots[(s.next+len(s.snapshots)-1)%len(s.snapshots)]
+}
+
+// Returns the kept snapshots, oldest first
+func (s *SnapshotTimeline) Snapshots() []MachineSnapshot {
+	return append(slices.Clone(s.snapshots[s.next:]), s.snapshots[:s.next]...)
+}
+
+// Returns the latest kept snapshot captured at or before the move. Returns false if there is none.
+func (s *SnapshotTimeline) At(move int) (MachineSnapshot, bool) {
+	snapshots := s.Snapshots()
+	i, found := slices.BinarySearchFunc(snapshots, move, func(snapshot MachineSnapshot, move int) int {
+		return snapshot.Moves - move
+	})
+	if found {
+		return snapshots[i], true
+	}
+	if i == 0 {
+		return MachineSnapshot{}, false
+	}
+	return snapshots[i-1], true
+}
+
+// Returns a machine in the state of the snapshot, which was taken of a machine given `input`. It may
+// be moved on from there, i.e. to reach a move between two snapshots. Head trajectories and tape writes
+// (see `HeadTrajectoryInterval` and `RecordTapeWrites`) are recorded from the snapshot onward.
+func (snapshot MachineSnapshot) Restore(input MachineInput) *Machine {
+	m := NewMachine(input)
+	m.tape = slices.Clone(snapshot.Tape)
+	m.origin = snapshot.Origin
+	m.scannedSquare = snapshot.Origin + snapshot.Head
+	m.currentMConfigurationName = snapshot.MConfiguration
+	m.moves = snapshot.Moves
+	m.halted = snapshot.Halted || m.err != nil
+	return m
+}
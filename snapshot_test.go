@@ -0,0 +1,74 @@
+package turing
+
+import (
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestSnapshotTimeline(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	exported := []int{}
+	timeline := NewSnapshotTimeline(10, 3)
+	timeline.Export = func(snapshot MachineSnapshot) {
+		exported = append(exported, snapshot.Moves)
+	}
+	m := NewMachine(input)
+	if report := timeline.Run(m, 55); report.Moves != 55 || m.moves != 55 {
+		t.Errorf("got %d moves, want 55", report.Moves)
+	}
+
+	// Only the most recent are kept, oldest first
+	moves := []int{}
+	for _, snapshot := range timeline.Snapshots() {
+		moves = append(moves, snapshot.Moves)
+	}
+	if len(exported) != 5 || len(moves) != 3 || moves[0] != 30 || moves[2] != 50 {
+		t.Errorf("got %v exported and %v kept, want 10 to 50 and 30 to 50", exported, moves)
+	}
+
+	t.Run("At", func(t *testing.T) {
+		for move, expected := range map[int]int{30: 30, 47: 40, 100: 50} {
+			snapshot, ok := timeline.At(move)
+			if !ok || snapshot.Moves != expected {
+				t.Errorf("got %d and %t at %d, want %d", snapshot.Moves, ok, move, expected)
+			}
+		}
+		if _, ok := timeline.At(29); ok {
+			t.Error("got a snapshot at 29, want none")
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		// Moving on from a snapshot reaches the same complete configuration
+		snapshot, _ := timeline.At(47)
+		restored := snapshot.Restore(input)
+		restored.MoveN(7)
+		expected := NewMachine(input)
+		expected.MoveN(47)
+		turingtest.Equal(t, restored.CompleteConfiguration(), expected.CompleteConfiguration())
+	})
+
+	t.Run("Halts", func(t *testing.T) {
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"R"}, "halt"},
+			},
+			HaltingMConfigurations: []string{"halt"},
+		}
+		timeline := NewSnapshotTimeline(10, 0)
+		timeline.Run(NewMachine(input), 100)
+		snapshots := timeline.Snapshots()
+		if len(snapshots) != 1 || !snapshots[0].Halted || snapshots[0].Moves != 2 || snapshots[0].Tape.String("") != "0 " {
+			t.Errorf("got %+v, want a single snapshot of the halted machine", snapshots)
+		}
+	})
+}
@@ -2,7 +2,7 @@ package turing
 
 import (
 	"bytes"
-	"errors"
+	"fmt"
 	"regexp"
 	"slices"
 	"strconv"
@@ -30,6 +30,7 @@ type (
 		nameCount             int
 		mConfigurationSymbols map[string]string
 		symbolCount           int
+		haltingName           string
 	}
 
 	// A map of new symbols to old symbols, used to verify Tape output
@@ -42,6 +43,11 @@ type (
 	DescriptionNumber string
 )
 
+const (
+	// Introduces the starting configuration in an extended S.D. (see `ExtendedStandardDescription`)
+	extendedSeparator string = ";N"
+)
+
 const (
 	mConfigurationNamePrefix   string = "q"
 	mConfigurationSymbolPrefix string = "S"
@@ -79,11 +85,14 @@ var (
 
 // Standardizes MachineInput so it conforms to Turing's standard form.
 func NewStandardTable(input MachineInput) StandardTable {
+	span := startSpan(input.Tracer, SpanStandardize, SpanAttribute{"turing.m_configurations", len(input.MConfigurations)})
 	s := &standardTableCreator{
 		input: input,
 	}
 
-	return s.standardize()
+	standardTable := s.standardize()
+	endSpan(span, SpanAttribute{"turing.standard_m_configurations", len(standardTable.MachineInput.MConfigurations)})
+	return standardTable
 }
 
 // Converts a Machine to a Machine that conforms to Turing's standard form.
@@ -95,70 +104,81 @@ func (s *standardTableCreator) standardize() StandardTable {
 	// This ensures ` ` (None) comes first
 	s.newMConfigurationSymbol(none)
 
+	// Hidden m-configurations may scan any symbol, including those printed but not declared possible
+	scannableSymbols := append(slices.Clone(s.input.PossibleSymbols), none)
+	for _, mConfiguration := range s.input.MConfigurations {
+		for _, operation := range mConfiguration.Operations {
+			if len(operation) > 1 && operationCode(operation[0]) == printOp && !slices.Contains(scannableSymbols, operation[1:]) {
+				scannableSymbols = append(scannableSymbols, operation[1:])
+			}
+		}
+	}
+
+	// Turing's convention is that the machine starts in q1
+	if len(s.input.StartingMConfiguration) != 0 {
+		s.newMConfigurationName(s.input.StartingMConfiguration)
+	}
+
 	// Every m-configuration will be rewritten and potentially introduce further m-configurations
 	for _, mConfiguration := range s.input.MConfigurations {
 		// Enumerate all symbols for the m-configuration in standard form
 		symbols := s.expandStandardSymbols(mConfiguration.Symbols)
 
+		// Turing's standard form has no halt operation, so it is removed here and encoded by the final m-configuration
+		operations, halts := withoutHaltOperations(mConfiguration.Operations)
+
 		// Split out the operations so they satisfy Turing's acceptable forms:
 		// (E), (E, R), (E, L), (Pa), (Pa, R), (Pa, L), (R), (L), (<Nothing>)
-		printOperations, moveOperations := s.expandStandardOperations(mConfiguration.Operations)
+		printOperations, moveOperations := s.expandStandardOperations(operations)
 
 		// Standardize m-configuration name
 		name := s.newMConfigurationName(mConfiguration.Name)
 
-		// Standardize final m-configuration
-		finalMConfiguration := s.newMConfigurationName(mConfiguration.FinalMConfiguration)
+		// Standardize final m-configuration. Halting is encoded explicitly by moving to an
+		// m-configuration without any rows.
+		var finalMConfiguration string
+		if halts || slices.Contains(s.input.HaltingMConfigurations, mConfiguration.FinalMConfiguration) {
+			finalMConfiguration = s.newHaltingMConfigurationName()
+		} else {
+			finalMConfiguration = s.newMConfigurationName(mConfiguration.FinalMConfiguration)
+		}
+
+		// The operations after the first are carried out by hidden m-configurations, which are shared by
+		// every symbol of the row
+		hiddenNames := []string{}
+		for i := 1; i < len(printOperations); i++ {
+			hiddenNames = append(hiddenNames, s.newHiddenMConfigurationName())
+		}
+		nextMConfiguration := func(i int) string {
+			if i == len(printOperations)-1 {
+				return finalMConfiguration
+			}
+			return hiddenNames[i]
+		}
 
 		// For each symbol, make identical m-configurations
 		for _, currentSymbol := range symbols {
+			// If we intend to print a 'Noop', just use the current symbol
+			standardMConfigurations = append(standardMConfigurations, MConfiguration{
+				Name:                name,
+				Symbols:             []string{currentSymbol},
+				Operations:          []string{s.calculateStandardPrintOperation(printOperations[0], currentSymbol), moveOperations[0]},
+				FinalMConfiguration: nextMConfiguration(0),
+			})
+		}
 
-			// For each operation for this symbol, calculate the m-configuration
-			var nextName string
-			for i := 0; i < len(printOperations); i++ {
-				// Only the first operation in a list needs a name that others will recognize
-				var calculatedName string
-				if i == 0 {
-					calculatedName = name
-				} else {
-					calculatedName = nextName
-				}
-
-				// Similarly, only the final operation in a list needs a name that others will recognize
-				var calculatedFinalMConfiguration string
-				if i == len(printOperations)-1 {
-					calculatedFinalMConfiguration = finalMConfiguration
-				} else {
-					nextName = s.newHiddenMConfigurationName()
-					calculatedFinalMConfiguration = nextName
-				}
-
+		// When we are in hidden states, we get to the final m-configuration no matter what
+		// This means we need to account for all symbols
+		for i := 1; i < len(printOperations); i++ {
+			for _, calculatedSymbol := range scannableSymbols {
 				// If we intend to print a 'Noop', just use the current symbol
-				calculatedPrintOperation := s.calculateStandardPrintOperation(printOperations[i], currentSymbol)
-
-				if i == 0 {
-					// Only one m-configuration needed
-					standardMConfigurations = append(standardMConfigurations, MConfiguration{
-						Name:                calculatedName,
-						Symbols:             []string{currentSymbol},
-						Operations:          []string{calculatedPrintOperation, moveOperations[i]},
-						FinalMConfiguration: calculatedFinalMConfiguration,
-					})
-				} else {
-					// When we are in hidden states, we get to the final m-configuration no matter what
-					// This means we need to account for all symbols
-					for _, calculatedSymbol := range append(s.input.PossibleSymbols, none) {
-						// If we intend to print a 'Noop', just use the current symbol
-						calculatedPrintOperation := s.calculateStandardPrintOperation(printOperations[i], s.newMConfigurationSymbol(calculatedSymbol))
-
-						standardMConfigurations = append(standardMConfigurations, MConfiguration{
-							Name:                calculatedName,
-							Symbols:             []string{s.newMConfigurationSymbol(calculatedSymbol)},
-							Operations:          []string{calculatedPrintOperation, moveOperations[i]},
-							FinalMConfiguration: calculatedFinalMConfiguration,
-						})
-					}
-				}
+				calculatedSymbol = s.newMConfigurationSymbol(calculatedSymbol)
+				standardMConfigurations = append(standardMConfigurations, MConfiguration{
+					Name:                hiddenNames[i-1],
+					Symbols:             []string{calculatedSymbol},
+					Operations:          []string{s.calculateStandardPrintOperation(printOperations[i], calculatedSymbol), moveOperations[i]},
+					FinalMConfiguration: nextMConfiguration(i),
+				})
 			}
 		}
 	}
@@ -166,9 +186,15 @@ func (s *standardTableCreator) standardize() StandardTable {
 	machineInput := MachineInput{
 		MConfigurations:        standardMConfigurations,
 		Tape:                   s.newTape(),
+		StartingSquare:         s.input.StartingSquare,
 		StartingMConfiguration: s.newStartingMConfiguration(),
 		PossibleSymbols:        s.newMConfigurationSymbols(),
 		NoneSymbol:             s.newMConfigurationSymbol(none),
+		Tracer:                 s.input.Tracer,
+		SpanRunInterval:        s.input.SpanRunInterval,
+	}
+	if len(s.haltingName) != 0 {
+		machineInput.HaltingMConfigurations = []string{s.haltingName}
 	}
 	sd := toStandardDescription(machineInput)
 	dn := toDescriptionNumber(sd)
@@ -183,6 +209,11 @@ func (s *standardTableCreator) standardize() StandardTable {
 
 // Expands and standardizes the list of symbols (to the form S0, S1, ..., etc.)
 func (s *standardTableCreator) expandStandardSymbols(originalSymbols []string) []string {
+	// No symbols is shorthand for every symbol, including ` ` (None)
+	if len(originalSymbols) == 0 {
+		originalSymbols = []string{any, none}
+	}
+
 	// First loop required for multiple Not scenario
 	notSymbols := []string{}
 	for _, symbol := range originalSymbols {
@@ -211,55 +242,49 @@ func (s *standardTableCreator) expandStandardSymbols(originalSymbols []string) [
 	return symbols
 }
 
-// Standardizes the list of options to the form Turing prefers (exactly one Print and one Move operation)
-// These are returned in two slices - the Print operation slice and the Move operation slice
+// Standardizes the list of operations to the form Turing prefers (exactly one Print and one Move operation)
+// These are returned in two slices - the Print operation slice and the Move operation slice. Each Print
+// (or Erase) is paired with the Move after it, if any. Moves without a Print before them are paired with
+// a Print noop, and Prints without a Move after them are paired with `N`.
 func (s *standardTableCreator) expandStandardOperations(originalOperations []string) ([]string, []string) {
 	printOperations := []string{}
 	moveOperations := []string{}
-	if len(originalOperations) == 0 {
-		printOperations = append(printOperations, string(printOp))
-		moveOperations = append(moveOperations, string(n))
-	} else {
-		lookingForPrint := true
-		for i, operation := range originalOperations {
-			operationCode := operationCode(operation[0])
-			if lookingForPrint {
-				if operationCode == printOp {
-					symbol := string(operation[1:])
-					var printOperation strings.Builder
-					printOperation.WriteByte(byte(printOp))
-					printOperation.WriteString(s.newMConfigurationSymbol(symbol))
-					printOperations = append(printOperations, printOperation.String())
-					lookingForPrint = false
-					if i == len(originalOperations)-1 {
-						moveOperations = append(moveOperations, string(n))
-					}
-				} else if operationCode == eraseOp {
-					var printOperation strings.Builder
-					printOperation.WriteByte(byte(printOp))
-					printOperation.WriteString(s.newMConfigurationSymbol(none))
-					printOperations = append(printOperations, printOperation.String())
-					lookingForPrint = false
-					if i == len(originalOperations)-1 {
-						moveOperations = append(moveOperations, string(n))
-					}
-				} else {
-					var printOperation strings.Builder
-					printOperation.WriteByte(byte(printOp))
-					// Printing the current symbol is essentially a Print noop
-					// We encode this by just including `P` with no symbol
-					printOperations = append(printOperations, printOperation.String())
-					moveOperations = append(moveOperations, string(operationCode))
-				}
-			} else {
-				if operationCode == leftOp || operationCode == rightOp {
-					moveOperations = append(moveOperations, string(operationCode))
-				} else {
-					moveOperations = append(moveOperations, string(n))
-				}
-				lookingForPrint = true
+	for i := 0; i < len(originalOperations); {
+		start := i
+		var printOperation strings.Builder
+		printOperation.WriteByte(byte(printOp))
+		switch operationCode(originalOperations[i][0]) {
+		case printOp:
+			printOperation.WriteString(s.newMConfigurationSymbol(originalOperations[i][1:]))
+			i++
+		case eraseOp:
+			printOperation.WriteString(s.newMConfigurationSymbol(none))
+			i++
+		}
+		// Otherwise printing the current symbol is essentially a Print noop
+		// We encode this by just including `P` with no symbol
+		printOperations = append(printOperations, printOperation.String())
+
+		moveOperation := string(n)
+		if i < len(originalOperations) {
+			switch operationCode := operationCode(originalOperations[i][0]); operationCode {
+			case leftOp, rightOp:
+				moveOperation = string(operationCode)
+				i++
+			case noOp:
+				i++
 			}
 		}
+		moveOperations = append(moveOperations, moveOperation)
+
+		// Skip anything else, so every operation is consumed
+		if i == start {
+			i++
+		}
+	}
+	if len(printOperations) == 0 {
+		printOperations = append(printOperations, string(printOp))
+		moveOperations = append(moveOperations, string(n))
 	}
 	return printOperations, moveOperations
 }
@@ -306,6 +331,25 @@ func (s *standardTableCreator) newHiddenMConfigurationName() string {
 	return newName
 }
 
+// Returns the standardized m-configuration name that every halting row moves to (it has no rows itself)
+func (s *standardTableCreator) newHaltingMConfigurationName() string {
+	if len(s.haltingName) == 0 {
+		s.haltingName = s.newHiddenMConfigurationName()
+	}
+	return s.haltingName
+}
+
+// Returns the operations without any `H` (Halt) operations, and whether any were removed
+func withoutHaltOperations(operations []string) ([]string, bool) {
+	kept := []string{}
+	for _, operation := range operations {
+		if operation != string(haltOp) {
+			kept = append(kept, operation)
+		}
+	}
+	return kept, len(kept) != len(operations)
+}
+
 // Returns the standardized symbol name (of the form S0, S1, ..., etc.), and stores it for deduping
 func (s *standardTableCreator) newMConfigurationSymbol(symbol string) string {
 	if s.mConfigurationSymbols == nil {
@@ -351,7 +395,7 @@ func (s *standardTableCreator) newStartingMConfiguration() string {
 func (s *standardTableCreator) newTape() []string {
 	newTape := []string{}
 	for _, square := range s.input.Tape {
-		newTape = append(newTape, s.mConfigurationSymbols[square])
+		newTape = append(newTape, s.newMConfigurationSymbol(square))
 	}
 	return newTape
 }
@@ -405,9 +449,15 @@ func toStandardDescription(input MachineInput) StandardDescription {
 
 // Conversts a S.D. to a D.N.
 func toDescriptionNumber(sd StandardDescription) DescriptionNumber {
+	// Description Numbers can be very long, so the digits are looked up in an array rather than the map
+	var digits [256]byte
+	for char, i := range sdCharToDNInt {
+		digits[char] = byte('0' + i)
+	}
 	var descriptionNumber strings.Builder
+	descriptionNumber.Grow(len(sd))
 	for _, char := range []byte(sd) {
-		descriptionNumber.WriteString(strconv.Itoa(sdCharToDNInt[char]))
+		descriptionNumber.WriteByte(digits[char])
 	}
 	return DescriptionNumber(descriptionNumber.String())
 }
@@ -416,21 +466,113 @@ func toDescriptionNumber(sd StandardDescription) DescriptionNumber {
 func NewMachineFromDescriptionNumber(dn DescriptionNumber) (MachineInput, error) {
 	matched, _ := regexp.MatchString("^(?:731+32*32*[456]31+)+$", string(dn))
 	if !matched {
-		return MachineInput{}, errors.New("not a well defined Description Number")
+		return MachineInput{}, fmt.Errorf("%w: Description Number %s", ErrNotWellDefined, dn)
 	}
 
+	// The D.N. being well-defined means its S.D. is too
+	return parseStandardDescription(toStandardDescriptionFromDescriptionNumber(dn)), nil
+}
+
+// Converts a D.N. to a S.D.
+func toStandardDescriptionFromDescriptionNumber(dn DescriptionNumber) StandardDescription {
 	var standardDescription strings.Builder
+	standardDescription.Grow(len(dn))
 	for _, char := range []byte(dn) {
-		i, err := strconv.Atoi(string(char))
-		if err != nil {
-			return MachineInput{}, err
+		standardDescription.WriteByte(dnIntToSDChar[int(char-'0')])
+	}
+	return StandardDescription(standardDescription.String())
+}
+
+// Converts a S.D. to a Machine. Returns an error if the S.D. is not well-defined.
+func NewMachineFromStandardDescription(sd StandardDescription) (MachineInput, error) {
+	matched, _ := regexp.MatchString("^(?:;DA+DC*DC*[LRN]DA+)+$", string(sd))
+	if !matched {
+		return MachineInput{}, fmt.Errorf("%w: Standard Description %s", ErrNotWellDefined, sd)
+	}
+	return parseStandardDescription(sd), nil
+}
+
+// Returns the S.D. followed by the machine's starting configuration, so that a machine starting on a
+// tape that is not blank is described by a single string. Turing's S.D. only describes the table, the
+// machine being taken to start in q1 on a blank tape. The starting configuration is introduced by `;N`
+// (no instruction begins with `N`), and consists of the starting m-configuration (`DAAA` for q3), an
+// `R` for each square the starting square lies right of the first square of the tape, and the squares
+// of the tape (`DCC` for S2, `D` for S0). For example `;DADDCRDA;NDARDDC` starts in q1 on the second
+// square of the tape S0 S1.
+func (st StandardTable) ExtendedStandardDescription() StandardDescription {
+	input := st.MachineInput
+	tape, start := slices.Clone(input.Tape), input.StartingSquare
+	for ; start < 0; start++ {
+		tape = append(Tape{input.NoneSymbol}, tape...)
+	}
+
+	var extended strings.Builder
+	extended.WriteString(string(st.StandardDescription))
+	extended.WriteString(extendedSeparator)
+	extended.WriteByte(d)
+	startNum, _ := strconv.Atoi(startingMConfigurationName(input)[1:])
+	extended.Write(bytes.Repeat([]byte{a}, startNum))
+	extended.Write(bytes.Repeat([]byte{r}, start))
+	for _, square := range tape {
+		extended.WriteByte(d)
+		symbolNum, _ := strconv.Atoi(square[1:])
+		extended.Write(bytes.Repeat([]byte{c}, symbolNum))
+	}
+	return StandardDescription(extended.String())
+}
+
+// Returns the D.N. of the extended S.D. (see `ExtendedStandardDescription`)
+func (st StandardTable) ExtendedDescriptionNumber() DescriptionNumber {
+	return toDescriptionNumber(st.ExtendedStandardDescription())
+}
+
+// Converts an extended S.D. (see `ExtendedStandardDescription`) to a Machine, including its tape and
+// starting configuration. Returns an error if the extended S.D. is not well-defined.
+func NewMachineFromExtendedStandardDescription(sd StandardDescription) (MachineInput, error) {
+	matched, _ := regexp.MatchString("^(?:;DA+DC*DC*[LRN]DA+)+;NDA+R*(?:DC*)*$", string(sd))
+	if !matched {
+		return MachineInput{}, fmt.Errorf("%w: extended Standard Description %s", ErrNotWellDefined, sd)
+	}
+	return parseExtendedStandardDescription(sd), nil
+}
+
+// Converts an extended D.N. (see `ExtendedDescriptionNumber`) to a Machine, including its tape and
+// starting configuration. Returns an error if the extended D.N. is not well-defined.
+func NewMachineFromExtendedDescriptionNumber(dn DescriptionNumber) (MachineInput, error) {
+	matched, _ := regexp.MatchString("^(?:731+32*32*[456]31+)+7631+5*(?:32*)*$", string(dn))
+	if !matched {
+		return MachineInput{}, fmt.Errorf("%w: extended Description Number %s", ErrNotWellDefined, dn)
+	}
+	return parseExtendedStandardDescription(toStandardDescriptionFromDescriptionNumber(dn)), nil
+}
+
+// Converts a well-defined extended S.D. to a Machine
+func parseExtendedStandardDescription(sd StandardDescription) MachineInput {
+	separator := strings.LastIndex(string(sd), extendedSeparator)
+	input := parseStandardDescription(sd[:separator])
+
+	// The starting m-configuration, then the starting square, then the squares
+	sections := strings.Split(string(sd[separator+len(extendedSeparator)+1:]), string(d))
+	start := strings.TrimRight(sections[0], string(r))
+	input.StartingMConfiguration = mConfigurationNamePrefix + strconv.Itoa(len(start))
+	input.StartingSquare = len(sections[0]) - len(start)
+	input.Tape = Tape{}
+	for _, square := range sections[1:] {
+		input.Tape = append(input.Tape, mConfigurationSymbolPrefix+strconv.Itoa(len(square)))
+		for len(input.PossibleSymbols) <= len(square) {
+			input.PossibleSymbols = append(input.PossibleSymbols, mConfigurationSymbolPrefix+strconv.Itoa(len(input.PossibleSymbols)))
 		}
-		standardDescription.WriteString(string(dnIntToSDChar[i]))
 	}
+	return input
+}
 
+// Converts a well-defined S.D. to a Machine
+func parseStandardDescription(sd StandardDescription) MachineInput {
 	mConfigurations := []MConfiguration{}
-	for _, section := range strings.Split(standardDescription.String()[1:], string(semicolon)) {
+	maxSymbol := 0
+	for _, section := range strings.Split(string(sd)[1:], string(semicolon)) {
 		subsections := strings.Split(section[1:], string(d))
+		maxSymbol = max(maxSymbol, len(subsections[1]), len(subsections[2])-1)
 		name := mConfigurationNamePrefix + strconv.Itoa(len(subsections[0]))
 		symbol := mConfigurationSymbolPrefix + strconv.Itoa(len(subsections[1]))
 		printOperation := string(printOp) + mConfigurationSymbolPrefix + strconv.Itoa(len(subsections[2])-1)
@@ -446,29 +588,14 @@ func NewMachineFromDescriptionNumber(dn DescriptionNumber) (MachineInput, error)
 	}
 
 	possibleSymbols := []string{}
-	for i := 0; i <= maxCharsRepeated([]byte(standardDescription.String()), c); i++ {
+	for i := 0; i <= maxSymbol; i++ {
 		possibleSymbols = append(possibleSymbols, mConfigurationSymbolPrefix+strconv.Itoa(i))
 	}
 
 	return MachineInput{
-		MConfigurations: mConfigurations,
-		PossibleSymbols: possibleSymbols,
-		NoneSymbol:      mConfigurationSymbolPrefix + strconv.Itoa(0),
-	}, nil
-}
-
-func maxCharsRepeated(s []byte, ch byte) int {
-	var maxCount int
-	var runningCount int
-	for _, b := range s {
-		if b == ch {
-			runningCount += 1
-			if runningCount > maxCount {
-				maxCount = runningCount
-			}
-		} else {
-			runningCount = 0
-		}
+		MConfigurations:        mConfigurations,
+		StartingMConfiguration: mConfigurationNamePrefix + strconv.Itoa(1),
+		PossibleSymbols:        possibleSymbols,
+		NoneSymbol:             mConfigurationSymbolPrefix + strconv.Itoa(0),
 	}
-	return maxCount
 }
@@ -1,7 +1,13 @@
 package turing
 
 import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
 )
 
 func TestStandardMachineExample1(t *testing.T) {
@@ -16,9 +22,9 @@ func TestStandardMachineExample1(t *testing.T) {
 	})
 	m := NewMachine(st.MachineInput)
 	m.MoveN(100)
-	checkTape(t, st.SymbolMap.TranslateTape(m.Tape()), "0 1 0 1 0 1 0 1 0 1 0 1")
-	checkStandardDescription(t, st.StandardDescription, ";DADDCRDAA;DAADDRDAAA;DAAADDCCRDAAAA;DAAAADDRDA")
-	checkDescriptionNumber(t, st.DescriptionNumber, "73133253117311335311173111332253111173111133531")
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "0 1 0 1 0 1 0 1 0 1 0 1")
+	turingtest.Equal(t, st.StandardDescription, ";DADDCRDAA;DAADDRDAAA;DAAADDCCRDAAAA;DAAAADDRDA")
+	turingtest.Equal(t, st.DescriptionNumber, "73133253117311335311173111332253111173111133531")
 }
 
 func TestStandardMachineExample1Short(t *testing.T) {
@@ -32,10 +38,55 @@ func TestStandardMachineExample1Short(t *testing.T) {
 	})
 	m := NewMachine(st.MachineInput)
 	m.MoveN(100)
-	checkTape(t, st.SymbolMap.TranslateTape(m.Tape()), "0 1 0 1 0 1 0 1 0 1 0 1")
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "0 1 0 1 0 1 0 1 0 1 0 1")
 	// No StandardDescription or DescriptionNumner given
 }
 
+func TestStandardMachineEmptySymbols(t *testing.T) {
+	st := NewStandardTable(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{}, []string{"P0", "R", "R"}, "c"},
+			{"c", []string{}, []string{"P1", "R", "R"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	})
+	// Each row is expanded to every symbol, including ` ` (None)
+	symbols := []string{}
+	for _, mConfiguration := range st.MachineInput.MConfigurations {
+		if mConfiguration.Name == "q1" {
+			symbols = append(symbols, st.SymbolMap[mConfiguration.Symbols[0]])
+		}
+	}
+	slices.Sort(symbols)
+	if !slices.Equal(symbols, []string{" ", "0", "1"}) {
+		t.Errorf("got symbols %q, want \" \", \"0\" and \"1\"", symbols)
+	}
+	m := NewMachine(st.MachineInput)
+	m.MoveN(50)
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "0 1 0 1 0 1 0 1 0 1")
+}
+
+func TestStandardMachineUnicodeSymbols(t *testing.T) {
+	st := NewStandardTable(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"Pə", "R", "P🐢", "R"}, "b"},
+		},
+		PossibleSymbols: []string{"ə", "🐢"},
+	})
+	m := NewMachine(st.MachineInput)
+	m.MoveN(50)
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "ə🐢ə🐢ə🐢")
+
+	// The symbols survive the S.D.
+	input, err := NewMachineFromStandardDescription(st.StandardDescription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = NewMachine(input)
+	m.MoveN(50)
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "ə🐢ə🐢ə🐢")
+}
+
 func TestStandardMachineExample2(t *testing.T) {
 	st := NewStandardTable(MachineInput{
 		MConfigurations: []MConfiguration{
@@ -54,8 +105,10 @@ func TestStandardMachineExample2(t *testing.T) {
 	})
 	m := NewMachine(st.MachineInput)
 	m.MoveN(1000)
-	checkTape(t, st.SymbolMap.TranslateTape(m.Tape()), "ee0 0 1 0 1 1 0 1 1 1 0 1 1 1 1")
-	// No StandardDescription or DescriptionNumner given
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(m.Tape()), "ee0 0 1 0 1 1 0 1 1 1 0 1 1 1 1")
+	// No StandardDescription or DescriptionNumner given, so they are checked against golden files
+	turingtest.Golden(t, "standard_example2.sd", st.StandardDescription)
+	turingtest.Golden(t, "standard_example2.dn", st.DescriptionNumber)
 }
 
 func TestNewMachineFromDescriptionNumber(t *testing.T) {
@@ -78,17 +131,153 @@ func TestNewMachineFromDescriptionNumber(t *testing.T) {
 	newM := NewMachine(newMachineInput)
 	newM.MoveN(100)
 
-	checkTape(t, m.TapeString(), newM.TapeString())
+	turingtest.TapePrefix(t, m.TapeString(), newM.TapeString())
 }
 
-func checkStandardDescription(t *testing.T, actual StandardDescription, expected string) {
-	if string(actual) != expected {
-		t.Errorf("got %s, want %s", actual, expected)
+func TestNewMachineFromStandardDescription(t *testing.T) {
+	st := NewStandardTable(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	})
+
+	fromStandardDescription, err := NewMachineFromStandardDescription(st.StandardDescription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromDescriptionNumber, err := NewMachineFromDescriptionNumber(st.DescriptionNumber)
+	if err != nil {
+		t.Fatal(err)
 	}
+	if !reflect.DeepEqual(fromStandardDescription, fromDescriptionNumber) {
+		t.Errorf("got %v, want %v", fromStandardDescription, fromDescriptionNumber)
+	}
+
+	for _, sd := range []StandardDescription{"", "DADDRDA", ";DADDXDA", ";DADDRD", ";DADDCRDAA;"} {
+		if _, err := NewMachineFromStandardDescription(sd); !errors.Is(err, ErrNotWellDefined) {
+			t.Errorf("got %v for %q, want ErrNotWellDefined", err, sd)
+		}
+	}
+}
+
+func TestStandardMachineExplicitHalt(t *testing.T) {
+	st := NewStandardTable(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"P1", "H"}, "b"},
+		},
+		PossibleSymbols: []string{"0", "1"},
+	})
+	for _, mConfiguration := range st.MachineInput.MConfigurations {
+		if slices.Contains(mConfiguration.Operations, "H") {
+			t.Errorf("got %v, want no H operation", mConfiguration.Operations)
+		}
+	}
+
+	newMachineInput, err := NewMachineFromDescriptionNumber(st.DescriptionNumber)
+	if err != nil {
+		t.Error(err)
+	}
+	newM := NewMachine(newMachineInput)
+	if moves := newM.MoveN(100); moves == 100 {
+		t.Error("expected standardized machine to halt")
+	}
+	turingtest.TapePrefix(t, st.SymbolMap.TranslateTape(newM.Tape()), "01")
 }
 
-func checkDescriptionNumber(t *testing.T, actual DescriptionNumber, expected string) {
-	if string(actual) != expected {
-		t.Errorf("got %s, want %s", actual, expected)
+func TestStandardOperationPatterns(t *testing.T) {
+	// Every list of up to four operations, run once (then halting) on a tape of `0`'s and `1`'s
+	alphabet := []string{"P0", "P1", "E", "R", "L", "N"}
+	patterns := [][]string{{}}
+	for length := 1; length <= 4; length++ {
+		for _, pattern := range patterns {
+			if len(pattern) != length-1 {
+				continue
+			}
+			for _, operation := range alphabet {
+				patterns = append(patterns, append(slices.Clone(pattern), operation))
+			}
+		}
+	}
+
+	for _, operations := range patterns {
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"*", " "}, operations, "halt"},
+			},
+			Tape:                   Tape{"1", "0", "1", "0", "1"},
+			PossibleSymbols:        []string{"0", "1"},
+			HaltingMConfigurations: []string{"halt"},
+		}
+		st := NewStandardTable(input)
+		for _, mConfiguration := range st.MachineInput.MConfigurations {
+			if len(mConfiguration.Operations) != 2 {
+				t.Fatalf("%v: got operations %v, want a print and a move", operations, mConfiguration.Operations)
+			}
+		}
+
+		m := NewMachine(input)
+		m.MoveN(10)
+		standard := NewMachine(st.MachineInput)
+		standard.MoveN(10)
+		if !standard.Halted() {
+			t.Errorf("%v: want the standardized machine to halt", operations)
+		}
+		if tape := st.SymbolMap.TranslateTape(standard.Tape()); tape != m.TapeString() || standard.Head() != m.Head() {
+			t.Errorf("%v: got %q scanning %d, want %q scanning %d", operations, tape, standard.Head(), m.TapeString(), m.Head())
+		}
 	}
 }
+
+func TestExtendedStandardDescription(t *testing.T) {
+	// Appends `1` to a unary number, starting on its second square
+	st := NewStandardTable(MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{"1"}, []string{"R"}, "b"},
+			{"b", []string{" "}, []string{"P1"}, "halt"},
+		},
+		Tape:                   Tape{"1", "1", "1"},
+		StartingSquare:         1,
+		PossibleSymbols:        []string{"1"},
+		HaltingMConfigurations: []string{"halt"},
+	})
+	extended := st.ExtendedStandardDescription()
+	if !strings.HasPrefix(string(extended), string(st.StandardDescription)+";NDAR") || !strings.HasSuffix(string(extended), "DCDCDC") {
+		t.Errorf("got %s, want the S.D. followed by ;NDARDCDCDC", extended)
+	}
+
+	for name, parse := range map[string]func() (MachineInput, error){
+		"S.D.": func() (MachineInput, error) { return NewMachineFromExtendedStandardDescription(extended) },
+		"D.N.": func() (MachineInput, error) {
+			return NewMachineFromExtendedDescriptionNumber(st.ExtendedDescriptionNumber())
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input, err := parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if input.StartingSquare != 1 || input.StartingMConfiguration != "q1" {
+				t.Errorf("got square %d and %s, want 1 and q1", input.StartingSquare, input.StartingMConfiguration)
+			}
+			m := NewMachine(input)
+			m.MoveN(10)
+			turingtest.Equal(t, st.SymbolMap.TranslateTape(m.Tape()), "1111")
+		})
+	}
+
+	t.Run("NotWellDefined", func(t *testing.T) {
+		for _, sd := range []StandardDescription{st.StandardDescription, extended + ";NDA", ";DADDCRDA;NRDC"} {
+			if _, err := NewMachineFromExtendedStandardDescription(sd); !errors.Is(err, ErrNotWellDefined) {
+				t.Errorf("got %v for %s, want ErrNotWellDefined", err, sd)
+			}
+		}
+		if _, err := NewMachineFromExtendedDescriptionNumber(st.DescriptionNumber); !errors.Is(err, ErrNotWellDefined) {
+			t.Errorf("got %v, want ErrNotWellDefined", err)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package turing
+
+import "slices"
+
+type (
+	// Bounds for a synthesis search
+	SynthesisOptions struct {
+		// The largest amount of m-configurations searched. Defaults to 2.
+		MaxStates int
+
+		// The symbols machines may read and print. The None symbol must come first.
+		// Defaults to ` ` (None) followed by the symbols of the target in order of appearance.
+		Symbols []string
+
+		// The amount of moves each candidate makes (unless it halts) before its figures are compared with
+		// the target. Defaults to 1000.
+		MaxMoves int
+
+		// The largest amount of candidates returned. Defaults to 1.
+		MaxCandidates int
+	}
+
+	// A machine found by a synthesis search
+	SynthesisCandidate struct {
+		// The machine
+		MachineInput MachineInput
+
+		// The Standard Description of the machine
+		StandardDescription StandardDescription
+	}
+)
+
+const (
+	defaultSynthesisMaxStates int = 2
+	defaultSynthesisMaxMoves  int = 1000
+)
+
+// Searches for the smallest machines whose first figures (the symbols on the F-squares) are
+// the target. Machines are enumerated in order of their amount of m-configurations, and the
+// search stops with the first amount that yields any candidates. Returns no candidates if none
+// exist within the bounds of the options.
+func Synthesize(target []string, options SynthesisOptions) []SynthesisCandidate {
+	options = options.withDefaults(target)
+
+	candidates := []SynthesisCandidate{}
+	for n := 1; n <= options.MaxStates && len(candidates) == 0; n++ {
+		enumerateMachines(n, options.Symbols, false, func(mConfigurations []MConfiguration) bool {
+			input := MachineInput{
+				MConfigurations: mConfigurations,
+				PossibleSymbols: options.Symbols[1:],
+				NoneSymbol:      options.Symbols[0],
+			}
+			if !computesFigures(input, target, options.MaxMoves) {
+				return true
+			}
+			input.MConfigurations = cloneMConfigurations(mConfigurations)
+			candidates = append(candidates, SynthesisCandidate{
+				MachineInput:        input,
+				StandardDescription: NewStandardTable(input).StandardDescription,
+			})
+			return len(candidates) < options.MaxCandidates
+		})
+	}
+	return candidates
+}
+
+// Returns the options with defaults filled in
+func (o SynthesisOptions) withDefaults(target []string) SynthesisOptions {
+	if o.MaxStates <= 0 {
+		o.MaxStates = defaultSynthesisMaxStates
+	}
+	if len(o.Symbols) == 0 {
+		o.Symbols = []string{none}
+		for _, symbol := range target {
+			if !slices.Contains(o.Symbols, symbol) {
+				o.Symbols = append(o.Symbols, symbol)
+			}
+		}
+	}
+	if o.MaxMoves <= 0 {
+		o.MaxMoves = defaultSynthesisMaxMoves
+	}
+	if o.MaxCandidates <= 0 {
+		o.MaxCandidates = 1
+	}
+	return o
+}
+
+// Returns true if the machine's first figures are the target once it has made `maxMoves` moves (or
+// halted). Machines may overwrite F-squares, so the figures are only read once the machine stops.
+func computesFigures(input MachineInput, target []string, maxMoves int) bool {
+	m := NewMachine(input)
+	m.MoveN(maxMoves)
+	figures := m.fSquareSymbols()
+	return len(figures) >= len(target) && slices.Equal(figures[:len(target)], target)
+}
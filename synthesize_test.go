@@ -0,0 +1,42 @@
+package turing
+
+import "testing"
+
+func TestSynthesize(t *testing.T) {
+	candidates := Synthesize([]string{"1", "1", "1"}, SynthesisOptions{MaxCandidates: 2})
+	if len(candidates) != 2 {
+		t.Fatalf("got %d candidates, want 2", len(candidates))
+	}
+	for _, candidate := range candidates {
+		if len(Reachable(candidate.MachineInput)) != 1 {
+			t.Errorf("got %v, want a machine with one m-configuration", candidate.MachineInput.MConfigurations)
+		}
+		if !computesFigures(candidate.MachineInput, []string{"1", "1", "1", "1"}, 1000) {
+			t.Errorf("got %v, want a machine computing 1111", candidate.MachineInput.MConfigurations)
+		}
+		if len(candidate.StandardDescription) == 0 {
+			t.Error("expected a Standard Description")
+		}
+	}
+}
+
+func TestSynthesizeNoCandidates(t *testing.T) {
+	candidates := Synthesize([]string{"1", "0"}, SynthesisOptions{MaxStates: 1})
+	if len(candidates) != 0 {
+		t.Errorf("got %v, want no candidates", candidates)
+	}
+}
+
+func TestComputesFiguresOverwritten(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P1", "R", "R", "P1", "L", "L", "P0"}, "halt"},
+		},
+	}
+	if computesFigures(input, []string{"1", "1"}, 1000) {
+		t.Errorf("got the figures 11, want 01 once the first is overwritten")
+	}
+	if !computesFigures(input, []string{"0", "1"}, 1000) {
+		t.Errorf("got other figures, want 01")
+	}
+}
@@ -0,0 +1,92 @@
+package turing
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Returns an iterator over the squares of the tape, with their indices
+func (t Tape) All() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i, square := range t {
+			if !yield(i, square) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the F-squares of the tape (every other square, starting with the first),
+// with their indices. Figures are written on F-squares without gaps, so iteration stops at the first blank.
+func (t Tape) Figures() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i := 0; i < len(t) && t[i] != none; i += 2 {
+			if !yield(i, t[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the marked E-squares of the tape (every other square, starting with
+// the second), with their indices. Blank E-squares are skipped.
+func (t Tape) Marks() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i := 1; i < len(t); i += 2 {
+			if t[i] == none {
+				continue
+			}
+			if !yield(i, t[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the tape without the blank squares at either end, and the amount of squares dropped from
+// its start (so that indices into the tape can be adjusted). The squares are copied, so the dropped
+// ones can be freed.
+func (t Tape) Compact() (Tape, int) {
+	start, end := 0, len(t)
+	for start < end && t[start] == none {
+		start++
+	}
+	for end > start && t[end-1] == none {
+		end--
+	}
+	return slices.Clone(t[start:end]), start
+}
+
+// Returns the tape written in the string. If `sep` is empty every character is a square, otherwise
+// squares are separated by `sep` (so they may be longer than a character, as `::` or `S12` are),
+// and empty squares are blank.
+func ParseTape(s string, sep string) Tape {
+	tape := Tape{}
+	if len(s) == 0 {
+		return tape
+	}
+	for _, square := range strings.Split(s, sep) {
+		if len(square) == 0 {
+			square = none
+		}
+		tape = append(tape, square)
+	}
+	return tape
+}
+
+// Returns the tape as a string that `ParseTape` reads back with the same `sep`. If `sep` is
+// not empty, blank squares are written as empty squares.
+func (t Tape) String(sep string) string {
+	if len(sep) == 0 {
+		return strings.Join(t, "")
+	}
+	squares := []string{}
+	for _, square := range t {
+		if square == none {
+			square = ""
+		}
+		squares = append(squares, square)
+	}
+	return strings.Join(squares, sep)
+}
@@ -0,0 +1,99 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTapeIterators(t *testing.T) {
+	tape := Tape{"0", "x", "1", " ", "0", "y", " ", "z", "1"}
+
+	t.Run("all", func(t *testing.T) {
+		squares := []string{}
+		for i, square := range tape.All() {
+			if tape[i] != square {
+				t.Errorf("got %q at %d, want %q", square, i, tape[i])
+			}
+			squares = append(squares, square)
+		}
+		if !reflect.DeepEqual(Tape(squares), tape) {
+			t.Errorf("got %q, want %q", squares, tape)
+		}
+	})
+
+	t.Run("figures", func(t *testing.T) {
+		figures := []string{}
+		indices := []int{}
+		for i, figure := range tape.Figures() {
+			figures = append(figures, figure)
+			indices = append(indices, i)
+		}
+		if !reflect.DeepEqual(figures, []string{"0", "1", "0"}) || !reflect.DeepEqual(indices, []int{0, 2, 4}) {
+			t.Errorf("got %q at %v, want 0 1 0 at 0 2 4", figures, indices)
+		}
+	})
+
+	t.Run("marks", func(t *testing.T) {
+		marks := []string{}
+		for _, mark := range tape.Marks() {
+			marks = append(marks, mark)
+		}
+		if !reflect.DeepEqual(marks, []string{"x", "y", "z"}) {
+			t.Errorf("got %q, want x y z", marks)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var count int
+		for range tape.All() {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("got %d squares, want 1", count)
+		}
+	})
+}
+
+func TestParseTape(t *testing.T) {
+	for _, test := range []struct {
+		s        string
+		sep      string
+		expected Tape
+	}{
+		{"; D A", "", Tape{";", " ", "D", " ", "A"}},
+		{"::,,S12,0", ",", Tape{"::", " ", "S12", "0"}},
+		{"e|e|0||1", "|", Tape{"e", "e", "0", " ", "1"}},
+		{"", ",", Tape{}},
+	} {
+		t.Run(test.s, func(t *testing.T) {
+			tape := ParseTape(test.s, test.sep)
+			if !reflect.DeepEqual(tape, test.expected) {
+				t.Errorf("got %q, want %q", tape, test.expected)
+			}
+			if s := tape.String(test.sep); s != test.s {
+				t.Errorf("got %q, want %q", s, test.s)
+			}
+		})
+	}
+}
+
+func TestTapeCompact(t *testing.T) {
+	for _, test := range []struct {
+		tape     Tape
+		expected Tape
+		start    int
+	}{
+		{Tape{" ", " ", "0", " ", "1", " "}, Tape{"0", " ", "1"}, 2},
+		{Tape{"0", "1"}, Tape{"0", "1"}, 0},
+		{Tape{" ", " "}, Tape{}, 2},
+		{Tape{}, Tape{}, 0},
+	} {
+		t.Run(test.tape.String(","), func(t *testing.T) {
+			compacted, start := test.tape.Compact()
+			if !reflect.DeepEqual(compacted, test.expected) || start != test.start {
+				t.Errorf("got %q and %d, want %q and %d", compacted, start, test.expected, test.start)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package turing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Generates m-configurations from templated rules, expanded at compile time with loops
+	// over symbol sets and integer ranges, and conditions.
+	Template struct {
+		// Variables available to every rule
+		Constants map[string]string
+
+		// The rules, expanded in order
+		Rules []TemplateRule
+	}
+
+	// A templated m-configuration. Its name, symbols, operations and final m-configuration may
+	// contain placeholders such as `{s}`, `{i}`, `{i+1}` or `{i-1}`, which are replaced by the
+	// values of variables (integers may be offset).
+	TemplateRule struct {
+		// Nested loops, outermost first. The rule is emitted once for every combination of values.
+		Loops []TemplateLoop
+
+		// The rule is only emitted for combinations of values where all conditions hold
+		Conditions []TemplateCondition
+
+		// The templated m-configuration
+		MConfiguration MConfiguration
+	}
+
+	// Binds a variable to each value in turn
+	TemplateLoop struct {
+		// The name of the variable
+		Var string
+
+		// The values to loop over (i.e. a symbol set). If empty, the integers From...To are used.
+		Values []string
+
+		// The first integer of the range (inclusive)
+		From int
+
+		// The last integer of the range (inclusive)
+		To int
+
+		// If provided, a variable bound to the position (counting from 0) of the value
+		Index string
+	}
+
+	// Compares two templated values. Values that are both integers are compared numerically.
+	TemplateCondition struct {
+		Left string
+
+		// One of `==`, `!=`, `<`, `<=`, `>`, `>=`
+		Operator string
+
+		Right string
+	}
+)
+
+// Returns the m-configurations of every rule, for every combination of its loops' values where
+// its conditions hold. An error wrapping `ErrSyntax` is returned for unknown variables, malformed
+// placeholders, or unknown operators.
+func (t Template) Expand() ([]MConfiguration, error) {
+	mConfigurations := []MConfiguration{}
+	for _, rule := range t.Rules {
+		bindings := map[string]string{}
+		for name, value := range t.Constants {
+			bindings[name] = value
+		}
+		expanded, err := rule.expand(rule.Loops, bindings)
+		if err != nil {
+			return nil, err
+		}
+		mConfigurations = append(mConfigurations, expanded...)
+	}
+	return mConfigurations, nil
+}
+
+// Expands the rule for every value of the first loop, recursing into the remaining loops
+func (r TemplateRule) expand(loops []TemplateLoop, bindings map[string]string) ([]MConfiguration, error) {
+	if len(loops) == 0 {
+		return r.emit(bindings)
+	}
+
+	loop := loops[0]
+	values := loop.Values
+	if len(values) == 0 {
+		values = []string{}
+		for i := loop.From; i <= loop.To; i++ {
+			values = append(values, strconv.Itoa(i))
+		}
+	}
+
+	mConfigurations := []MConfiguration{}
+	for i, value := range values {
+		bindings[loop.Var] = value
+		if len(loop.Index) != 0 {
+			bindings[loop.Index] = strconv.Itoa(i)
+		}
+		expanded, err := r.expand(loops[1:], bindings)
+		if err != nil {
+			return nil, err
+		}
+		mConfigurations = append(mConfigurations, expanded...)
+	}
+	delete(bindings, loop.Var)
+	delete(bindings, loop.Index)
+	return mConfigurations, nil
+}
+
+// Returns the rule's m-configuration with the bindings substituted, if its conditions hold
+func (r TemplateRule) emit(bindings map[string]string) ([]MConfiguration, error) {
+	for _, condition := range r.Conditions {
+		holds, err := condition.holds(bindings)
+		if err != nil || !holds {
+			return nil, err
+		}
+	}
+
+	var err error
+	substitute := func(s string) string {
+		substituted, substituteErr := substituteTemplate(s, bindings)
+		if substituteErr != nil && err == nil {
+			err = substituteErr
+		}
+		return substituted
+	}
+	mConfiguration := MConfiguration{
+		Name:                substitute(r.MConfiguration.Name),
+		Symbols:             []string{},
+		Operations:          []string{},
+		FinalMConfiguration: substitute(r.MConfiguration.FinalMConfiguration),
+	}
+	for _, symbol := range r.MConfiguration.Symbols {
+		mConfiguration.Symbols = append(mConfiguration.Symbols, substitute(symbol))
+	}
+	for _, operation := range r.MConfiguration.Operations {
+		mConfiguration.Operations = append(mConfiguration.Operations, substitute(operation))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []MConfiguration{mConfiguration}, nil
+}
+
+// Returns true if the condition holds for the bindings
+func (c TemplateCondition) holds(bindings map[string]string) (bool, error) {
+	left, err := substituteTemplate(c.Left, bindings)
+	if err != nil {
+		return false, err
+	}
+	right, err := substituteTemplate(c.Right, bindings)
+	if err != nil {
+		return false, err
+	}
+
+	comparison := strings.Compare(left, right)
+	leftInt, leftErr := strconv.Atoi(left)
+	rightInt, rightErr := strconv.Atoi(right)
+	if leftErr == nil && rightErr == nil {
+		comparison = leftInt - rightInt
+	}
+
+	switch c.Operator {
+	case "==":
+		return comparison == 0, nil
+	case "!=":
+		return comparison != 0, nil
+	case "<":
+		return comparison < 0, nil
+	case "<=":
+		return comparison <= 0, nil
+	case ">":
+		return comparison > 0, nil
+	case ">=":
+		return comparison >= 0, nil
+	}
+	return false, fmt.Errorf("%w: unknown operator %q", ErrSyntax, c.Operator)
+}
+
+// Replaces the placeholders in the string with the values of the bindings
+func substituteTemplate(s string, bindings map[string]string) (string, error) {
+	var substituted strings.Builder
+	for {
+		start := strings.Index(s, "{")
+		if start == -1 {
+			substituted.WriteString(s)
+			return substituted.String(), nil
+		}
+		end := strings.Index(s[start:], "}")
+		if end == -1 {
+			return "", fmt.Errorf("%w: unterminated placeholder in %q", ErrSyntax, s)
+		}
+		value, err := evaluateTemplatePlaceholder(s[start+1:start+end], bindings)
+		if err != nil {
+			return "", err
+		}
+		substituted.WriteString(s[:start])
+		substituted.WriteString(value)
+		s = s[start+end+1:]
+	}
+}
+
+// Evaluates `name`, `name+n`, or `name-n`
+func evaluateTemplatePlaceholder(placeholder string, bindings map[string]string) (string, error) {
+	name, offset := strings.TrimSpace(placeholder), 0
+	if i := strings.IndexAny(name, "+-"); i > 0 {
+		var err error
+		offset, err = strconv.Atoi(strings.TrimSpace(name[i:]))
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid offset in {%s}", ErrSyntax, placeholder)
+		}
+		name = strings.TrimSpace(name[:i])
+	}
+
+	value, ok := bindings[name]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown variable in {%s}", ErrSyntax, placeholder)
+	}
+	if offset == 0 {
+		return value, nil
+	}
+	integer, err := strconv.Atoi(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s is not an integer in {%s}", ErrSyntax, value, placeholder)
+	}
+	return strconv.Itoa(integer + offset), nil
+}
@@ -0,0 +1,67 @@
+package turing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateExpand(t *testing.T) {
+	mConfigurations, err := Template{
+		Constants: map[string]string{"n": "3"},
+		Rules: []TemplateRule{
+			{
+				Loops:          []TemplateLoop{{Var: "i", From: 1, To: 3}},
+				Conditions:     []TemplateCondition{{"{i}", "<", "{n}"}},
+				MConfiguration: MConfiguration{"sh{i}", []string{"*"}, []string{"R"}, "sh{i+1}"},
+			},
+			{
+				Loops:          []TemplateLoop{{Var: "i", From: 1, To: 3}, {Var: "s", Values: []string{"0", "1"}}},
+				Conditions:     []TemplateCondition{{"{i}", "==", "{n}"}},
+				MConfiguration: MConfiguration{"sh{i}", []string{"{s}"}, []string{"P{s}"}, "done"},
+			},
+		},
+	}.Expand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []MConfiguration{
+		{"sh1", []string{"*"}, []string{"R"}, "sh2"},
+		{"sh2", []string{"*"}, []string{"R"}, "sh3"},
+		{"sh3", []string{"0"}, []string{"P0"}, "done"},
+		{"sh3", []string{"1"}, []string{"P1"}, "done"},
+	}
+	if !reflect.DeepEqual(mConfigurations, expected) {
+		t.Errorf("got %v, want %v", mConfigurations, expected)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for name, rule := range map[string]TemplateRule{
+		"UnknownVariable": {MConfiguration: MConfiguration{Name: "{x}"}},
+		"NotAnInteger":    {Loops: []TemplateLoop{{Var: "s", Values: []string{"a"}}}, MConfiguration: MConfiguration{Name: "{s+1}"}},
+		"Unterminated":    {MConfiguration: MConfiguration{Name: "{x"}},
+		"UnknownOperator": {Conditions: []TemplateCondition{{"1", "=<", "2"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (Template{Rules: []TemplateRule{rule}}).Expand(); !errors.Is(err, ErrSyntax) {
+				t.Errorf("got %v, want ErrSyntax", err)
+			}
+		})
+	}
+}
+
+func TestEnhancedShowTemplate(t *testing.T) {
+	enhancedShow := getEnhancedShow(SymbolMap{"S0": " ", "S1": "0", "S2": "1"})
+	expected := []MConfiguration{
+		{"sh3", []string{"C"}, []string{"R", "R"}, "sh4"},
+		{"sh4", []string{"C"}, []string{"R", "R"}, "sh5"},
+		{"sh5", []string{"C"}, []string{"R", "R"}, "inst"},
+		{"sh3", []string{"!C", " "}, []string{}, "pe2(inst, _ , :)"},
+		{"sh4", []string{"!C", " "}, []string{}, "pe2(inst, _0, :)"},
+		{"sh5", []string{"!C", " "}, []string{}, "pe2(inst, _1, :)"},
+	}
+	if !reflect.DeepEqual(enhancedShow[4:], expected) {
+		t.Errorf("got %v, want %v", enhancedShow[4:], expected)
+	}
+}
@@ -0,0 +1,82 @@
+// A reference reimplementation of the semantics of Anthony Morphett's online Turing machine simulator,
+// as its documentation describes them, used by `TestMorphettSimulator`. It is not Morphett's code, so
+// the test checks the translation to his format, not conformance with his simulator.
+//
+// Usage: node morphett.js <tape> <most steps> < program
+//
+// The tape uses `_` for blanks and `*` before the scanned square. The outcome is written as lines of
+// the form `state <state>`, `steps <steps>`, `head <offset>`, `halted <true or false>` and `tape <tape>`.
+
+const fs = require("fs");
+
+const [input, maxSteps] = process.argv.slice(2);
+
+// Rules by state and symbol. Either may be `*`, which matches anything not matched exactly.
+const rules = new Map();
+for (const line of fs.readFileSync(0, "utf8").split("\n")) {
+  const fields = line.split(";")[0].trim().split(/\s+/);
+  if (fields.length !== 5) {
+    continue;
+  }
+  const [state, symbol, write, move, next] = fields;
+  const key = state + " " + symbol;
+  if (!rules.has(key)) {
+    rules.set(key, { write, move, next });
+  }
+}
+
+const findRule = (state, symbol) =>
+  rules.get(state + " " + symbol) ||
+  rules.get(state + " *") ||
+  rules.get("* " + symbol) ||
+  rules.get("* *");
+
+// Squares by position relative to the square originally scanned
+const tape = new Map();
+const chars = Array.from(input);
+const start = Math.max(chars.indexOf("*"), 0);
+chars.filter((char) => char !== "*").forEach((char, i) => tape.set(i - start, char));
+
+let state = "0";
+let head = 0;
+let steps = 0;
+let halted = false;
+let [left, right] = [-start, chars.length - 1 - start];
+while (steps < Number(maxSteps)) {
+  if (state.startsWith("halt")) {
+    halted = true;
+    break;
+  }
+  const symbol = tape.get(head) || "_";
+  const rule = findRule(state, symbol);
+  if (!rule) {
+    halted = true;
+    break;
+  }
+  if (rule.write !== "*") {
+    tape.set(head, rule.write);
+  }
+  if (rule.move === "l") {
+    head--;
+  } else if (rule.move === "r") {
+    head++;
+  }
+  if (rule.next !== "*") {
+    state = rule.next;
+  }
+  steps++;
+  [left, right] = [Math.min(left, head), Math.max(right, head)];
+}
+if (state.startsWith("halt")) {
+  halted = true;
+}
+
+let written = "";
+for (let square = left; square <= right; square++) {
+  written += tape.get(square) || "_";
+}
+console.log("state " + state);
+console.log("steps " + steps);
+console.log("head " + head);
+console.log("halted " + halted);
+console.log("tape " + written);
@@ -0,0 +1,82 @@
+package turing
+
+type (
+	// Receives spans for compiling abbreviated tables, standardizing tables, and segments of runs,
+	// so applications embedding the package can see where time goes in their traces. Adapts
+	// readily to an OpenTelemetry tracer (see `MachineInput.Tracer`).
+	Tracer interface {
+		// Starts a span
+		Start(name string, attributes ...SpanAttribute) Span
+	}
+
+	// A span started by a Tracer
+	Span interface {
+		// Ends the span, with attributes only known once the work is done
+		End(attributes ...SpanAttribute)
+	}
+
+	// An attribute of a span. Values are strings, ints or bools.
+	SpanAttribute struct {
+		Key   string
+		Value interface{}
+	}
+
+	// Adapts a function to a Tracer
+	TracerFunc func(name string, attributes ...SpanAttribute) Span
+)
+
+const (
+	// The names of the spans
+	SpanCompileAbbreviatedTable string = "turing.compile_abbreviated_table"
+	SpanStandardize             string = "turing.standardize"
+	SpanRun                     string = "turing.run"
+
+	// The amount of moves in each run span if not provided
+	defaultSpanRunInterval int = 10000
+)
+
+func (f TracerFunc) Start(name string, attributes ...SpanAttribute) Span {
+	return f(name, attributes...)
+}
+
+// Starts a span with the tracer. Returns nil if the tracer is nil.
+func startSpan(tracer Tracer, name string, attributes ...SpanAttribute) Span {
+	if tracer == nil {
+		return nil
+	}
+	return tracer.Start(name, attributes...)
+}
+
+// Ends the span, if tracing was on when it started
+func endSpan(span Span, attributes ...SpanAttribute) {
+	if span != nil {
+		span.End(attributes...)
+	}
+}
+
+// Moves the machine n times, with a span for each run interval of moves. The moves are counted across
+// calls, so a span stays open until the machine has made the run interval's moves or halts.
+func (m *Machine) tracedMoveN(n int) int {
+	moves := 0
+	for moves < n {
+		if m.runSpan == nil {
+			m.runSpan = m.tracer.Start(SpanRun,
+				SpanAttribute{"turing.move", m.moves},
+				SpanAttribute{"turing.m_configuration", m.currentMConfigurationName})
+			m.runSpanMoves = 0
+		}
+		segment := m.moveN(min(m.spanRunInterval-m.runSpanMoves, n-moves))
+		moves += segment
+		m.runSpanMoves += segment
+		if m.runSpanMoves == m.spanRunInterval || m.halted {
+			m.runSpan.End(
+				SpanAttribute{"turing.moves", m.runSpanMoves},
+				SpanAttribute{"turing.halted", m.halted})
+			m.runSpan = nil
+		}
+		if m.halted {
+			break
+		}
+	}
+	return moves
+}
@@ -0,0 +1,78 @@
+package turing
+
+import "testing"
+
+type recordedSpan struct {
+	name       string
+	attributes []SpanAttribute
+	ended      bool
+}
+
+func (s *recordedSpan) End(attributes ...SpanAttribute) {
+	s.attributes = append(s.attributes, attributes...)
+	s.ended = true
+}
+
+func TestTracer(t *testing.T) {
+	spans := []*recordedSpan{}
+	tracer := TracerFunc(func(name string, attributes ...SpanAttribute) Span {
+		span := &recordedSpan{name: name, attributes: attributes}
+		spans = append(spans, span)
+		return span
+	})
+
+	abbreviated := UnaryIncrementAbbreviatedTable()
+	abbreviated.Tracer = tracer
+	abbreviated.SpanRunInterval = 4
+	input := NewAbbreviatedTable(abbreviated)
+	NewStandardTable(input)
+	input.Tape = UnaryTape(3)
+	m := NewMachine(input)
+	m.MoveN(100)
+
+	names := []string{}
+	for _, span := range spans {
+		names = append(names, span.name)
+		if !span.ended {
+			t.Errorf("span %s did not end", span.name)
+		}
+	}
+	// The machine halts during its second segment of 4 moves
+	expected := []string{SpanCompileAbbreviatedTable, SpanStandardize, SpanRun, SpanRun}
+	if len(names) != len(expected) {
+		t.Fatalf("got spans %v, want %v", names, expected)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("got spans %v, want %v", names, expected)
+		}
+	}
+	last := spans[len(spans)-1].attributes
+	if last[0] != (SpanAttribute{"turing.move", 4}) || last[len(last)-1] != (SpanAttribute{"turing.halted", true}) {
+		t.Errorf("got attributes %v", last)
+	}
+
+	t.Run("AcrossCalls", func(t *testing.T) {
+		count := len(spans)
+		m := NewMachine(input)
+		for i := 0; i < 5; i++ {
+			m.MoveN(1)
+		}
+		// A span for the first 4 moves, and one still open
+		if len(spans)-count != 2 || !spans[count].ended || spans[count+1].ended {
+			t.Fatalf("got %d spans, want one ended and one open", len(spans)-count)
+		}
+		if moves := spans[count].attributes[2]; moves != (SpanAttribute{"turing.moves", 4}) {
+			t.Errorf("got %v, want 4 moves", moves)
+		}
+	})
+
+	t.Run("Off", func(t *testing.T) {
+		count := len(spans)
+		input.Tracer = nil
+		NewMachine(input).MoveN(100)
+		if len(spans) != count {
+			t.Errorf("got %d spans from a machine without a tracer", len(spans)-count)
+		}
+	})
+}
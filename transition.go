@@ -0,0 +1,160 @@
+package turing
+
+import (
+	"slices"
+	"strconv"
+)
+
+type (
+	// A row of a table in the 5-tuple form most tools expect: in the m-configuration `State`, scanning
+	// the symbol `Read`, the machine writes the symbol `Write`, moves `Move` (`L`, `R` or `N`) and
+	// goes to the m-configuration `Next` (see `MachineInput.Transitions`)
+	Transition struct {
+		State string
+		Read  string
+		Write string
+		Move  string
+		Next  string
+	}
+
+	// A part of a row's operations that a single transition performs
+	transitionStep struct {
+		// The symbol written (if empty, the symbol scanned is written back)
+		write string
+
+		// The move made (if empty, `N`)
+		move string
+	}
+
+	// The first step of a row split into transitions, and the m-configuration it goes to
+	splitRow struct {
+		first transitionStep
+		next  string
+	}
+)
+
+// Returns the table as transitions, one for each m-configuration and symbol it scans (the None symbol
+// and every symbol the machine declares, prints, matches or has on its Tape). Rows matching many symbols
+// (`*`, `!x` or no symbols at all) are expanded in order, so that earlier rows take precedence as they do
+// when the machine runs. A row whose operations are not a write followed by a move is split into a
+// transition for each, through new m-configurations named after the row's (`b.1`, `b.2`, and so on), so
+// the machine makes more moves but prints the same sequence. A row that halts the machine (`H`) goes
+// to `halt`, which must have no rows.
+func (input MachineInput) Transitions() []Transition {
+	m := NewMachine(input)
+	symbols := append([]string{m.noneSymbol}, machineSymbols(input)...)
+
+	// The names of new m-configurations must not be taken
+	taken := map[string]bool{haltMConfigurationName: true}
+	for _, mConfiguration := range input.MConfigurations {
+		taken[mConfiguration.Name] = true
+		taken[mConfiguration.FinalMConfiguration] = true
+	}
+	counts := map[string]int{}
+	newName := func(name string) string {
+		for {
+			counts[name]++
+			split := name + "." + strconv.Itoa(counts[name])
+			if !taken[split] {
+				taken[split] = true
+				return split
+			}
+		}
+	}
+
+	// The transitions for the rest of each row are only written once, after the others
+	transitions := []Transition{}
+	rest := []Transition{}
+	splitRows := map[int]splitRow{}
+	for _, name := range mConfigurationNames(input) {
+		for _, symbol := range symbols {
+			i := slices.IndexFunc(input.MConfigurations, func(mConfiguration MConfiguration) bool {
+				return mConfiguration.Name == name && m.matches(mConfiguration, symbol)
+			})
+			if i < 0 {
+				continue
+			}
+			split, ok := splitRows[i]
+			if !ok {
+				steps, final := transitionSteps(input.MConfigurations[i], m.noneSymbol)
+				names := []string{}
+				for range steps[1:] {
+					names = append(names, newName(name))
+				}
+				names = append(names, final)
+				for j, step := range steps[1:] {
+					for _, symbol := range symbols {
+						rest = append(rest, step.transition(names[j], symbol, names[j+1]))
+					}
+				}
+				split = splitRow{steps[0], names[0]}
+				splitRows[i] = split
+			}
+			transitions = append(transitions, split.first.transition(name, symbol, split.next))
+		}
+	}
+	return append(transitions, rest...)
+}
+
+// Returns the rows of the transitions, one for each, printing the symbol written and then moving. Together
+// with the rest of the machine's input, they make a machine that runs as the transitions describe.
+func MConfigurationsFromTransitions(transitions []Transition) []MConfiguration {
+	mConfigurations := []MConfiguration{}
+	for _, transition := range transitions {
+		mConfigurations = append(mConfigurations, MConfiguration{
+			Name:                transition.State,
+			Symbols:             []string{transition.Read},
+			Operations:          []string{string(printOp) + transition.Write, transition.Move},
+			FinalMConfiguration: transition.Next,
+		})
+	}
+	return mConfigurations
+}
+
+// Splits the row's operations into steps of a write followed by a move, and returns the m-configuration
+// the last step goes to
+func transitionSteps(mConfiguration MConfiguration, noneSymbol string) ([]transitionStep, string) {
+	final := mConfiguration.FinalMConfiguration
+	steps := []transitionStep{}
+	step := transitionStep{}
+	for _, operation := range mConfiguration.Operations {
+		if len(operation) == 0 {
+			continue
+		}
+		switch operationCode(operation[0]) {
+		case printOp, eraseOp:
+			if len(step.write) != 0 || len(step.move) != 0 {
+				steps = append(steps, step)
+				step = transitionStep{}
+			}
+			step.write = noneSymbol
+			if operationCode(operation[0]) == printOp {
+				step.write = operation[1:]
+			}
+		case leftOp, rightOp:
+			if len(step.move) != 0 {
+				steps = append(steps, step)
+				step = transitionStep{}
+			}
+			step.move = operation
+		case haltOp:
+			final = haltMConfigurationName
+		}
+	}
+	if len(steps) == 0 || len(step.write) != 0 || len(step.move) != 0 {
+		steps = append(steps, step)
+	}
+	return steps, final
+}
+
+// Returns the transition taking the step from the m-configuration scanning the symbol
+func (step transitionStep) transition(state string, symbol string, next string) Transition {
+	transition := Transition{State: state, Read: symbol, Write: symbol, Move: string(noOp), Next: next}
+	if len(step.write) != 0 {
+		transition.Write = step.write
+	}
+	if len(step.move) != 0 {
+		transition.Move = step.move
+	}
+	return transition
+}
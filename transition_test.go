@@ -0,0 +1,77 @@
+package turing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+func TestTransitions(t *testing.T) {
+	t.Run("Quintuples", func(t *testing.T) {
+		// Turing's first example, whose rows are already 5-tuples
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"R"}, "e"},
+				{"e", []string{" "}, []string{"P1", "R"}, "k"},
+				{"k", []string{" "}, []string{"R"}, "b"},
+			},
+			PossibleSymbols: []string{"0", "1"},
+		}
+		want := []Transition{
+			{"b", " ", "0", "R", "c"},
+			{"c", " ", " ", "R", "e"},
+			{"e", " ", "1", "R", "k"},
+			{"k", " ", " ", "R", "b"},
+		}
+		if got := input.Transitions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Expanded", func(t *testing.T) {
+		// Earlier rows take precedence, and rows are split into a write and a move
+		input := MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{"0"}, []string{"E"}, "b"},
+				{"b", []string{}, []string{"P0", "R", "L", "P1"}, "halt"},
+			},
+			PossibleSymbols: []string{"0", "1"},
+		}
+		want := []Transition{
+			{"b", " ", "0", "R", "b.1"},
+			{"b", "0", " ", "N", "b"},
+			{"b", "1", "0", "R", "b.1"},
+			{"b.1", " ", " ", "L", "b.2"},
+			{"b.1", "0", "0", "L", "b.2"},
+			{"b.1", "1", "1", "L", "b.2"},
+			{"b.2", " ", "1", "N", "halt"},
+			{"b.2", "0", "1", "N", "halt"},
+			{"b.2", "1", "1", "N", "halt"},
+		}
+		if got := input.Transitions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		input := UnaryAdditionMachine()
+		input.Tape = UnaryTape(2, 3)
+		transitioned := input
+		transitioned.MConfigurations = MConfigurationsFromTransitions(input.Transitions())
+
+		m := NewMachine(input)
+		if _, err := m.RunUntilHalt(1000); err != nil {
+			t.Fatal(err)
+		}
+		transitionedM := NewMachine(transitioned)
+		if _, err := transitionedM.RunUntilHalt(1000); err != nil {
+			t.Fatal(err)
+		}
+		turingtest.Equal(t, transitionedM.TapeString(), m.TapeString())
+		if got := transitioned.Transitions(); !reflect.DeepEqual(got, input.Transitions()) {
+			t.Errorf("got %v, want the same transitions", got)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Package turingtest provides helpers for testing machines: asserting tapes, complete
+// configurations (or the traces they form), and Standard Descriptions or Description Numbers,
+// inline or against golden files.
+//
+// Golden files live in the `testdata` directory of the package under test, and are rewritten with
+// the actual values when the tests are run with `-update`:
+//
+//	go test ./... -update
+package turingtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var (
+	update = flag.Bool("update", false, "rewrite golden files with the actual values")
+)
+
+const (
+	// The directory holding golden files, relative to the package under test
+	goldenDirectory string = "testdata"
+
+	// The extension of golden files
+	goldenExtension string = ".golden"
+
+	// The amount of extra characters of a tape shown when it does not have the expected prefix
+	tapeContext int = 10
+
+	// The step of a trace matching any amount of entries (see `Trace`)
+	traceEventually string = "..."
+
+	// Matches any characters within a step of a trace (see `Trace`)
+	traceWildcard string = "*"
+)
+
+// Asserts that the tape begins with the prefix
+func TapePrefix[T ~string](t testing.TB, tape T, prefix string) {
+	t.Helper()
+	if !strings.HasPrefix(string(tape), prefix) {
+		actual := string(tape)
+		if len(prefix)+tapeContext <= len(actual) {
+			actual = actual[0 : len(prefix)+tapeContext]
+		}
+		t.Errorf("got %s, want %s", actual, prefix)
+	}
+}
+
+// Asserts that the value (i.e. a complete configuration, S.D. or D.N.) is the expected value
+func Equal[T ~string](t testing.TB, actual T, expected string) {
+	t.Helper()
+	if string(actual) != expected {
+		t.Errorf("got %s, want %s", actual, expected)
+	}
+}
+
+// Asserts that the sequence of complete configurations is the expected sequence
+func CompleteConfigurations(t testing.TB, actual []string, expected []string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Errorf("got %d complete configurations, want %d", len(actual), len(expected))
+	}
+	for i := 0; i < min(len(actual), len(expected)); i++ {
+		if actual[i] != expected[i] {
+			t.Errorf("got %s, want %s (complete configuration %d)", actual[i], expected[i], i)
+			return
+		}
+	}
+}
+
+// Asserts that the trace (i.e. the complete configurations or m-configurations a machine passes through,
+// in order) matches the steps. Each step matches one entry of the trace, and `*` within a step matches
+// any characters (so `q*` matches every m-configuration beginning with `q`). The step `...` matches
+// any amount of entries, so `"b", "...", "k"` asserts that the trace begins with `b` and eventually
+// reaches `k`. The trace may continue after the last step.
+func Trace(t testing.TB, actual []string, steps ...string) {
+	t.Helper()
+	if matched := matchTrace(actual, steps); matched < len(steps) {
+		t.Errorf("got trace %q, want step %d (%s) of %q", actual, matched, steps[matched], steps)
+	}
+}
+
+// Returns the amount of steps the trace matches (all of them, if it matches)
+func matchTrace(actual []string, steps []string) int {
+	if len(steps) == 0 {
+		return 0
+	}
+	if steps[0] == traceEventually {
+		matched := 0
+		for i := 0; i <= len(actual); i++ {
+			rest := matchTrace(actual[i:], steps[1:])
+			if rest == len(steps)-1 {
+				return len(steps)
+			}
+			matched = max(matched, rest+1)
+		}
+		return matched
+	}
+	if len(actual) == 0 || !matchStep(actual[0], steps[0]) {
+		return 0
+	}
+	return matchTrace(actual[1:], steps[1:]) + 1
+}
+
+// Returns true if the entry matches the step, where `*` matches any characters
+func matchStep(entry string, step string) bool {
+	prefix, rest, wildcard := strings.Cut(step, traceWildcard)
+	if !wildcard {
+		return entry == step
+	}
+	if !strings.HasPrefix(entry, prefix) {
+		return false
+	}
+	for i := len(prefix); i <= len(entry); i++ {
+		if matchStep(entry[i:], rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// Asserts that the value is the contents of the golden file `testdata/<name>.golden`
+func Golden[T ~string](t testing.TB, name string, actual T) {
+	t.Helper()
+	path := filepath.Join(goldenDirectory, name+goldenExtension)
+	if *update {
+		if err := os.MkdirAll(goldenDirectory, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	Equal(t, actual, string(expected))
+}
+
+// Asserts that the sequence of complete configurations, one per line, is the contents of the
+// golden file `testdata/<name>.golden`
+func GoldenCompleteConfigurations(t testing.TB, name string, actual []string) {
+	t.Helper()
+	path := filepath.Join(goldenDirectory, name+goldenExtension)
+	if *update {
+		Golden(t, name, strings.Join(actual, "\n")+"\n")
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	CompleteConfigurations(t, actual, strings.Split(strings.TrimSuffix(string(expected), "\n"), "\n"))
+}
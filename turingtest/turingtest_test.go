@@ -0,0 +1,97 @@
+package turingtest
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Records the failures of a test, rather than failing
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func checkFailures(t *testing.T, r *recorder, expected ...string) {
+	t.Helper()
+	if fmt.Sprint(r.failures) != fmt.Sprint(expected) {
+		t.Errorf("got failures %q, want %q", r.failures, expected)
+	}
+}
+
+func TestTapePrefix(t *testing.T) {
+	r := &recorder{TB: t}
+	TapePrefix(r, "0 1 0 1 0 1 0 1 0 1", "0 1 0")
+	checkFailures(t, r)
+	TapePrefix(r, "0 1 0 1 0 1 0 1 0 1", "1")
+	checkFailures(t, r, "got 0 1 0 1 0 1, want 1")
+	TapePrefix(r, "0 1", "1 0")
+	checkFailures(t, r, "got 0 1 0 1 0 1, want 1", "got 0 1, want 1 0")
+}
+
+func TestEqual(t *testing.T) {
+	type description string
+	r := &recorder{TB: t}
+	Equal(r, description("DADDCRDAA;"), "DADDCRDAA;")
+	checkFailures(t, r)
+	Equal(r, description("DADDCRDAA;"), "DADDRDAA;")
+	checkFailures(t, r, "got DADDCRDAA;, want DADDRDAA;")
+}
+
+func TestCompleteConfigurations(t *testing.T) {
+	r := &recorder{TB: t}
+	CompleteConfigurations(r, []string{"b", "q0"}, []string{"b", "q0"})
+	checkFailures(t, r)
+	CompleteConfigurations(r, []string{"b", "q1"}, []string{"b", "q0", "0 c"})
+	checkFailures(t, r, "got 2 complete configurations, want 3", "got q1, want q0 (complete configuration 1)")
+}
+
+func TestTrace(t *testing.T) {
+	trace := []string{"b", "0c", "0 e", "0 1k", "0 1 b"}
+	for _, steps := range [][]string{
+		{},
+		{"b", "0c"},
+		{"b", "...", "0 1k"},
+		{"...", "0 1k", "0 1 b"},
+		{"...", "*k", "...", "*b"},
+		{"b", "0*", "0 *", "...", "0 1 b", "..."},
+	} {
+		r := &recorder{TB: t}
+		Trace(r, trace, steps...)
+		checkFailures(t, r)
+	}
+
+	r := &recorder{TB: t}
+	Trace(r, trace, "b", "0c", "0 1k")
+	checkFailures(t, r, `got trace ["b" "0c" "0 e" "0 1k" "0 1 b"], want step 2 (0 1k) of ["b" "0c" "0 1k"]`)
+
+	r = &recorder{TB: t}
+	Trace(r, trace, "...", "*k", "...", "*c")
+	checkFailures(t, r, `got trace ["b" "0c" "0 e" "0 1k" "0 1 b"], want step 3 (*c) of ["..." "*k" "..." "*c"]`)
+
+	r = &recorder{TB: t}
+	Trace(r, trace[:1], "b", "0c")
+	checkFailures(t, r, `got trace ["b"], want step 1 (0c) of ["b" "0c"]`)
+}
+
+func TestGolden(t *testing.T) {
+	r := &recorder{TB: t}
+	Golden(r, "example", "b\nq0\n0 c\n")
+	GoldenCompleteConfigurations(r, "example", []string{"b", "q0", "0 c"})
+	checkFailures(t, r)
+	GoldenCompleteConfigurations(r, "example", []string{"b", "q0", "0 k"})
+	checkFailures(t, r, "got 0 k, want 0 c (complete configuration 2)")
+	Golden(r, "missing", "")
+	if len(r.failures) != 2 {
+		t.Errorf("got failures %q, want a failure for the missing golden file", r.failures)
+	}
+}
@@ -0,0 +1,116 @@
+package turing
+
+import "strings"
+
+// The m-functions below compute with numbers written in unary (`n` is written as `n` 1's),
+// with numbers separated by a single blank square. Each begins scanning the leftmost square
+// of its first number, and -> `C` once done.
+var (
+	// `inc(C)`. Adds one to the number.
+	unaryIncrement = []MConfiguration{
+		{"inc(C)", []string{"1"}, []string{"R"}, "inc(C)"},
+		{"inc(C)", []string{" "}, []string{"P1"}, "C"},
+	}
+
+	// `add(C)`. Replaces the two numbers with their sum, by filling in the blank between
+	// them and erasing the final 1.
+	unaryAddition = []MConfiguration{
+		{"add(C)", []string{"1"}, []string{"R"}, "add(C)"},
+		{"add(C)", []string{" "}, []string{"P1", "R"}, "add1(C)"},
+		{"add1(C)", []string{"1"}, []string{"R"}, "add1(C)"},
+		{"add1(C)", []string{" "}, []string{"L"}, "add2(C)"},
+		{"add2(C)", []string{"1"}, []string{"E"}, "C"},
+	}
+
+	// `mul(C)`. Writes the product of the two numbers after them (separated by a blank). For each
+	// 1 of the first number (marked `x` while in use), each 1 of the second number (marked `y`
+	// while in use) is copied to the end. The marks are then erased.
+	unaryMultiplication = []MConfiguration{
+		{"mul(C)", []string{"1"}, []string{"Px", "R"}, "mul1(C)"},
+		{"mul(C)", []string{" "}, []string{"L"}, "mul7(C)"},
+		// Move to the second number
+		{"mul1(C)", []string{"1"}, []string{"R"}, "mul1(C)"},
+		{"mul1(C)", []string{" "}, []string{"R"}, "mul2(C)"},
+		// Mark the next 1 of the second number, or restore it once all are marked
+		{"mul2(C)", []string{"y"}, []string{"R"}, "mul2(C)"},
+		{"mul2(C)", []string{"1"}, []string{"Py", "R"}, "mul3(C)"},
+		{"mul2(C)", []string{" "}, []string{"L"}, "mul6(C)"},
+		// Print a 1 at the end of the product
+		{"mul3(C)", []string{"1"}, []string{"R"}, "mul3(C)"},
+		{"mul3(C)", []string{" "}, []string{"R"}, "mul4(C)"},
+		{"mul4(C)", []string{"1"}, []string{"R"}, "mul4(C)"},
+		{"mul4(C)", []string{" "}, []string{"P1"}, "mul5(C)"},
+		// Return to the last marked 1 of the second number
+		{"mul5(C)", []string{"1", " "}, []string{"L"}, "mul5(C)"},
+		{"mul5(C)", []string{"y"}, []string{"R"}, "mul2(C)"},
+		// Restore the second number, and return to the last marked 1 of the first number
+		{"mul6(C)", []string{"y"}, []string{"P1", "L"}, "mul6(C)"},
+		{"mul6(C)", []string{" "}, []string{"L"}, "mul8(C)"},
+		{"mul8(C)", []string{"1"}, []string{"L"}, "mul8(C)"},
+		{"mul8(C)", []string{"x"}, []string{"R"}, "mul(C)"},
+		// Restore the first number
+		{"mul7(C)", []string{"x"}, []string{"P1", "L"}, "mul7(C)"},
+		{"mul7(C)", []string{" "}, []string{"R"}, "C"},
+	}
+)
+
+// Returns an abbreviated table that adds one to a unary number, then halts
+func UnaryIncrementAbbreviatedTable() AbbreviatedTableInput {
+	return unaryAbbreviatedTable("inc(halt)", unaryIncrement)
+}
+
+// Returns an abbreviated table that adds two unary numbers, then halts
+func UnaryAdditionAbbreviatedTable() AbbreviatedTableInput {
+	return unaryAbbreviatedTable("add(halt)", unaryAddition)
+}
+
+// Returns an abbreviated table that multiplies two unary numbers, then halts
+func UnaryMultiplicationAbbreviatedTable() AbbreviatedTableInput {
+	return unaryAbbreviatedTable("mul(halt)", unaryMultiplication)
+}
+
+// Returns a machine that adds one to a unary number (see `UnaryTape`), then halts
+func UnaryIncrementMachine() MachineInput {
+	return NewAbbreviatedTable(UnaryIncrementAbbreviatedTable())
+}
+
+// Returns a machine that adds two unary numbers (see `UnaryTape`), then halts
+func UnaryAdditionMachine() MachineInput {
+	return NewAbbreviatedTable(UnaryAdditionAbbreviatedTable())
+}
+
+// Returns a machine that multiplies two unary numbers (see `UnaryTape`), then halts.
+// The product is written after the numbers.
+func UnaryMultiplicationMachine() MachineInput {
+	return NewAbbreviatedTable(UnaryMultiplicationAbbreviatedTable())
+}
+
+// Returns an abbreviated table that calls the m-function and then halts
+func unaryAbbreviatedTable(call string, mFunction []MConfiguration) AbbreviatedTableInput {
+	mConfigurations := []MConfiguration{
+		{"b", []string{"*", " "}, []string{}, call},
+	}
+	mConfigurations = append(mConfigurations, cloneMConfigurations(mFunction)...)
+	return AbbreviatedTableInput{
+		MConfigurations: mConfigurations,
+		PossibleSymbols: []string{"1", "x", "y"},
+	}
+}
+
+// Returns a tape with the numbers written in unary, separated by blanks
+func UnaryTape(numbers ...int) Tape {
+	values := []Tape{}
+	for _, number := range numbers {
+		values = append(values, EncodeUnary(number))
+	}
+	return EncodeDelimited(values, none)
+}
+
+// Returns the unary numbers on the tape, ignoring blanks at either end
+func UnaryNumbers(tape string) []int {
+	numbers := []int{}
+	for _, number := range strings.Split(strings.Trim(tape, none), none) {
+		numbers = append(numbers, len(number))
+	}
+	return numbers
+}
@@ -0,0 +1,53 @@
+package turing
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestUnaryIncrementMachine(t *testing.T) {
+	for _, n := range []int{0, 1, 4} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			checkUnaryMachine(t, UnaryIncrementMachine(), UnaryTape(n), []int{n + 1})
+		})
+	}
+}
+
+func TestUnaryAdditionMachine(t *testing.T) {
+	for _, numbers := range [][]int{{1, 1}, {3, 2}, {0, 2}, {2, 0}} {
+		t.Run(strconv.Itoa(numbers[0])+"+"+strconv.Itoa(numbers[1]), func(t *testing.T) {
+			checkUnaryMachine(t, UnaryAdditionMachine(), UnaryTape(numbers...), []int{numbers[0] + numbers[1]})
+		})
+	}
+}
+
+func TestUnaryMultiplicationMachine(t *testing.T) {
+	for _, numbers := range [][]int{{1, 1}, {3, 2}, {2, 4}} {
+		t.Run(strconv.Itoa(numbers[0])+"*"+strconv.Itoa(numbers[1]), func(t *testing.T) {
+			checkUnaryMachine(t, UnaryMultiplicationMachine(), UnaryTape(numbers...), []int{numbers[0], numbers[1], numbers[0] * numbers[1]})
+		})
+	}
+}
+
+func TestUnaryStandardized(t *testing.T) {
+	input := UnaryAdditionMachine()
+	input.Tape = UnaryTape(2, 3)
+	st := NewStandardTable(input)
+	m := NewMachine(st.MachineInput)
+	m.MoveN(1000)
+	if numbers := UnaryNumbers(st.SymbolMap.TranslateTape(m.Tape())); !reflect.DeepEqual(numbers, []int{5}) {
+		t.Errorf("got %v, want [5]", numbers)
+	}
+}
+
+func checkUnaryMachine(t *testing.T, input MachineInput, tape Tape, expected []int) {
+	input.Tape = tape
+	m := NewMachine(input)
+	if _, err := m.RunUntilHalt(10000); err != nil {
+		t.Fatal(err)
+	}
+	if numbers := UnaryNumbers(m.TapeString()); !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("got %v, want %v", numbers, expected)
+	}
+}
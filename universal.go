@@ -122,7 +122,36 @@ type (
 	UniversalMachineInput struct {
 		StandardDescription
 		SymbolMap
+
+		// If `true`, `U` does not print `M`'s figures, so the tape holds only the successive
+		// complete configurations of `M` (see `CompleteConfigurationsFromUniversalMachine`)
+		OmitFigures bool
+
+		// How `U` prints `M`'s figures (defaults to `EnhancedShow`)
+		Show UniversalMachineShow
 	}
+
+	// Which version of the `sh` m-function `U` uses to print `M`'s figures
+	UniversalMachineShow int
+
+	// The figures checked by `VerifyUniversal`
+	UniversalVerification struct {
+		// The figures `U` showed, matching those `M` printed
+		Figures []string
+
+		// For each figure, the amount of moves `U` had made when it was shown
+		Moves []int
+	}
+)
+
+// The most moves `U` may make between showing figures in `VerifyUniversal`
+const universalMaxMovesPerFigure = 10000000
+
+const (
+	// Every symbol `M` prints is shown, prefixed with `_` (see `TapeStringFromUniversalMachine`)
+	EnhancedShow UniversalMachineShow = iota
+	// Turing's original `show`, which only shows `0` (S1) and `1` (S2)
+	OriginalShow
 )
 
 // If `M` is a Machine that computes a sequence, this function takes the Standard Description of `M` and returns
@@ -130,18 +159,24 @@ type (
 func NewUniversalMachine(input UniversalMachineInput) MachineInput {
 	// Helper MFunctions
 	mConfigurations := []MConfiguration{}
-	mConfigurations = append(mConfigurations, allhelperFunctions()...)
+	mConfigurations = append(mConfigurations, StandardSkeletons()...)
 
 	// Universal Machine MFunctions
-	mConfigurations = append(mConfigurations, configuration...)
-	mConfigurations = append(mConfigurations, begin...)
-	mConfigurations = append(mConfigurations, anfang...)
-	mConfigurations = append(mConfigurations, kom...)
-	mConfigurations = append(mConfigurations, kmp...)
-	mConfigurations = append(mConfigurations, similar...)
-	mConfigurations = append(mConfigurations, mark...)
-	mConfigurations = append(mConfigurations, getEnhancedShow(input.SymbolMap)...)
-	mConfigurations = append(mConfigurations, instruction...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(configuration)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(begin)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(anfang)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(kom)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(kmp)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(similar)...)
+	mConfigurations = append(mConfigurations, cloneMConfigurations(mark)...)
+	if input.OmitFigures {
+		mConfigurations = append(mConfigurations, MConfiguration{"sh", []string{"*", " "}, []string{}, "inst"})
+	} else if input.Show == OriginalShow {
+		mConfigurations = append(mConfigurations, cloneMConfigurations(show)...)
+	} else {
+		mConfigurations = append(mConfigurations, getEnhancedShow(input.SymbolMap)...)
+	}
+	mConfigurations = append(mConfigurations, cloneMConfigurations(instruction)...)
 
 	// Construct tape
 	tapeFromStandardDescription := []string{"e", "e"}
@@ -160,46 +195,126 @@ func NewUniversalMachine(input UniversalMachineInput) MachineInput {
 	})
 }
 
+// Standardizes `U` itself, as it is when simulating machines that print the symbols of `symbolMap`
+// (see `NewUniversalMachine`), so it can be given its own S.D. and D.N. `U`'s tape does not affect them.
+// Note that `U` is large: its S.D. is tens of millions of characters long.
+func UniversalMachineStandardTable(symbolMap SymbolMap) StandardTable {
+	return NewStandardTable(NewUniversalMachine(UniversalMachineInput{
+		SymbolMap: symbolMap,
+	}))
+}
+
+// Runs `M` directly and under `U` (see `NewUniversalMachine`), and checks that `U` shows the same first
+// `digits` figures that `M` prints. With `EnhancedShow`, `U` shows every symbol `M` prints (one per move of
+// `M` in standard form). Fewer figures are checked if `M` halts sooner. An error wrapping
+// `ErrUniversalMismatch` is returned for the first figure that differs, or wrapping `ErrStepLimit` if `U`
+// takes more than `universalMaxMovesPerFigure` moves to show a figure.
+func VerifyUniversal(input MachineInput, digits int) (UniversalVerification, error) {
+	st := NewStandardTable(input)
+
+	// Every move of `M` in standard form prints exactly one symbol
+	standard := st.MachineInput
+	standard.RecordTapeWrites = true
+	m := NewMachine(standard)
+	m.MoveN(digits)
+	expected := []string{}
+	for _, write := range m.TapeWrites() {
+		expected = append(expected, st.SymbolMap[write.New])
+	}
+
+	universal := NewUniversalMachine(UniversalMachineInput{
+		StandardDescription: st.StandardDescription,
+		SymbolMap:           st.SymbolMap,
+	})
+	universal.RecordTapeWrites = true
+	um := NewMachine(universal)
+
+	verification := UniversalVerification{
+		Figures: []string{},
+		Moves:   []int{},
+	}
+	for moves := 0; len(verification.Figures) < len(expected); {
+		if moves-verification.lastMoves() >= universalMaxMovesPerFigure || um.halted {
+			return verification, fmt.Errorf("%w: figure %d was not shown after %d moves", ErrStepLimit, len(verification.Figures), moves)
+		}
+		um.Move()
+		moves++
+
+		// Shown figures are the only symbols `U` prints that are prefixed with `_`
+		for _, write := range um.tapeWrites {
+			if !strings.HasPrefix(write.New, "_") {
+				continue
+			}
+			i := len(verification.Figures)
+			figure := strings.TrimPrefix(write.New, "_")
+			if len(figure) == 0 {
+				figure = none
+			}
+			if figure != expected[i] {
+				return verification, fmt.Errorf("%w: figure %d is %q, not %q", ErrUniversalMismatch, i, figure, expected[i])
+			}
+			verification.Figures = append(verification.Figures, figure)
+			verification.Moves = append(verification.Moves, moves)
+		}
+		um.tapeWrites = um.tapeWrites[:0]
+	}
+	return verification, nil
+}
+
+// Returns the amount of moves `U` had made when it showed the last figure
+func (v UniversalVerification) lastMoves() int {
+	if len(v.Moves) == 0 {
+		return 0
+	}
+	return v.Moves[len(v.Moves)-1]
+}
+
 // Rather than using Turing's original `show` m-function, we create our own version
 // that is capable of printing all characters the Machine requires (not just `0` and `1`).
 func getEnhancedShow(symbolMap SymbolMap) []MConfiguration {
 	enhancedShow := []MConfiguration{}
 
 	// First four `show` MConfigurations are valid
-	enhancedShow = append(enhancedShow, show[0:4]...)
-
-	// Pick up where `show` left off...
-	for symbolKey, symbolValue := range symbolMap {
-		symbolNumber, _ := strconv.Atoi(symbolKey[1:])
-		// The blank symbol (S0) is `sh3`, and so on.
-		showNumber := symbolNumber + 3
-		showName := fmt.Sprintf("sh%d", showNumber)
-
-		// To continue our `enhancedShow` MConfigurations move to the next afterwards,
-		// unless it is the last symbol.
-		var nextShowName string
-		if symbolNumber >= len(symbolMap)-1 {
-			nextShowName = "inst"
-		} else {
-			nextShowName = fmt.Sprintf("sh%d", showNumber+1)
-		}
+	enhancedShow = append(enhancedShow, cloneMConfigurations(show[0:4])...)
 
-		// Turing's convention is that F-squares do not contain blanks
-		// unless they are the end of the tape. This poses a problem for our
-		// `enhancedShow` MFunction, which would like to show blanks, etc.
-		// In addition, sometimes we might be simulating a Machine that prints
-		// `e`, etc. or other characters that the Universal Machine itself uses.
-		// To combat this, we prepend "shown" values with `_` (underscore).
-		// The `CondensedTapeString` will remove this prepended underscore.
-		printSymbol := fmt.Sprintf("pe2(inst, _%s, :)", symbolValue)
-
-		enhancedShow = append(enhancedShow, []MConfiguration{
-			{showName, []string{"C"}, []string{"R", "R"}, nextShowName},
-			{showName, []string{"!C", " "}, []string{}, printSymbol},
-		}...)
+	// Pick up where `show` left off, with one `sh` m-configuration per symbol.
+	// The blank symbol (S0) is `sh3`, and so on.
+	symbols := []string{}
+	for _, symbol := range standardSymbols(symbolMap) {
+		symbols = append(symbols, symbolMap[symbol])
 	}
+	loops := []TemplateLoop{{Var: "s", Values: symbols, Index: "i"}}
+
+	// Turing's convention is that F-squares do not contain blanks
+	// unless they are the end of the tape. This poses a problem for our
+	// `enhancedShow` MFunction, which would like to show blanks, etc.
+	// In addition, sometimes we might be simulating a Machine that prints
+	// `e`, etc. or other characters that the Universal Machine itself uses.
+	// To combat this, we prepend "shown" values with `_` (underscore).
+	// The `CondensedTapeString` will remove this prepended underscore.
+	generated, _ := Template{
+		Constants: map[string]string{"last": strconv.Itoa(len(symbols) - 1)},
+		Rules: []TemplateRule{
+			// To continue our `enhancedShow` MConfigurations move to the next afterwards,
+			// unless it is the last symbol.
+			{
+				Loops:          loops,
+				Conditions:     []TemplateCondition{{"{i}", "<", "{last}"}},
+				MConfiguration: MConfiguration{"sh{i+3}", []string{"C"}, []string{"R", "R"}, "sh{i+4}"},
+			},
+			{
+				Loops:          loops,
+				Conditions:     []TemplateCondition{{"{i}", "==", "{last}"}},
+				MConfiguration: MConfiguration{"sh{i+3}", []string{"C"}, []string{"R", "R"}, "inst"},
+			},
+			{
+				Loops:          loops,
+				MConfiguration: MConfiguration{"sh{i+3}", []string{"!C", " "}, []string{}, "pe2(inst, _{s}, :)"},
+			},
+		},
+	}.Expand()
 
-	return enhancedShow
+	return append(enhancedShow, generated...)
 }
 
 // Helper function to isolate the computed sequence between the colons
@@ -236,3 +351,37 @@ func (m *Machine) TapeStringFromUniversalMachine() string {
 	}
 	return tapeString.String()
 }
+
+// Helper function to isolate the complete configurations of `M` written by the Universal Machine, in
+// their encoded form (i.e. `DAD` is `M` in m-configuration `DA` scanning a blank). Each is written
+// between colons, and only those that have been completely written are returned.
+func (m *Machine) CompleteConfigurationsFromUniversalMachine() []string {
+	completeConfigurations := []string{}
+
+	var started bool
+	var skip bool
+	var current strings.Builder
+	for _, square := range m.tape {
+		if !started {
+			if square == "::" {
+				started = true
+				skip = true
+			}
+			continue
+		}
+		if skip {
+			skip = !skip
+			continue
+		}
+		if square == ":" {
+			if strings.HasPrefix(current.String(), "D") {
+				completeConfigurations = append(completeConfigurations, current.String())
+			}
+			current.Reset()
+		} else {
+			current.WriteString(square)
+		}
+		skip = !skip
+	}
+	return completeConfigurations
+}
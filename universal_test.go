@@ -1,7 +1,10 @@
 package turing
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
 )
 
 func TestUniversalMachineExample1(t *testing.T) {
@@ -21,7 +24,7 @@ func TestUniversalMachineExample1(t *testing.T) {
 
 	// Check Machine Tape
 	m.MoveN(50)
-	checkTape(t, m.TapeString(), expected)
+	turingtest.TapePrefix(t, m.TapeString(), expected)
 
 	// Check Universal Machine Tape
 	um := NewMachine(NewUniversalMachine(UniversalMachineInput{
@@ -29,5 +32,135 @@ func TestUniversalMachineExample1(t *testing.T) {
 		SymbolMap:           st.SymbolMap,
 	}))
 	um.MoveN(500000)
-	checkTape(t, um.TapeStringFromUniversalMachine(), expected)
+	turingtest.TapePrefix(t, um.TapeStringFromUniversalMachine(), expected)
+}
+
+func TestUniversalMachineCompleteConfigurations(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	st := NewStandardTable(input)
+
+	// `b` scanning a blank, `0` followed by `c` scanning a blank, and so on
+	expected := []string{"DAD", "DCDAAD", "DCDDAAAD", "DCDDCCDAAAAD"}
+
+	for _, omitFigures := range []bool{false, true} {
+		um := NewMachine(NewUniversalMachine(UniversalMachineInput{
+			StandardDescription: st.StandardDescription,
+			SymbolMap:           st.SymbolMap,
+			OmitFigures:         omitFigures,
+		}))
+		um.MoveN(100000)
+		completeConfigurations := um.CompleteConfigurationsFromUniversalMachine()
+		if len(completeConfigurations) < len(expected) {
+			t.Fatalf("got %d complete configurations, want at least %d", len(completeConfigurations), len(expected))
+		}
+		for i, completeConfiguration := range expected {
+			if completeConfigurations[i] != completeConfiguration {
+				t.Errorf("got %s, want %s", completeConfigurations[i], completeConfiguration)
+			}
+		}
+		if omitFigures && len(um.TapeStringFromUniversalMachine()) != 0 {
+			t.Errorf("got figures %q, want none", um.TapeStringFromUniversalMachine())
+		}
+	}
+}
+
+func TestUniversalMachineOriginalShow(t *testing.T) {
+	input := MachineInput{
+		MConfigurations: []MConfiguration{
+			{"b", []string{" "}, []string{"P0", "R"}, "c"},
+			{"c", []string{" "}, []string{"R"}, "e"},
+			{"e", []string{" "}, []string{"P1", "R"}, "k"},
+			{"k", []string{" "}, []string{"R"}, "b"},
+		},
+	}
+	st := NewStandardTable(input)
+
+	completeConfigurations := map[UniversalMachineShow][]string{}
+	for _, show := range []UniversalMachineShow{EnhancedShow, OriginalShow} {
+		um := NewMachine(NewUniversalMachine(UniversalMachineInput{
+			StandardDescription: st.StandardDescription,
+			SymbolMap:           st.SymbolMap,
+			Show:                show,
+		}))
+		um.MoveN(200000)
+		completeConfigurations[show] = um.CompleteConfigurationsFromUniversalMachine()
+
+		// Turing's `show` only prints `0` and `1`, not the blanks `M` prints
+		if show == OriginalShow {
+			turingtest.TapePrefix(t, um.TapeStringFromUniversalMachine(), "0101")
+		}
+	}
+
+	// Only the figures differ, so the complete configurations are the same
+	enhanced, original := completeConfigurations[EnhancedShow], completeConfigurations[OriginalShow]
+	n := min(len(enhanced), len(original))
+	if n < 4 || !slices.Equal(enhanced[:n], original[:n]) {
+		t.Errorf("got %v and %v, want the same complete configurations", enhanced, original)
+	}
+}
+
+func TestUniversalMachineStandardTable(t *testing.T) {
+	symbolMap := SymbolMap{"S0": " ", "S1": "0", "S2": "1"}
+	st := UniversalMachineStandardTable(symbolMap)
+
+	reconstructed, err := NewMachineFromDescriptionNumber(st.DescriptionNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toStandardDescription(reconstructed) != st.StandardDescription {
+		t.Error("want the reconstructed machine to have the same S.D.")
+	}
+
+	// `U` starts in `b`, which by convention is q1
+	if st.MachineInput.StartingMConfiguration != "q1" || reconstructed.StartingMConfiguration != "q1" {
+		t.Errorf("got starting m-configurations %q and %q, want q1", st.MachineInput.StartingMConfiguration, reconstructed.StartingMConfiguration)
+	}
+}
+
+func TestVerifyUniversal(t *testing.T) {
+	t.Run("Example1", func(t *testing.T) {
+		verification, err := VerifyUniversal(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P0", "R"}, "c"},
+				{"c", []string{" "}, []string{"R"}, "e"},
+				{"e", []string{" "}, []string{"P1", "R"}, "k"},
+				{"k", []string{" "}, []string{"R"}, "b"},
+			},
+		}, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(verification.Figures, []string{"0", " ", "1", " "}) {
+			t.Errorf("got figures %q, want \"0 1 \"", verification.Figures)
+		}
+		// Each complete configuration is longer than the last, so `U` takes longer to show each figure
+		for i := 2; i < len(verification.Moves); i++ {
+			if verification.Moves[i]-verification.Moves[i-1] <= verification.Moves[i-1]-verification.Moves[i-2] {
+				t.Errorf("got moves %v, want the moves between figures to increase", verification.Moves)
+			}
+		}
+	})
+
+	t.Run("Halts", func(t *testing.T) {
+		verification, err := VerifyUniversal(MachineInput{
+			MConfigurations: []MConfiguration{
+				{"b", []string{" "}, []string{"P1", "R", "P0"}, "halt"},
+			},
+			PossibleSymbols:        []string{"0", "1"},
+			HaltingMConfigurations: []string{"halt"},
+		}, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(verification.Figures, []string{"1", "0"}) {
+			t.Errorf("got figures %q, want \"10\"", verification.Figures)
+		}
+	})
 }
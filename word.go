@@ -0,0 +1,94 @@
+package turing
+
+type (
+	// Describes how a word is written onto the tape before a decider runs
+	WordOptions struct {
+		// The symbols words are written in. Symbols may be longer than one character, in which
+		// case the longest matching symbol is used. If empty, every character is a symbol.
+		InputAlphabet []string
+
+		// If provided, this symbol is written immediately before the word
+		LeftEndMarker string
+
+		// If provided, this symbol is written immediately after the word
+		RightEndMarker string
+
+		// The amount of blank squares between the left end marker (if any) and the word
+		Offset int
+
+		// If `true`, the word is written on every other square (Turing's F-squares), leaving
+		// blank squares in between for the machine's own marks.
+		Spaced bool
+	}
+
+	// The result of running a decider on a word
+	WordResult struct {
+		// Whether the machine accepted, rejected, or timed out
+		Decision Decision
+
+		// Convenience for `Decision == Accept`
+		Accepted bool
+
+		// The amount of moves the machine made
+		Moves int
+
+		// The tape once the machine stopped
+		Tape Tape
+	}
+)
+
+// Writes the word onto the tape (as described by the options), runs the machine, and reports
+// whether the word was accepted along with the final tape. The machine begins by scanning the
+// leftmost square written. An error wrapping `ErrNotInAlphabet` is returned if the word cannot be
+// written in the input alphabet.
+func (d DeciderInput) RunWord(word string, options WordOptions) (WordResult, error) {
+	symbols, err := options.split(word)
+	if err != nil {
+		return WordResult{}, err
+	}
+
+	m, decision := d.run(options.tape(symbols, d.noneSymbol()))
+	return WordResult{
+		Decision: decision,
+		Accepted: decision == Accept,
+		Moves:    m.moves,
+		Tape:     m.Tape(),
+	}, nil
+}
+
+// Splits the word into symbols of the input alphabet
+func (o WordOptions) split(word string) ([]string, error) {
+	return EncodeString(word, o.InputAlphabet)
+}
+
+// Returns the tape with the symbols of the word placed on it
+func (o WordOptions) tape(symbols []string, noneSymbol string) Tape {
+	tape := Tape{}
+	if len(o.LeftEndMarker) != 0 {
+		tape = append(tape, o.LeftEndMarker)
+	}
+	for i := 0; i < o.Offset; i++ {
+		tape = append(tape, noneSymbol)
+	}
+	for i, symbol := range symbols {
+		if o.Spaced && i > 0 {
+			tape = append(tape, noneSymbol)
+		}
+		tape = append(tape, symbol)
+	}
+	if len(o.RightEndMarker) != 0 {
+		if o.Spaced && len(symbols) > 0 {
+			tape = append(tape, noneSymbol)
+		}
+		tape = append(tape, o.RightEndMarker)
+	}
+	return tape
+}
+
+// Returns the None symbol of the decider
+func (d DeciderInput) noneSymbol() string {
+	if len(d.NoneSymbol) == 0 {
+		return none
+	}
+	return d.NoneSymbol
+}
@@ -0,0 +1,83 @@
+package turing
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/planetlambert/turing/turingtest"
+)
+
+// Recognizes {0^n 1^n}. Words outside the language halt without matching an m-configuration.
+var zerosThenOnes = DeciderInput{
+	MachineInput: MachineInput{
+		MConfigurations: []MConfiguration{
+			{"q0", []string{"0"}, []string{"PX", "R"}, "q1"},
+			{"q0", []string{"Y"}, []string{"R"}, "q3"},
+			{"q0", []string{" "}, []string{}, "accept"},
+			{"q1", []string{"0", "Y"}, []string{"R"}, "q1"},
+			{"q1", []string{"1"}, []string{"PY", "L"}, "q2"},
+			{"q2", []string{"0", "Y"}, []string{"L"}, "q2"},
+			{"q2", []string{"X"}, []string{"R"}, "q0"},
+			{"q3", []string{"Y"}, []string{"R"}, "q3"},
+			{"q3", []string{" "}, []string{}, "accept"},
+		},
+		PossibleSymbols: []string{"0", "1", "X", "Y"},
+	},
+	AcceptMConfigurations: []string{"accept"},
+}
+
+func TestRunWordZerosThenOnes(t *testing.T) {
+	for word, expected := range map[string]bool{
+		"":       true,
+		"01":     true,
+		"0011":   true,
+		"000111": true,
+		"0":      false,
+		"10":     false,
+		"011":    false,
+		"0101":   false,
+	} {
+		t.Run(word, func(t *testing.T) {
+			result, err := zerosThenOnes.RunWord(word, WordOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Accepted != expected {
+				t.Errorf("got %s, want accepted to be %t", result.Decision, expected)
+			}
+		})
+	}
+
+	result, _ := zerosThenOnes.RunWord("0011", WordOptions{})
+	turingtest.TapePrefix(t, strings.Join(result.Tape, ""), "XXYY")
+}
+
+func TestRunWordInputAlphabet(t *testing.T) {
+	options := WordOptions{InputAlphabet: []string{"a", "ab", "c"}}
+	symbols, err := options.split("abac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(symbols, []string{"ab", "a", "c"}) {
+		t.Errorf("got %v, want [ab a c]", symbols)
+	}
+
+	if _, err := zerosThenOnes.RunWord("012", WordOptions{InputAlphabet: []string{"0", "1"}}); !errors.Is(err, ErrNotInAlphabet) {
+		t.Errorf("got %v, want ErrNotInAlphabet", err)
+	}
+}
+
+func TestRunWordPlacement(t *testing.T) {
+	options := WordOptions{
+		LeftEndMarker:  "<",
+		RightEndMarker: ">",
+		Offset:         1,
+		Spaced:         true,
+	}
+	tape := options.tape([]string{"a", "b"}, none)
+	if !reflect.DeepEqual(tape, Tape{"<", " ", "a", " ", "b", " ", ">"}) {
+		t.Errorf("got %q", tape)
+	}
+}